@@ -4,6 +4,7 @@ import (
 	"app-assets-generator/pkg/color"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -12,6 +13,21 @@ var (
 	colorInput    string
 	colorOutput   string
 	colorPlatform string
+	colorCatalog  string
+	colorFolder   string
+	
+	colorSwiftOutput      string
+	colorKotlinOutput     string
+	colorKotlinPackage    string
+	colorAndroidNamespace string
+	colorAndroidTheme     string
+	
+	colorDeriveDark       bool
+	colorDarkMinLightness float64
+	colorDarkMaxLightness float64
+	colorDerivedReport    string
+	
+	colorSpace string
 )
 
 // colorCmd 颜色生成命令
@@ -26,7 +42,10 @@ var colorCmd = &cobra.Command{
   app-assets-generator color --input colors.yaml --output output/android --platform android
   
   # 同时生成两个平台
-  app-assets-generator color --input colors.yaml --output output/ --platform all`,
+  app-assets-generator color --input colors.yaml --output output/ --platform all
+  
+  # 合并到已有的资源目录
+  app-assets-generator color --input colors.yaml --catalog App/Assets.xcassets --folder Colors --platform ios`,
 	Run: runColorCommand,
 }
 
@@ -36,12 +55,23 @@ func init() {
 	
 	// 添加flag
 	colorCmd.Flags().StringVarP(&colorInput, "input", "i", "", "输入的YAML配置文件路径 (必需)")
-	colorCmd.Flags().StringVarP(&colorOutput, "output", "o", "", "输出目录路径 (必需)")
+	colorCmd.Flags().StringVarP(&colorOutput, "output", "o", "", "输出目录路径 (仅生成iOS且指定--catalog时可省略)")
 	colorCmd.Flags().StringVarP(&colorPlatform, "platform", "p", "all", "目标平台 (ios/android/all)")
+	colorCmd.Flags().StringVar(&colorCatalog, "catalog", "", "已有的Assets.xcassets路径，iOS资源将合并写入该目录")
+	colorCmd.Flags().StringVar(&colorFolder, "folder", "", "资源目录内的子文件夹 (配合--catalog使用，如 Colors)")
+	colorCmd.Flags().StringVar(&colorSwiftOutput, "swift", "", "生成Swift颜色访问器的文件路径，如 Sources/Colors.swift")
+	colorCmd.Flags().StringVar(&colorKotlinOutput, "kotlin", "", "生成Kotlin(Compose)颜色访问器的文件路径，如 ui/AppColors.kt")
+	colorCmd.Flags().StringVar(&colorKotlinPackage, "kotlin-package", "", "Kotlin访问器的包名 (配合--kotlin使用)")
+	colorCmd.Flags().StringVar(&colorAndroidNamespace, "android-namespace", "", "R类所在的包名，默认与--kotlin-package相同")
+	colorCmd.Flags().StringVar(&colorAndroidTheme, "android-theme-prefix", color.DefaultAndroidThemePrefix, "Android主题覆盖样式的名称前缀，如 App -> ThemeOverlay.App.<主题>")
+	colorCmd.Flags().BoolVar(&colorDeriveDark, "derive-dark", false, "为缺少dark值的颜色自动推导深色值（OKLCH中反转亮度）")
+	colorCmd.Flags().Float64Var(&colorDarkMinLightness, "dark-min-lightness", color.DefaultDarkDerivation.MinLightness, "推导深色值的最小OKLCH亮度 (0-1)")
+	colorCmd.Flags().Float64Var(&colorDarkMaxLightness, "dark-max-lightness", color.DefaultDarkDerivation.MaxLightness, "推导深色值的最大OKLCH亮度 (0-1)")
+	colorCmd.Flags().StringVar(&colorDerivedReport, "derived-report", "", "将推导出的深色值写入YAML文件供设计确认")
+	colorCmd.Flags().StringVar(&colorSpace, "color-space", string(color.ColorSpaceSRGB), "颜色表达式的默认计算空间 (srgb/oklch)")
 	
 	// 标记必需的flag
 	colorCmd.MarkFlagRequired("input")
-	colorCmd.MarkFlagRequired("output")
 }
 
 func runColorCommand(cmd *cobra.Command, args []string) {
@@ -55,11 +85,35 @@ func runColorCommand(cmd *cobra.Command, args []string) {
 		exitWithError("无效的平台参数: %s (必须是 ios/android/all)", colorPlatform)
 	}
 	
+	// 验证输出参数：指定--catalog时iOS可以不需要--output
+	if colorOutput == "" && (colorCatalog == "" || colorPlatform != "ios") {
+		exitWithError("必须指定输出目录 --output")
+	}
+	
+	// 验证颜色空间参数
+	space, err := color.ParseColorSpace(colorSpace)
+	if err != nil {
+		exitWithError("%v", err)
+	}
+	
 	// 创建生成器
-	generator := color.NewGenerator(colorInput, colorOutput)
+	generator := color.NewGenerator(colorInput, colorOutput, color.Options{
+		CatalogPath:        colorCatalog,
+		Folder:             colorFolder,
+		SwiftOutput:        colorSwiftOutput,
+		KotlinOutput:       colorKotlinOutput,
+		KotlinPackage:      colorKotlinPackage,
+		AndroidNamespace:   colorAndroidNamespace,
+		AndroidThemePrefix: colorAndroidTheme,
+		DeriveDark:         colorDeriveDark,
+		DarkDerivation: color.DarkDerivation{
+			MinLightness: colorDarkMinLightness,
+			MaxLightness: colorDarkMaxLightness,
+		},
+		ColorSpace: space,
+	})
 	
 	// 根据平台生成资源
-	var err error
 	switch colorPlatform {
 	case "ios":
 		fmt.Println("正在生成iOS颜色资源...")
@@ -80,5 +134,49 @@ func runColorCommand(cmd *cobra.Command, args []string) {
 		exitWithError("生成失败: %v", err)
 	}
 	
-	fmt.Printf("✅ 颜色资源生成成功！输出目录: %s\n", colorOutput)
-}
\ No newline at end of file
+	// 列出自动推导的深色值供设计确认
+	if derived := generator.DerivedDarkColors(); len(derived) > 0 {
+		fmt.Printf("🌓 自动推导了 %d 个深色值，请设计确认:\n", len(derived))
+		for _, item := range derived {
+			fmt.Printf("   %s\n", item.String())
+		}
+		if colorDerivedReport != "" {
+			if err := color.WriteDerivedReport(colorDerivedReport, derived); err != nil {
+				exitWithError("写入推导报告失败: %v", err)
+			}
+			fmt.Printf("   推导报告: %s\n", colorDerivedReport)
+		}
+	}
+	
+	for _, warning := range generator.Warnings() {
+		printWarning("%s:%d:%d: %s", warning.File, warning.Line, warning.Column, warning.Message)
+	}
+	
+	if colorOutput != "" {
+		fmt.Printf("✅ 颜色资源生成成功！输出目录: %s\n", colorOutput)
+	}
+	printColorBrands(generator)
+	if colorCatalog != "" && colorPlatform != "android" {
+		fmt.Printf("✅ iOS颜色资源已合并到: %s\n", colorCatalog)
+	}
+}
+
+// printColorBrands 列出每个品牌的输出位置
+func printColorBrands(generator *color.Generator) {
+	brands, err := generator.Brands()
+	if err != nil || len(brands) == 0 {
+		return
+	}
+	
+	fmt.Printf("🏷  共 %d 个品牌，共享资源写入main源码集:\n", len(brands))
+	for _, name := range brands {
+		var outputs []string
+		if colorPlatform != "android" {
+			outputs = append(outputs, generator.BrandCatalogPath(name))
+		}
+		if colorPlatform != "ios" {
+			outputs = append(outputs, generator.AndroidResPath(name))
+		}
+		fmt.Printf("   %s: %s\n", name, strings.Join(outputs, ", "))
+	}
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"app-assets-generator/pkg/color"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	colorExportInput   string
+	colorExportOutput  string
+	colorExportFormats []string
+	colorExportThemes  []string
+	colorExportName    string
+)
+
+// colorExportCmd 调色板导出命令
+var colorExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "导出设计工具使用的调色板",
+	Long: `将颜色配置导出为设计工具使用的调色板，每个主题一个文件：
+- ase: Adobe Swatch Exchange（不支持透明度）
+- gpl: GIMP/Inkscape调色板（不支持透明度，alpha以注释保留）
+- clr: macOS颜色列表（NSColorList）`,
+	Example: `  app-assets-generator color export --input colors.yaml --output palettes/
+  app-assets-generator color export --input colors.yaml --output palettes/ --format ase,clr --theme dark`,
+	Run: runColorExportCommand,
+}
+
+func init() {
+	// 注册为color的子命令
+	colorCmd.AddCommand(colorExportCmd)
+
+	colorExportCmd.Flags().StringVarP(&colorExportInput, "input", "i", "", "输入的YAML配置文件路径 (必需)")
+	colorExportCmd.Flags().StringVarP(&colorExportOutput, "output", "o", "", "输出目录路径 (必需)")
+	colorExportCmd.Flags().StringSliceVarP(&colorExportFormats, "format", "f", color.ExportFormats, "导出格式 (ase/gpl/clr)")
+	colorExportCmd.Flags().StringSliceVarP(&colorExportThemes, "theme", "t", []string{"light", "dark"}, "导出的主题 (default/light/dark)")
+	colorExportCmd.Flags().StringVar(&colorExportName, "name", "", "调色板名称，默认使用输入文件名")
+
+	colorExportCmd.MarkFlagRequired("input")
+	colorExportCmd.MarkFlagRequired("output")
+}
+
+func runColorExportCommand(cmd *cobra.Command, args []string) {
+	// 验证输入文件是否存在
+	if _, err := os.Stat(colorExportInput); os.IsNotExist(err) {
+		exitWithError("输入文件不存在: %s", colorExportInput)
+	}
+
+	name := colorExportName
+	if name == "" {
+		name = strings.TrimSuffix(filepath.Base(colorExportInput), filepath.Ext(colorExportInput))
+	}
+
+	generator := color.NewGenerator(colorExportInput, colorExportOutput, color.Options{})
+	written, err := generator.Export(name, colorExportFormats, colorExportThemes)
+	if err != nil {
+		exitWithError("导出失败: %v", err)
+	}
+
+	for _, warning := range generator.Warnings() {
+		printWarning("%s:%d:%d: %s", warning.File, warning.Line, warning.Column, warning.Message)
+	}
+
+	fmt.Printf("✅ 调色板导出成功！共 %d 个文件:\n", len(written))
+	for _, filePath := range written {
+		fmt.Printf("   %s\n", filePath)
+	}
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"app-assets-generator/pkg/color"
+	"app-assets-generator/pkg/figma"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	figmaFileKey    string
+	figmaToken      string
+	figmaAPIURL     string
+	figmaCollection string
+	figmaLightMode  string
+	figmaDarkMode   string
+
+	colorPullOutput  string
+	colorPushInput   string
+	colorPushDryRun  bool
+	colorPushPayload string
+)
+
+// colorPullCmd 从Figma拉取颜色变量
+var colorPullCmd = &cobra.Command{
+	Use:   "pull",
+	Short: "从Figma Variables拉取颜色并写入colors.yaml",
+	Long: `通过Figma Variables REST API读取颜色变量并写入colors.yaml：
+- 变量集合的默认模式映射为default，名称包含light/dark的模式映射为light/dark
+- 别名变量按相同主题递归解析为具体颜色
+- 变量名称转换为颜色名称，如 Color/Primary 500 -> color_primary_500`,
+	Example: `  FIGMA_TOKEN=xxx app-assets-generator color pull --file-key AbC123 --output colors.yaml
+  app-assets-generator color pull --file-key AbC123 --output colors.yaml --api-url http://localhost:8080`,
+	Run: runColorPullCommand,
+}
+
+// colorPushCmd 预览推送到Figma的变更
+var colorPushCmd = &cobra.Command{
+	Use:   "push",
+	Short: "预览将colors.yaml推送到Figma Variables时的变更",
+	Long: `对比colors.yaml与Figma中的颜色变量，列出推送时将在Figma端发生的变更。
+目前只支持dry-run，可以使用 --payload 导出对应的API请求体。`,
+	Example: `  app-assets-generator color push --file-key AbC123 --input colors.yaml --dry-run
+  app-assets-generator color push --file-key AbC123 --input colors.yaml --payload figma-changes.json`,
+	Run: runColorPushCommand,
+}
+
+func init() {
+	// 注册为color的子命令
+	colorCmd.AddCommand(colorPullCmd)
+	colorCmd.AddCommand(colorPushCmd)
+
+	for _, command := range []*cobra.Command{colorPullCmd, colorPushCmd} {
+		command.Flags().StringVar(&figmaFileKey, "file-key", "", "Figma文件的key (必需)")
+		command.Flags().StringVar(&figmaToken, "token", "", "Figma个人访问令牌，默认读取环境变量FIGMA_TOKEN")
+		command.Flags().StringVar(&figmaAPIURL, "api-url", figma.DefaultBaseURL, "Figma API地址，可指向本地mock")
+		command.Flags().StringVar(&figmaCollection, "collection", "", "只处理指定名称的变量集合")
+		command.Flags().StringVar(&figmaLightMode, "light-mode", "", "浅色模式名称，默认自动识别名称包含light的模式")
+		command.Flags().StringVar(&figmaDarkMode, "dark-mode", "", "深色模式名称，默认自动识别名称包含dark的模式")
+		command.MarkFlagRequired("file-key")
+	}
+
+	colorPullCmd.Flags().StringVarP(&colorPullOutput, "output", "o", "colors.yaml", "输出的YAML配置文件路径")
+
+	colorPushCmd.Flags().StringVarP(&colorPushInput, "input", "i", "", "输入的YAML配置文件路径 (必需)")
+	colorPushCmd.Flags().BoolVar(&colorPushDryRun, "dry-run", true, "只显示变更，不修改Figma（目前只支持dry-run）")
+	colorPushCmd.Flags().StringVar(&colorPushPayload, "payload", "", "将对应的API请求体写入JSON文件")
+	colorPushCmd.MarkFlagRequired("input")
+}
+
+// fetchFigmaVariables 读取Figma变量
+func fetchFigmaVariables() *figma.LocalVariablesResponse {
+	token := figmaToken
+	if token == "" {
+		token = os.Getenv("FIGMA_TOKEN")
+	}
+	if token == "" {
+		exitWithError("缺少Figma访问令牌，请使用 --token 或设置环境变量FIGMA_TOKEN")
+	}
+
+	client := figma.NewClient(figmaAPIURL, token)
+	response, err := client.LocalVariables(figmaFileKey)
+	if err != nil {
+		exitWithError("读取Figma变量失败: %v", err)
+	}
+	return response
+}
+
+// figmaOptions 模式映射和集合过滤配置
+func figmaOptions() figma.Options {
+	return figma.Options{
+		Collection: figmaCollection,
+		LightMode:  figmaLightMode,
+		DarkMode:   figmaDarkMode,
+	}
+}
+
+func runColorPullCommand(cmd *cobra.Command, args []string) {
+	response := fetchFigmaVariables()
+
+	colors, err := figma.ToColors(response, figmaOptions())
+	if err != nil {
+		exitWithError("转换Figma变量失败: %v", err)
+	}
+	if len(colors) == 0 {
+		exitWithError("Figma文件中没有找到颜色变量")
+	}
+
+	if err := color.WriteYAML(colorPullOutput, colors); err != nil {
+		exitWithError("写入颜色配置失败: %v", err)
+	}
+
+	fmt.Printf("✅ 已从Figma拉取 %d 个颜色: %s\n", len(colors), colorPullOutput)
+}
+
+func runColorPushCommand(cmd *cobra.Command, args []string) {
+	if !colorPushDryRun {
+		exitWithError("目前只支持 --dry-run")
+	}
+
+	colors, err := color.ParseYAML(colorPushInput)
+	if err != nil {
+		exitWithError("解析颜色配置失败: %v", err)
+	}
+
+	response := fetchFigmaVariables()
+	plan, err := figma.PlanPush(response, colors, figmaOptions())
+	if err != nil {
+		exitWithError("计算变更失败: %v", err)
+	}
+
+	if len(plan.Changes) == 0 {
+		fmt.Println("✅ Figma中的颜色变量已是最新，没有需要推送的变更")
+	} else {
+		fmt.Printf("🔍 [dry-run] 推送将在Figma端产生 %d 个变更:\n", len(plan.Changes))
+		for _, change := range plan.Changes {
+			fmt.Printf("   %s\n", change.String())
+		}
+		if len(plan.Payload.Variables) > 0 {
+			fmt.Printf("   新建的变量将放在集合: %s\n", plan.Collection)
+		}
+	}
+	if len(plan.FigmaOnly) > 0 {
+		fmt.Printf("ℹ️  以下 %d 个变量只存在于Figma中，不会被删除:\n", len(plan.FigmaOnly))
+		for _, name := range plan.FigmaOnly {
+			fmt.Printf("   %s\n", name)
+		}
+	}
+
+	if colorPushPayload != "" {
+		data, err := json.MarshalIndent(plan.Payload, "", "  ")
+		if err != nil {
+			exitWithError("序列化请求体失败: %v", err)
+		}
+		if err := os.WriteFile(colorPushPayload, append(data, '\n'), 0644); err != nil {
+			exitWithError("写入请求体失败: %v", err)
+		}
+		fmt.Printf("   请求体: %s\n", colorPushPayload)
+	}
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"app-assets-generator/internal/diag"
+	"app-assets-generator/pkg/color"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	colorImportTailwind  string
+	colorImportCSS       string
+	colorImportCSSPrefix string
+	colorImportOutput    string
+)
+
+// colorImportCmd 从Web项目导入颜色
+var colorImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "从Tailwind配置和CSS自定义属性导入颜色",
+	Long: `读取Web项目的颜色并写入colors.yaml，使移动端和Web共用同一份颜色配置：
+- Tailwind: theme.colors导出的JSON，嵌套的键以下划线连接（如 blue.500 -> blue_500），DEFAULT表示上一级名称
+- CSS: 以 --color- 开头的自定义属性，去掉前缀后作为颜色名称（如 --color-primary -> primary），
+  prefers-color-scheme: dark 媒体查询中的值映射为dark，支持var()引用
+
+同时指定两者时，CSS中的同名颜色覆盖Tailwind中的颜色。`,
+	Example: `  app-assets-generator color import --tailwind tailwind-colors.json --output colors.yaml
+  app-assets-generator color import --tailwind tailwind-colors.json --css tokens.css --output colors.yaml`,
+	Run: runColorImportCommand,
+}
+
+func init() {
+	// 注册为color的子命令
+	colorCmd.AddCommand(colorImportCmd)
+
+	colorImportCmd.Flags().StringVar(&colorImportTailwind, "tailwind", "", "Tailwind theme.colors导出的JSON文件")
+	colorImportCmd.Flags().StringVar(&colorImportCSS, "css", "", "包含颜色自定义属性的CSS文件")
+	colorImportCmd.Flags().StringVar(&colorImportCSSPrefix, "css-prefix", color.DefaultCSSPrefix, "导入的CSS自定义属性前缀")
+	colorImportCmd.Flags().StringVarP(&colorImportOutput, "output", "o", "colors.yaml", "输出的YAML配置文件路径")
+}
+
+func runColorImportCommand(cmd *cobra.Command, args []string) {
+	if colorImportTailwind == "" && colorImportCSS == "" {
+		exitWithError("请至少指定 --tailwind 或 --css")
+	}
+
+	colors := make(map[string]*color.ColorDefinition)
+	merge := func(imported map[string]*color.ColorDefinition, diagnostics diag.Diagnostics) {
+		for _, warning := range diagnostics {
+			printWarning("%s:%d:%d: %s", warning.File, warning.Line, warning.Column, warning.Message)
+		}
+
+		offset := len(colors)
+		for name, definition := range imported {
+			if _, exists := colors[name]; exists {
+				printWarning("颜色 %s 在Tailwind和CSS中都有定义，使用CSS中的值", name)
+			}
+			definition.Order += offset
+			colors[name] = definition
+		}
+	}
+
+	if colorImportTailwind != "" {
+		imported, diagnostics, err := color.ImportTailwind(colorImportTailwind)
+		if err != nil {
+			exitWithError("导入Tailwind颜色失败: %v", err)
+		}
+		merge(imported, diagnostics)
+	}
+	if colorImportCSS != "" {
+		imported, diagnostics, err := color.ImportCSS(colorImportCSS, colorImportCSSPrefix)
+		if err != nil {
+			exitWithError("导入CSS颜色失败: %v", err)
+		}
+		merge(imported, diagnostics)
+	}
+
+	if len(colors) == 0 {
+		exitWithError("没有找到可导入的颜色")
+	}
+
+	if err := color.WriteYAML(colorImportOutput, colors); err != nil {
+		exitWithError("写入颜色配置失败: %v", err)
+	}
+
+	fmt.Printf("✅ 已导入 %d 个颜色: %s\n", len(colors), colorImportOutput)
+}
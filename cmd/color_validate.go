@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"app-assets-generator/pkg/color"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var colorValidateStrict bool
+
+// colorValidateCmd 颜色配置校验命令
+var colorValidateCmd = &cobra.Command{
+	Use:   "validate <colors.yaml>...",
+	Short: "校验颜色配置文件",
+	Long: `校验颜色配置文件，一次性输出所有带 文件:行:列 位置信息的错误和警告。
+
+存在错误时退出码为1；使用 --strict 时警告也视为错误。`,
+	Example: `  app-assets-generator color validate colors.yaml
+  app-assets-generator color validate --strict colors.yaml brand.yaml`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  runColorValidateCommand,
+}
+
+func init() {
+	// 注册为color的子命令
+	colorCmd.AddCommand(colorValidateCmd)
+
+	colorValidateCmd.Flags().BoolVar(&colorValidateStrict, "strict", false, "将警告视为错误")
+}
+
+func runColorValidateCommand(cmd *cobra.Command, args []string) {
+	errorCount, warningCount := 0, 0
+
+	for _, filePath := range args {
+		_, diagnostics, err := color.ParseYAMLWithDiagnostics(filePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: error: %v\n", filePath, err)
+			errorCount++
+			continue
+		}
+
+		for _, diagnostic := range diagnostics {
+			fmt.Fprintln(os.Stderr, diagnostic.String())
+		}
+		errorCount += len(diagnostics.Errors())
+		warningCount += len(diagnostics.Warnings())
+	}
+
+	if errorCount > 0 || (colorValidateStrict && warningCount > 0) {
+		exitWithError("校验失败: %d 个错误, %d 个警告", errorCount, warningCount)
+	}
+
+	fmt.Printf("✅ 校验通过: %d 个错误, %d 个警告\n", errorCount, warningCount)
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"app-assets-generator/pkg/dimens"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	dimensInput    string
+	dimensOutput   string
+	dimensPlatform string
+
+	dimensSwiftOutput      string
+	dimensSwiftType        string
+	dimensKotlinOutput     string
+	dimensKotlinPackage    string
+	dimensAndroidNamespace string
+)
+
+// dimensCmd 尺寸生成命令
+var dimensCmd = &cobra.Command{
+	Use:   "dimens",
+	Short: "生成尺寸和间距资源",
+	Long: `从YAML配置文件生成iOS和Android平台的尺寸常量
+
+Android生成 values/dimens.xml（有平板值时另外生成 values-sw600dp/dimens.xml）和Compose访问器，
+iOS生成CGFloat常量的Swift枚举。尺寸之间可以引用并进行四则运算。`,
+	Example: `  # Android平台
+  app-assets-generator dimens --input dimens.yaml --output app/src/main/res --platform android
+
+  # iOS平台
+  app-assets-generator dimens --input dimens.yaml --swift Sources/Dimens.swift --platform ios
+
+  # 同时生成Compose访问器
+  app-assets-generator dimens --input dimens.yaml --output app/src/main/res --swift Sources/Dimens.swift \
+    --kotlin app/src/main/java/com/example/ui/AppDimens.kt --kotlin-package com.example.ui`,
+	Run: runDimensCommand,
+}
+
+func init() {
+	// 注册命令
+	rootCmd.AddCommand(dimensCmd)
+
+	// 添加flag
+	dimensCmd.Flags().StringVarP(&dimensInput, "input", "i", "", "输入的YAML配置文件路径 (必需)")
+	dimensCmd.Flags().StringVarP(&dimensOutput, "output", "o", "", "Android res目录路径 (生成Android时必需)")
+	dimensCmd.Flags().StringVarP(&dimensPlatform, "platform", "p", "all", "目标平台 (ios/android/all)")
+	dimensCmd.Flags().StringVar(&dimensSwiftOutput, "swift", "", "生成Swift尺寸常量的文件路径 (生成iOS时必需)，如 Sources/Dimens.swift")
+	dimensCmd.Flags().StringVar(&dimensSwiftType, "swift-type", dimens.DefaultSwiftType, "Swift枚举名称")
+	dimensCmd.Flags().StringVar(&dimensKotlinOutput, "kotlin", "", "生成Kotlin(Compose)尺寸访问器的文件路径，如 ui/AppDimens.kt")
+	dimensCmd.Flags().StringVar(&dimensKotlinPackage, "kotlin-package", "", "Kotlin访问器的包名 (配合--kotlin使用)")
+	dimensCmd.Flags().StringVar(&dimensAndroidNamespace, "android-namespace", "", "R类所在的包名，默认与--kotlin-package相同")
+
+	// 标记必需的flag
+	dimensCmd.MarkFlagRequired("input")
+}
+
+func runDimensCommand(cmd *cobra.Command, args []string) {
+	// 验证输入文件是否存在
+	if _, err := os.Stat(dimensInput); os.IsNotExist(err) {
+		exitWithError("输入文件不存在: %s", dimensInput)
+	}
+
+	// 验证平台参数
+	if dimensPlatform != "ios" && dimensPlatform != "android" && dimensPlatform != "all" {
+		exitWithError("无效的平台参数: %s (必须是 ios/android/all)", dimensPlatform)
+	}
+
+	// 验证输出参数
+	if dimensPlatform != "ios" && dimensOutput == "" {
+		exitWithError("生成Android尺寸必须指定输出目录 --output")
+	}
+	if dimensPlatform != "android" && dimensSwiftOutput == "" {
+		exitWithError("生成iOS尺寸必须指定Swift输出文件 --swift")
+	}
+
+	// 创建生成器
+	generator := dimens.NewGenerator(dimensInput, dimensOutput, dimens.Options{
+		SwiftOutput:      dimensSwiftOutput,
+		SwiftType:        dimensSwiftType,
+		KotlinOutput:     dimensKotlinOutput,
+		KotlinPackage:    dimensKotlinPackage,
+		AndroidNamespace: dimensAndroidNamespace,
+	})
+
+	// 根据平台生成资源
+	var err error
+	switch dimensPlatform {
+	case "ios":
+		fmt.Println("正在生成iOS尺寸常量...")
+		err = generator.GenerateIOS()
+	case "android":
+		fmt.Println("正在生成Android尺寸资源...")
+		err = generator.GenerateAndroid()
+	case "all":
+		fmt.Println("正在生成iOS尺寸常量...")
+		if err = generator.GenerateIOS(); err != nil {
+			exitWithError("生成iOS尺寸失败: %v", err)
+		}
+		fmt.Println("正在生成Android尺寸资源...")
+		err = generator.GenerateAndroid()
+	}
+
+	if err != nil {
+		exitWithError("生成失败: %v", err)
+	}
+
+	for _, warning := range generator.Warnings() {
+		printWarning("%s:%d:%d: %s", warning.File, warning.Line, warning.Column, warning.Message)
+	}
+
+	if dimensOutput != "" && dimensPlatform != "ios" {
+		fmt.Printf("✅ Android尺寸资源生成成功！输出目录: %s\n", dimensOutput)
+	}
+	if dimensPlatform != "android" {
+		fmt.Printf("✅ Swift尺寸常量生成成功！输出文件: %s\n", dimensSwiftOutput)
+	}
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"app-assets-generator/pkg/font"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	fontInput    string
+	fontOutput   string
+	fontPlatform string
+)
+
+// fontCmd 字体资源生成命令
+var fontCmd = &cobra.Command{
+	Use:   "font",
+	Short: "生成字体资源",
+	Long: `扫描字体目录中的TTF/OTF文件，为iOS和Android平台注册自定义字体
+
+字体家族、字重和样式从字体文件的name表和OS/2表中读取，不依赖文件名。
+Android将字体复制到 font/ 目录并生成 <font-family> XML，
+iOS复制字体文件并生成需要合并到Info.plist的UIAppFonts片段。
+同一家族中字重和样式相同的字体会作为错误报告。`,
+	Example: `  # Android平台，输出到res目录
+  app-assets-generator font --input fonts/ --output app/src/main/res --platform android
+
+  # iOS平台
+  app-assets-generator font --input fonts/ --output App/Fonts --platform ios
+
+  # 同时生成两个平台 (分别写入 <output>/ios 和 <output>/android)
+  app-assets-generator font --input fonts/ --output output/ --platform all`,
+	Run: runFontCommand,
+}
+
+func init() {
+	// 注册命令
+	rootCmd.AddCommand(fontCmd)
+
+	// 添加flag
+	fontCmd.Flags().StringVarP(&fontInput, "input", "i", "", "输入的字体目录路径 (必需)")
+	fontCmd.Flags().StringVarP(&fontOutput, "output", "o", "", "输出目录路径 (必需)")
+	fontCmd.Flags().StringVarP(&fontPlatform, "platform", "p", "all", "目标平台 (ios/android/all)")
+
+	// 标记必需的flag
+	fontCmd.MarkFlagRequired("input")
+	fontCmd.MarkFlagRequired("output")
+}
+
+func runFontCommand(cmd *cobra.Command, args []string) {
+	// 验证输入目录是否存在
+	if info, err := os.Stat(fontInput); os.IsNotExist(err) {
+		exitWithError("输入目录不存在: %s", fontInput)
+	} else if !info.IsDir() {
+		exitWithError("输入路径不是目录: %s", fontInput)
+	}
+
+	// 验证平台参数
+	if fontPlatform != "ios" && fontPlatform != "android" && fontPlatform != "all" {
+		exitWithError("无效的平台参数: %s (必须是 ios/android/all)", fontPlatform)
+	}
+
+	// 根据平台生成资源，all时两个平台分别写入子目录
+	var err error
+	switch fontPlatform {
+	case "ios":
+		fmt.Println("正在生成iOS字体资源...")
+		err = font.NewGenerator(fontInput, fontOutput).GenerateIOS()
+	case "android":
+		fmt.Println("正在生成Android字体资源...")
+		err = font.NewGenerator(fontInput, fontOutput).GenerateAndroid()
+	case "all":
+		fmt.Println("正在生成iOS字体资源...")
+		if err = font.NewGenerator(fontInput, filepath.Join(fontOutput, "ios")).GenerateIOS(); err != nil {
+			exitWithError("生成iOS字体资源失败: %v", err)
+		}
+		fmt.Println("正在生成Android字体资源...")
+		err = font.NewGenerator(fontInput, filepath.Join(fontOutput, "android")).GenerateAndroid()
+	}
+
+	if err != nil {
+		exitWithError("生成失败: %v", err)
+	}
+
+	// 再次扫描用于输出警告和字体列表（结果与生成时一致）
+	generator := font.NewGenerator(fontInput, fontOutput)
+	fonts, err := generator.Fonts()
+	if err != nil {
+		exitWithError("扫描字体失败: %v", err)
+	}
+	for _, warning := range generator.Warnings() {
+		printWarning("%s", warning)
+	}
+
+	fmt.Printf("✅ 字体资源生成成功！输出目录: %s\n", fontOutput)
+	printFontFamilies(fonts)
+	if fontPlatform != "android" {
+		fmt.Printf("📝 请将 %s 中的UIAppFonts合并到应用的Info.plist，并把字体文件加入应用target\n", font.PlistFileName)
+	}
+}
+
+// printFontFamilies 按家族列出字体，iOS中 UIFont(name:) 需要使用PostScript名称
+func printFontFamilies(fonts []*font.FontInfo) {
+	fmt.Println("🔤 字体家族:")
+	for i, f := range fonts {
+		if i == 0 || fonts[i-1].Family != f.Family {
+			fmt.Printf("   %s (@font/%s)\n", f.Family, font.FamilyResourceName(f.Family))
+		}
+		details := []string{fmt.Sprintf("%d %s", f.Weight, f.Style())}
+		if f.PostScriptName != "" {
+			details = append(details, "PostScript: "+f.PostScriptName)
+		}
+		fmt.Printf("     - %s: %s\n", filepath.Base(f.Path), strings.Join(details, ", "))
+	}
+}
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"app-assets-generator/pkg/icon"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	iconInput       string
+	iconOutput      string
+	iconPlatform    string
+	iconCatalog     string
+	iconName        string
+	iconLegacy      bool
+	iconBackground  string
+	iconAndroidName string
+	iconAdaptiveBg  string
+	iconColors      string
+	iconForeground  string
+	iconMonochrome  string
+)
+
+// iconCmd 应用图标生成命令
+var iconCmd = &cobra.Command{
+	Use:   "icon",
+	Short: "生成应用图标",
+	Long: `从一张1024×1024的母版（PNG或SVG）生成iOS和Android的应用图标
+
+iOS生成 AppIcon.appiconset，默认使用Xcode 14起的单一1024尺寸格式，--legacy 生成旧版的完整尺寸列表。
+Android生成 mipmap-*dpi/ic_launcher.png 和圆形的 ic_launcher_round.png。
+指定 --adaptive-background 时同时生成Android 8+的自适应图标 mipmap-anydpi-v26/ic_launcher.xml，
+背景层引用colors.yaml中的颜色，前景层和单色层（Android 13主题图标）缩放到108dp图层中央的72dp可见区域。
+母版必须是正方形且不小于1024像素；iOS图标不能包含透明区域，可以用 --background 合成到背景色上。`,
+	Example: `  # 同时生成两个平台
+  app-assets-generator icon --input icon.png --output output/ --platform all
+
+  # 写入已有的资源目录，生成旧版Xcode的完整尺寸列表
+  app-assets-generator icon --input icon.svg --catalog App/Assets.xcassets --platform ios --legacy
+
+  # 透明的母版合成到白色背景上
+  app-assets-generator icon --input icon.png --output app/src/main/res --platform android --background "#ffffff"
+
+  # 生成自适应图标和主题图标
+  app-assets-generator icon --input icon.png --output app/src/main/res --platform android \
+    --adaptive-background icon_background --colors colors.yaml --foreground logo.svg --monochrome logo_mono.svg`,
+	Run: runIconCommand,
+}
+
+func init() {
+	// 注册命令
+	rootCmd.AddCommand(iconCmd)
+
+	// 添加flag
+	iconCmd.Flags().StringVarP(&iconInput, "input", "i", "", "图标母版路径，1024×1024的PNG或SVG (必需)")
+	iconCmd.Flags().StringVarP(&iconOutput, "output", "o", "", "输出目录路径 (仅生成iOS且指定--catalog时可省略)")
+	iconCmd.Flags().StringVarP(&iconPlatform, "platform", "p", "all", "目标平台 (ios/android/all)")
+	iconCmd.Flags().StringVar(&iconCatalog, "catalog", "", "已有的Assets.xcassets路径，iOS图标集将写入该目录")
+	iconCmd.Flags().StringVar(&iconName, "name", icon.DefaultIconSetName, "iOS图标集名称")
+	iconCmd.Flags().BoolVar(&iconLegacy, "legacy", false, "iOS生成旧版Xcode需要的完整尺寸列表（默认只生成1024单一尺寸）")
+	iconCmd.Flags().StringVar(&iconBackground, "background", "", "透明区域合成到的背景色，如 #ffffff")
+	iconCmd.Flags().StringVar(&iconAndroidName, "android-name", icon.DefaultAndroidName, "Android图标资源名称")
+	iconCmd.Flags().StringVar(&iconAdaptiveBg, "adaptive-background", "", "自适应图标背景层使用的颜色名称，设置后生成自适应图标")
+	iconCmd.Flags().StringVar(&iconColors, "colors", "", "定义背景色的颜色配置文件 (colors.yaml)")
+	iconCmd.Flags().StringVar(&iconForeground, "foreground", "", "自适应图标的前景层图片，SVG或PNG (默认使用图标母版)")
+	iconCmd.Flags().StringVar(&iconMonochrome, "monochrome", "", "Android 13主题图标的单色层图片，SVG或PNG (默认使用前景层)")
+
+	// 标记必需的flag
+	iconCmd.MarkFlagRequired("input")
+}
+
+func runIconCommand(cmd *cobra.Command, args []string) {
+	// 验证输入文件是否存在
+	if info, err := os.Stat(iconInput); os.IsNotExist(err) {
+		exitWithError("图标母版不存在: %s", iconInput)
+	} else if info.IsDir() {
+		exitWithError("图标母版必须是文件: %s", iconInput)
+	}
+
+	// 验证平台参数
+	if iconPlatform != "ios" && iconPlatform != "android" && iconPlatform != "all" {
+		exitWithError("无效的平台参数: %s (必须是 ios/android/all)", iconPlatform)
+	}
+
+	// 验证输出参数：指定--catalog时iOS可以不需要--output
+	if iconOutput == "" && (iconCatalog == "" || iconPlatform != "ios") {
+		exitWithError("必须指定输出目录 --output")
+	}
+
+	// 验证自适应图标参数
+	if iconAdaptiveBg == "" && (iconColors != "" || iconForeground != "" || iconMonochrome != "") {
+		exitWithError("--colors、--foreground 和 --monochrome 需要同时指定 --adaptive-background")
+	}
+	if iconAdaptiveBg != "" && iconColors == "" {
+		exitWithError("指定 --adaptive-background 时必须指定颜色配置文件 --colors")
+	}
+	if iconAdaptiveBg != "" && iconPlatform == "ios" {
+		printWarning("自适应图标只用于Android，--adaptive-background 不会生效")
+	}
+	for _, path := range []string{iconColors, iconForeground, iconMonochrome} {
+		if path == "" {
+			continue
+		}
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			exitWithError("文件不存在: %s", path)
+		}
+	}
+
+	options := icon.Options{
+		CatalogPath: iconCatalog,
+		Name:        iconName,
+		Legacy:      iconLegacy,
+		AndroidName: iconAndroidName,
+
+		AdaptiveBackground: iconAdaptiveBg,
+		ColorsPath:         iconColors,
+		Foreground:         iconForeground,
+		Monochrome:         iconMonochrome,
+	}
+	if iconBackground != "" {
+		background, err := icon.ParseBackground(iconBackground)
+		if err != nil {
+			exitWithError("%v", err)
+		}
+		options.Background = background
+	}
+	generator := icon.NewGenerator(iconInput, iconOutput, options)
+
+	// 根据平台生成图标
+	var err error
+	switch iconPlatform {
+	case "ios":
+		fmt.Println("正在生成iOS应用图标...")
+		err = generator.GenerateIOS()
+	case "android":
+		fmt.Println("正在生成Android应用图标...")
+		err = generator.GenerateAndroid()
+	case "all":
+		if err = generator.ValidateAndroid(); err != nil {
+			exitWithError("生成Android应用图标失败: %v", err)
+		}
+		fmt.Println("正在生成iOS应用图标...")
+		if err = generator.GenerateIOS(); err != nil {
+			exitWithError("生成iOS应用图标失败: %v", err)
+		}
+		fmt.Println("正在生成Android应用图标...")
+		err = generator.GenerateAndroid()
+	}
+
+	if err != nil {
+		exitWithError("生成失败: %v", err)
+	}
+
+	for _, warning := range generator.Warnings() {
+		printWarning("%s", warning)
+	}
+
+	if iconOutput != "" {
+		fmt.Printf("✅ 应用图标生成成功！输出目录: %s\n", iconOutput)
+	}
+	if iconCatalog != "" && iconPlatform != "android" {
+		fmt.Printf("✅ iOS应用图标已写入: %s\n", iconCatalog)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"app-assets-generator/pkg/image"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -12,6 +13,17 @@ var (
 	imageInput    string
 	imageOutput   string
 	imagePlatform string
+	imageCatalog  string
+	imageFolder   string
+	imageBrands   string
+	imageNoVector bool
+	imagePDF      bool
+	imagePDFNames []string
+	imagePNG      bool
+	imagePNGNames []string
+	imagePNGSize  string
+	imageDark     string
+	imageManifest string
 )
 
 // imageCmd 图片生成命令
@@ -26,7 +38,22 @@ var imageCmd = &cobra.Command{
   app-assets-generator image --input icons/ --output output/android --platform android
   
   # 同时生成两个平台
-  app-assets-generator image --input icons/ --output output/ --platform all`,
+  app-assets-generator image --input icons/ --output output/ --platform all
+  
+  # 合并到已有的资源目录
+  app-assets-generator image --input icons/ --catalog App/Assets.xcassets --folder Images --platform ios
+  
+  # iOS中将SVG转换为PDF
+  app-assets-generator image --input icons/ --output output/ios --platform ios --pdf
+  
+  # SVG渲染为24点的PNG（通知图标等不能使用矢量图的场景）
+  app-assets-generator image --input icons/ --output output/ --platform all --png-image ic_notification --png-size 24
+  
+  # 带 _dark 后缀的图片作为同名图片的深色版本
+  app-assets-generator image --input icons/ --output output/ --platform all --dark-suffix _dark
+  
+  # 多品牌：brands/<品牌>/ 中的同名图片覆盖基础图片
+  app-assets-generator image --input icons/ --brands-dir brands/ --output app/ --platform android`,
 	Run: runImageCommand,
 }
 
@@ -36,12 +63,22 @@ func init() {
 	
 	// 添加flag
 	imageCmd.Flags().StringVarP(&imageInput, "input", "i", "", "输入的图片目录路径 (必需)")
-	imageCmd.Flags().StringVarP(&imageOutput, "output", "o", "", "输出目录路径 (必需)")
+	imageCmd.Flags().StringVarP(&imageOutput, "output", "o", "", "输出目录路径 (仅生成iOS且指定--catalog时可省略)")
 	imageCmd.Flags().StringVarP(&imagePlatform, "platform", "p", "all", "目标平台 (ios/android/all)")
+	imageCmd.Flags().StringVar(&imageCatalog, "catalog", "", "已有的Assets.xcassets路径，iOS资源将合并写入该目录")
+	imageCmd.Flags().StringVar(&imageFolder, "folder", "", "资源目录内的子文件夹 (配合--catalog使用，如 Images)")
+	imageCmd.Flags().StringVar(&imageBrands, "brands-dir", "", "品牌图片目录，每个子目录为一个品牌 (Android输出到 src/<品牌>/res)")
+	imageCmd.Flags().BoolVar(&imagePDF, "pdf", false, "iOS中所有SVG转换为PDF")
+	imageCmd.Flags().StringSliceVar(&imagePDFNames, "pdf-image", nil, "iOS中需要转换为PDF的SVG图片名称，可重复或用逗号分隔")
+	imageCmd.Flags().BoolVar(&imagePNG, "png", false, "所有SVG渲染为iOS 1x/2x/3x和Android各密度的PNG")
+	imageCmd.Flags().StringSliceVar(&imagePNGNames, "png-image", nil, "需要渲染为PNG的SVG图片名称，可重复或用逗号分隔")
+	imageCmd.Flags().StringVar(&imagePNGSize, "png-size", "", "PNG的点尺寸，如 24（最长边）或 24x32，默认使用SVG自身的尺寸")
+	imageCmd.Flags().StringVar(&imageDark, "dark-suffix", "", "深色图片的文件名后缀，如 _dark 识别 icon_dark.png、icon@2x_dark.png (默认不识别深色图片)")
+	imageCmd.Flags().StringVar(&imageManifest, "manifest", "", "图片设置文件路径 (默认使用输入目录中的images.yaml)")
+	imageCmd.Flags().BoolVar(&imageNoVector, "no-preserve-vector", false, "iOS单倍数的SVG/PDF不保留矢量数据，由Xcode编译时生成各倍数位图")
 	
 	// 标记必需的flag
 	imageCmd.MarkFlagRequired("input")
-	imageCmd.MarkFlagRequired("output")
 }
 
 func runImageCommand(cmd *cobra.Command, args []string) {
@@ -57,8 +94,36 @@ func runImageCommand(cmd *cobra.Command, args []string) {
 		exitWithError("无效的平台参数: %s (必须是 ios/android/all)", imagePlatform)
 	}
 	
+	// 验证输出参数：指定--catalog时iOS可以不需要--output
+	if imageOutput == "" && (imageCatalog == "" || imagePlatform != "ios") {
+		exitWithError("必须指定输出目录 --output")
+	}
+	
+	// 解析PNG渲染尺寸
+	var pngSize image.Size
+	if imagePNGSize != "" {
+		size, err := image.ParseSize(imagePNGSize)
+		if err != nil {
+			exitWithError("%v", err)
+		}
+		pngSize = size
+	}
+	
 	// 创建生成器
-	generator := image.NewGenerator(imageInput, imageOutput)
+	generator := image.NewGenerator(imageInput, imageOutput, image.Options{
+		CatalogPath: imageCatalog,
+		Folder:      imageFolder,
+		BrandsDir:   imageBrands,
+		
+		RasterizeVectors: imageNoVector,
+		PDF:              imagePDF,
+		PDFImages:        imagePDFNames,
+		PNG:              imagePNG,
+		PNGImages:        imagePNGNames,
+		PNGSize:          pngSize,
+		DarkSuffix:       imageDark,
+		ManifestPath:     imageManifest,
+	})
 	
 	// 根据平台生成资源
 	var err error
@@ -82,5 +147,35 @@ func runImageCommand(cmd *cobra.Command, args []string) {
 		exitWithError("生成失败: %v", err)
 	}
 	
-	fmt.Printf("✅ 图片资源生成成功！输出目录: %s\n", imageOutput)
-}
\ No newline at end of file
+	// 输出警告
+	for _, warning := range generator.Warnings() {
+		printWarning("%s", warning)
+	}
+	
+	if imageOutput != "" {
+		fmt.Printf("✅ 图片资源生成成功！输出目录: %s\n", imageOutput)
+	}
+	printImageBrands(generator)
+	if imageCatalog != "" && imagePlatform != "android" {
+		fmt.Printf("✅ iOS图片资源已合并到: %s\n", imageCatalog)
+	}
+}
+// printImageBrands 列出每个品牌的输出位置
+func printImageBrands(generator *image.Generator) {
+	brands, err := generator.Brands()
+	if err != nil || len(brands) == 0 {
+		return
+	}
+	
+	fmt.Printf("🏷  共 %d 个品牌，共享资源写入main源码集:\n", len(brands))
+	for _, name := range brands {
+		var outputs []string
+		if imagePlatform != "android" {
+			outputs = append(outputs, generator.BrandCatalogPath(name))
+		}
+		if imagePlatform != "ios" {
+			outputs = append(outputs, generator.AndroidResPath(name))
+		}
+		fmt.Printf("   %s: %s\n", name, strings.Join(outputs, ", "))
+	}
+}
@@ -36,6 +36,11 @@ func init() {
 	// rootCmd.PersistentFlags().StringP("config", "c", "", "配置文件路径")
 }
 
+// 显示警告
+func printWarning(msg string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, "⚠️  警告: "+msg+"\n", args...)
+}
+
 // 退出并显示错误
 func exitWithError(msg string, args ...interface{}) {
 	fmt.Fprintf(os.Stderr, "错误: "+msg+"\n", args...)
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"app-assets-generator/pkg/splash"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	splashInput        string
+	splashDarkLogo     string
+	splashOutput       string
+	splashPlatform     string
+	splashBackground   string
+	splashColors       string
+	splashCatalog      string
+	splashLogoSize     float64
+	splashLaunchScreen string
+	splashTheme        string
+	splashPostTheme    string
+)
+
+// splashCmd 启动画面生成命令
+var splashCmd = &cobra.Command{
+	Use:   "splash",
+	Short: "生成启动画面资源",
+	Long: `从标志图片（SVG或PNG）和colors.yaml中的背景色生成iOS和Android的启动画面
+
+iOS生成标志图片集 LaunchLogo.imageset、背景颜色集 LaunchBackground.colorset，
+以及Info.plist的UILaunchScreen配置片段（UILaunchScreen.plist）或 LaunchScreen.storyboard。
+Android生成288dp的启动画面图标 splash_logo，以及Android 12 SplashScreen和兼容库的主题 splash_themes.xml。
+背景色的深色值和 --dark-logo 用于深色模式。`,
+	Example: `  # 同时生成两个平台
+  app-assets-generator splash --input logo.svg --background splash_background --colors colors.yaml --output output/ --platform all
+
+  # 指定深色模式的标志，iOS生成LaunchScreen.storyboard
+  app-assets-generator splash --input logo.svg --dark-logo logo_dark.svg --background splash_background --colors colors.yaml \
+    --output ios/App --catalog ios/App/Assets.xcassets --platform ios --launch-screen storyboard`,
+	Run: runSplashCommand,
+}
+
+func init() {
+	// 注册命令
+	rootCmd.AddCommand(splashCmd)
+
+	// 添加flag
+	splashCmd.Flags().StringVarP(&splashInput, "input", "i", "", "标志图片路径，SVG或PNG (必需)")
+	splashCmd.Flags().StringVar(&splashDarkLogo, "dark-logo", "", "深色模式的标志图片 (默认使用同一个标志)")
+	splashCmd.Flags().StringVarP(&splashOutput, "output", "o", "", "输出目录路径 (必需)")
+	splashCmd.Flags().StringVarP(&splashPlatform, "platform", "p", "all", "目标平台 (ios/android/all)")
+	splashCmd.Flags().StringVar(&splashBackground, "background", "", "背景色使用的颜色名称 (必需)")
+	splashCmd.Flags().StringVar(&splashColors, "colors", "", "定义背景色的颜色配置文件 colors.yaml (必需)")
+	splashCmd.Flags().StringVar(&splashCatalog, "catalog", "", "已有的Assets.xcassets路径，iOS图片集和颜色集将写入该目录")
+	splashCmd.Flags().Float64Var(&splashLogoSize, "ios-logo-size", splash.DefaultIOSLogoSize, "iOS标志较长边的点尺寸")
+	splashCmd.Flags().StringVar(&splashLaunchScreen, "launch-screen", splash.LaunchScreenPlist, "iOS启动画面的形式 (plist/storyboard)")
+	splashCmd.Flags().StringVar(&splashTheme, "theme", splash.DefaultTheme, "Android启动画面主题名称")
+	splashCmd.Flags().StringVar(&splashPostTheme, "post-splash-theme", splash.DefaultPostSplashTheme, "启动画面结束后切换到的Android主题")
+
+	// 标记必需的flag
+	splashCmd.MarkFlagRequired("input")
+	splashCmd.MarkFlagRequired("output")
+	splashCmd.MarkFlagRequired("background")
+	splashCmd.MarkFlagRequired("colors")
+}
+
+func runSplashCommand(cmd *cobra.Command, args []string) {
+	// 验证输入文件是否存在
+	for _, path := range []string{splashInput, splashDarkLogo, splashColors} {
+		if path == "" {
+			continue
+		}
+		if info, err := os.Stat(path); os.IsNotExist(err) {
+			exitWithError("文件不存在: %s", path)
+		} else if info.IsDir() {
+			exitWithError("必须是文件: %s", path)
+		}
+	}
+
+	// 验证平台参数
+	if splashPlatform != "ios" && splashPlatform != "android" && splashPlatform != "all" {
+		exitWithError("无效的平台参数: %s (必须是 ios/android/all)", splashPlatform)
+	}
+
+	// 验证iOS参数
+	if splashLaunchScreen != splash.LaunchScreenPlist && splashLaunchScreen != splash.LaunchScreenStoryboard {
+		exitWithError("无效的启动画面形式: %s (必须是 plist/storyboard)", splashLaunchScreen)
+	}
+	if splashLogoSize <= 0 {
+		exitWithError("无效的标志尺寸: %v (必须大于0)", splashLogoSize)
+	}
+
+	generator := splash.NewGenerator(splashInput, splashOutput, splash.Options{
+		DarkLogo:        splashDarkLogo,
+		Background:      splashBackground,
+		ColorsPath:      splashColors,
+		CatalogPath:     splashCatalog,
+		IOSLogoSize:     splashLogoSize,
+		LaunchScreen:    splashLaunchScreen,
+		Theme:           splashTheme,
+		PostSplashTheme: splashPostTheme,
+	})
+
+	// 根据平台生成启动画面
+	var err error
+	switch splashPlatform {
+	case "ios":
+		fmt.Println("正在生成iOS启动画面...")
+		err = generator.GenerateIOS()
+	case "android":
+		fmt.Println("正在生成Android启动画面...")
+		err = generator.GenerateAndroid()
+	case "all":
+		fmt.Println("正在生成iOS启动画面...")
+		if err = generator.GenerateIOS(); err != nil {
+			exitWithError("生成iOS启动画面失败: %v", err)
+		}
+		fmt.Println("正在生成Android启动画面...")
+		err = generator.GenerateAndroid()
+	}
+
+	if err != nil {
+		exitWithError("生成失败: %v", err)
+	}
+
+	for _, warning := range generator.Warnings() {
+		printWarning("%s", warning)
+	}
+
+	fmt.Printf("✅ 启动画面生成成功！输出目录: %s\n", splashOutput)
+	if splashCatalog != "" && splashPlatform != "android" {
+		fmt.Printf("✅ iOS图片集和颜色集已写入: %s\n", splashCatalog)
+	}
+}
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"app-assets-generator/pkg/localization"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	stringsInput    string
+	stringsOutput   string
+	stringsPlatform string
+
+	stringsBaseLocale       string
+	stringsIOSOutput        string
+	stringsIOSFormat        string
+	stringsTableName        string
+	stringsSwiftOutput      string
+	stringsSwiftType        string
+	stringsKotlinOutput     string
+	stringsKotlinPackage    string
+	stringsAndroidNamespace string
+)
+
+// stringsCmd 本地化字符串生成命令
+var stringsCmd = &cobra.Command{
+	Use:   "strings",
+	Short: "生成本地化字符串",
+	Long: `从YAML或CSV翻译文件生成iOS和Android平台的本地化字符串
+
+Android生成 values-<语言>/strings.xml（包括 <plurals>），
+iOS生成 Localizable.xcstrings 或传统的 .strings/.stringsdict，并可以生成类型安全的访问器。
+占位符写作 {name} 或 {count:int}，各语言之间占位符不一致时报错，缺少的翻译给出警告。`,
+	Example: `  # Android平台
+  app-assets-generator strings --input strings.yaml --output app/src/main/res --platform android
+
+  # iOS平台，生成String Catalog和Swift访问器
+  app-assets-generator strings --input strings.yaml --ios-output App/Resources --swift App/L10n.swift --platform ios
+
+  # 从CSV读取，iOS生成传统的 .strings/.stringsdict
+  app-assets-generator strings --input strings.csv --ios-output App/Resources --ios-format strings --platform ios
+
+  # 同时生成Compose访问器
+  app-assets-generator strings --input strings.yaml --output app/src/main/res --ios-output App/Resources \
+    --kotlin app/src/main/java/com/example/ui/AppStrings.kt --kotlin-package com.example.ui`,
+	Run: runStringsCommand,
+}
+
+func init() {
+	// 注册命令
+	rootCmd.AddCommand(stringsCmd)
+
+	// 添加flag
+	stringsCmd.Flags().StringVarP(&stringsInput, "input", "i", "", "输入的YAML或CSV翻译文件路径 (必需)")
+	stringsCmd.Flags().StringVarP(&stringsOutput, "output", "o", "", "Android res目录路径 (生成Android时必需)")
+	stringsCmd.Flags().StringVarP(&stringsPlatform, "platform", "p", "all", "目标平台 (ios/android/all)")
+	stringsCmd.Flags().StringVar(&stringsBaseLocale, "base-locale", localization.DefaultBaseLocale, "基础语言，Android中写入 values/ 目录，其它语言缺少翻译时回退到该语言")
+	stringsCmd.Flags().StringVar(&stringsIOSOutput, "ios-output", "", "iOS字符串资源的输出目录 (生成iOS时必需)")
+	stringsCmd.Flags().StringVar(&stringsIOSFormat, "ios-format", localization.FormatXCStrings, "iOS字符串资源格式 (xcstrings/strings)")
+	stringsCmd.Flags().StringVar(&stringsTableName, "table", localization.DefaultTableName, "iOS字符串表名称")
+	stringsCmd.Flags().StringVar(&stringsSwiftOutput, "swift", "", "生成Swift字符串访问器的文件路径，如 Sources/L10n.swift")
+	stringsCmd.Flags().StringVar(&stringsSwiftType, "swift-type", localization.DefaultSwiftType, "Swift访问器枚举名称")
+	stringsCmd.Flags().StringVar(&stringsKotlinOutput, "kotlin", "", "生成Kotlin(Compose)字符串访问器的文件路径，如 ui/AppStrings.kt")
+	stringsCmd.Flags().StringVar(&stringsKotlinPackage, "kotlin-package", "", "Kotlin访问器的包名 (配合--kotlin使用)")
+	stringsCmd.Flags().StringVar(&stringsAndroidNamespace, "android-namespace", "", "R类所在的包名，默认与--kotlin-package相同")
+
+	// 标记必需的flag
+	stringsCmd.MarkFlagRequired("input")
+}
+
+func runStringsCommand(cmd *cobra.Command, args []string) {
+	// 验证输入文件是否存在
+	if _, err := os.Stat(stringsInput); os.IsNotExist(err) {
+		exitWithError("输入文件不存在: %s", stringsInput)
+	}
+
+	// 验证平台参数
+	if stringsPlatform != "ios" && stringsPlatform != "android" && stringsPlatform != "all" {
+		exitWithError("无效的平台参数: %s (必须是 ios/android/all)", stringsPlatform)
+	}
+	if stringsIOSFormat != localization.FormatXCStrings && stringsIOSFormat != localization.FormatStrings {
+		exitWithError("无效的iOS字符串格式: %s (必须是 xcstrings/strings)", stringsIOSFormat)
+	}
+
+	// 验证输出参数
+	if stringsPlatform != "ios" && stringsOutput == "" {
+		exitWithError("生成Android字符串必须指定输出目录 --output")
+	}
+	if stringsPlatform != "android" && stringsIOSOutput == "" {
+		exitWithError("生成iOS字符串必须指定输出目录 --ios-output")
+	}
+
+	// 创建生成器
+	generator := localization.NewGenerator(stringsInput, stringsOutput, localization.Options{
+		BaseLocale:       stringsBaseLocale,
+		IOSOutput:        stringsIOSOutput,
+		IOSFormat:        stringsIOSFormat,
+		TableName:        stringsTableName,
+		SwiftOutput:      stringsSwiftOutput,
+		SwiftType:        stringsSwiftType,
+		KotlinOutput:     stringsKotlinOutput,
+		KotlinPackage:    stringsKotlinPackage,
+		AndroidNamespace: stringsAndroidNamespace,
+	})
+
+	// 根据平台生成资源
+	var err error
+	switch stringsPlatform {
+	case "ios":
+		fmt.Println("正在生成iOS本地化字符串...")
+		err = generator.GenerateIOS()
+	case "android":
+		fmt.Println("正在生成Android本地化字符串...")
+		err = generator.GenerateAndroid()
+	case "all":
+		fmt.Println("正在生成iOS本地化字符串...")
+		if err = generator.GenerateIOS(); err != nil {
+			exitWithError("生成iOS本地化字符串失败: %v", err)
+		}
+		fmt.Println("正在生成Android本地化字符串...")
+		err = generator.GenerateAndroid()
+	}
+
+	if err != nil {
+		exitWithError("生成失败: %v", err)
+	}
+
+	for _, warning := range generator.Warnings() {
+		printWarning("%s:%d:%d: %s", warning.File, warning.Line, warning.Column, warning.Message)
+	}
+
+	if stringsPlatform != "ios" {
+		fmt.Printf("✅ Android本地化字符串生成成功！输出目录: %s\n", stringsOutput)
+	}
+	if stringsPlatform != "android" {
+		fmt.Printf("✅ iOS本地化字符串生成成功！输出目录: %s\n", stringsIOSOutput)
+	}
+	printStringsSummary(generator)
+}
+
+// printStringsSummary 输出每种语言的翻译完成情况
+func printStringsSummary(generator *localization.Generator) {
+	catalog, err := generator.Catalog()
+	if err != nil || len(catalog.Entries) == 0 {
+		return
+	}
+
+	var summary []string
+	for _, locale := range catalog.Locales {
+		translated := 0
+		for _, entry := range catalog.Entries {
+			if _, ok := entry.Translations[locale]; ok {
+				translated++
+			}
+		}
+		summary = append(summary, fmt.Sprintf("%s %d/%d", locale, translated, len(catalog.Entries)))
+	}
+	fmt.Printf("🌐 共 %d 个字符串: %s\n", len(catalog.Entries), strings.Join(summary, ", "))
+}
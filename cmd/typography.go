@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"app-assets-generator/pkg/typography"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	typographyInput    string
+	typographyOutput   string
+	typographyPlatform string
+
+	typographyAndroidPrefix    string
+	typographySwiftOutput      string
+	typographySwiftType        string
+	typographyKotlinOutput     string
+	typographyKotlinPackage    string
+	typographyAndroidNamespace string
+)
+
+// typographyCmd 文字样式生成命令
+var typographyCmd = &cobra.Command{
+	Use:   "typography",
+	Short: "生成文字样式",
+	Long: `从YAML配置文件生成iOS和Android平台的文字样式
+
+Android生成 values/styles.xml 中的 TextAppearance 样式和Compose TextStyle，
+iOS生成支持Dynamic Type的UIFont和SwiftUI Font。行高和字间距在pt、sp和em之间自动换算。`,
+	Example: `  # Android平台
+  app-assets-generator typography --input typography.yaml --output app/src/main/res --platform android
+
+  # iOS平台
+  app-assets-generator typography --input typography.yaml --swift Sources/TextStyles.swift --platform ios
+
+  # 同时生成Compose TextStyle
+  app-assets-generator typography --input typography.yaml --output app/src/main/res --swift Sources/TextStyles.swift \
+    --kotlin app/src/main/java/com/example/ui/AppTextStyles.kt --kotlin-package com.example.ui`,
+	Run: runTypographyCommand,
+}
+
+func init() {
+	// 注册命令
+	rootCmd.AddCommand(typographyCmd)
+
+	// 添加flag
+	typographyCmd.Flags().StringVarP(&typographyInput, "input", "i", "", "输入的YAML配置文件路径 (必需)")
+	typographyCmd.Flags().StringVarP(&typographyOutput, "output", "o", "", "Android res目录路径 (生成Android时必需)")
+	typographyCmd.Flags().StringVarP(&typographyPlatform, "platform", "p", "all", "目标平台 (ios/android/all)")
+	typographyCmd.Flags().StringVar(&typographyAndroidPrefix, "android-style-prefix", typography.DefaultAndroidStylePrefix, "TextAppearance样式名称前缀，如 App -> TextAppearance.App.<样式>")
+	typographyCmd.Flags().StringVar(&typographySwiftOutput, "swift", "", "生成Swift文字样式的文件路径 (生成iOS时必需)，如 Sources/TextStyles.swift")
+	typographyCmd.Flags().StringVar(&typographySwiftType, "swift-type", typography.DefaultSwiftType, "Swift文字样式类型名称")
+	typographyCmd.Flags().StringVar(&typographyKotlinOutput, "kotlin", "", "生成Kotlin(Compose)文字样式的文件路径，如 ui/AppTextStyles.kt")
+	typographyCmd.Flags().StringVar(&typographyKotlinPackage, "kotlin-package", "", "Kotlin代码的包名 (配合--kotlin使用)")
+	typographyCmd.Flags().StringVar(&typographyAndroidNamespace, "android-namespace", "", "R类所在的包名，默认与--kotlin-package相同")
+
+	// 标记必需的flag
+	typographyCmd.MarkFlagRequired("input")
+}
+
+func runTypographyCommand(cmd *cobra.Command, args []string) {
+	// 验证输入文件是否存在
+	if _, err := os.Stat(typographyInput); os.IsNotExist(err) {
+		exitWithError("输入文件不存在: %s", typographyInput)
+	}
+
+	// 验证平台参数
+	if typographyPlatform != "ios" && typographyPlatform != "android" && typographyPlatform != "all" {
+		exitWithError("无效的平台参数: %s (必须是 ios/android/all)", typographyPlatform)
+	}
+
+	// 验证输出参数
+	if typographyPlatform != "ios" && typographyOutput == "" {
+		exitWithError("生成Android文字样式必须指定输出目录 --output")
+	}
+	if typographyPlatform != "android" && typographySwiftOutput == "" {
+		exitWithError("生成iOS文字样式必须指定Swift输出文件 --swift")
+	}
+
+	// 创建生成器
+	generator := typography.NewGenerator(typographyInput, typographyOutput, typography.Options{
+		AndroidStylePrefix: typographyAndroidPrefix,
+		SwiftOutput:        typographySwiftOutput,
+		SwiftType:          typographySwiftType,
+		KotlinOutput:       typographyKotlinOutput,
+		KotlinPackage:      typographyKotlinPackage,
+		AndroidNamespace:   typographyAndroidNamespace,
+	})
+
+	// 根据平台生成资源
+	var err error
+	switch typographyPlatform {
+	case "ios":
+		fmt.Println("正在生成iOS文字样式...")
+		err = generator.GenerateIOS()
+	case "android":
+		fmt.Println("正在生成Android文字样式...")
+		err = generator.GenerateAndroid()
+	case "all":
+		fmt.Println("正在生成iOS文字样式...")
+		if err = generator.GenerateIOS(); err != nil {
+			exitWithError("生成iOS文字样式失败: %v", err)
+		}
+		fmt.Println("正在生成Android文字样式...")
+		err = generator.GenerateAndroid()
+	}
+
+	if err != nil {
+		exitWithError("生成失败: %v", err)
+	}
+
+	for _, warning := range generator.Warnings() {
+		printWarning("%s:%d:%d: %s", warning.File, warning.Line, warning.Column, warning.Message)
+	}
+
+	if typographyOutput != "" && typographyPlatform != "ios" {
+		fmt.Printf("✅ Android文字样式生成成功！输出目录: %s\n", typographyOutput)
+	}
+	if typographyPlatform != "android" {
+		fmt.Printf("✅ Swift文字样式生成成功！输出文件: %s\n", typographySwiftOutput)
+	}
+
+	// 提示需要引入的自定义字体
+	if families, err := generator.FontFamilies(); err == nil && len(families) > 0 {
+		fmt.Printf("🔤 用到的自定义字体: %s（需要在两个平台中引入对应的字体文件）\n", strings.Join(families, ", "))
+	}
+}
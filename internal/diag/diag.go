@@ -0,0 +1,73 @@
+package diag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity 问题级别
+type Severity string
+
+const (
+	SeverityError   Severity = "error"   // 错误，无法生成
+	SeverityWarning Severity = "warning" // 警告，可以生成但结果可能不符合预期
+)
+
+// Diagnostic 带位置信息的校验问题
+type Diagnostic struct {
+	File     string   // 文件路径
+	Line     int      // 行号（从1开始）
+	Column   int      // 列号（从1开始）
+	Severity Severity // 问题级别
+	Message  string   // 问题描述
+}
+
+// String 格式化为 file:line:column: level: message
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s: %s", d.File, d.Line, d.Column, d.Severity, d.Message)
+}
+
+// Diagnostics 校验问题列表
+type Diagnostics []Diagnostic
+
+// HasErrors 判断是否包含错误
+func (d Diagnostics) HasErrors() bool {
+	return len(d.Errors()) > 0
+}
+
+// Errors 获取所有错误
+func (d Diagnostics) Errors() Diagnostics {
+	return d.filter(SeverityError)
+}
+
+// Warnings 获取所有警告
+func (d Diagnostics) Warnings() Diagnostics {
+	return d.filter(SeverityWarning)
+}
+
+// filter 按级别过滤
+func (d Diagnostics) filter(severity Severity) Diagnostics {
+	var result Diagnostics
+	for _, diagnostic := range d {
+		if diagnostic.Severity == severity {
+			result = append(result, diagnostic)
+		}
+	}
+	return result
+}
+
+// ValidationError 校验失败错误，包含一次校验中发现的所有错误
+type ValidationError struct {
+	Diagnostics Diagnostics
+}
+
+// Error 实现error接口
+func (e *ValidationError) Error() string {
+	errors := e.Diagnostics.Errors()
+	lines := make([]string, 0, len(errors)+1)
+	lines = append(lines, fmt.Sprintf("发现 %d 个错误:", len(errors)))
+	for _, diagnostic := range errors {
+		lines = append(lines, "  "+diagnostic.String())
+	}
+	return strings.Join(lines, "\n")
+}
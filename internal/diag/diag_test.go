@@ -0,0 +1,32 @@
+package diag
+
+import "testing"
+
+func TestDiagnostics(t *testing.T) {
+	diagnostics := Diagnostics{
+		{File: "colors.yaml", Line: 3, Column: 5, Severity: SeverityWarning, Message: "颜色 a 未使用"},
+		{File: "colors.yaml", Line: 7, Column: 3, Severity: SeverityError, Message: "颜色 b 的hex无效"},
+		{File: "colors.yaml", Line: 9, Column: 1, Severity: SeverityError, Message: "颜色 c 重复定义"},
+	}
+
+	if !diagnostics.HasErrors() || diagnostics.Warnings().HasErrors() {
+		t.Errorf("HasErrors() 结果不正确")
+	}
+	if got := len(diagnostics.Errors()); got != 2 {
+		t.Errorf("Errors() 有 %d 条，应为 2 条", got)
+	}
+	if got := len(diagnostics.Warnings()); got != 1 {
+		t.Errorf("Warnings() 有 %d 条，应为 1 条", got)
+	}
+
+	if got, want := diagnostics[0].String(), "colors.yaml:3:5: warning: 颜色 a 未使用"; got != want {
+		t.Errorf("String() = %q，应为 %q", got, want)
+	}
+
+	// 警告不出现在校验失败的错误信息中
+	err := &ValidationError{Diagnostics: diagnostics}
+	want := "发现 2 个错误:\n  colors.yaml:7:3: error: 颜色 b 的hex无效\n  colors.yaml:9:1: error: 颜色 c 重复定义"
+	if err.Error() != want {
+		t.Errorf("Error() = %q，应为 %q", err.Error(), want)
+	}
+}
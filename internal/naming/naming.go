@@ -0,0 +1,111 @@
+package naming
+
+import "strings"
+
+// Identifier 将资源名称转换为小驼峰标识符，如 color_black_mask_10 -> colorBlackMask10
+// 以数字开头或为空时添加下划线前缀
+func Identifier(name string) string {
+	result := PascalCase(name)
+	if result == "" || (result[0] >= '0' && result[0] <= '9') {
+		return "_" + result
+	}
+	return strings.ToLower(result[:1]) + result[1:]
+}
+
+// NormalizeName 将外部来源的名称（如 Color/Primary 500、--color-blue-500、Source Sans 3）转换为有效的资源名称
+// 转为小写，非字母数字字符替换为下划线，以数字开头时添加下划线前缀
+func NormalizeName(name string) string {
+	var builder strings.Builder
+	lastUnderscore := true
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			builder.WriteRune(r)
+			lastUnderscore = false
+		} else if !lastUnderscore {
+			builder.WriteRune('_')
+			lastUnderscore = true
+		}
+	}
+
+	result := strings.TrimSuffix(builder.String(), "_")
+	if result != "" && result[0] >= '0' && result[0] <= '9' {
+		result = "_" + result
+	}
+	return result
+}
+
+// PascalCase 将资源名称转换为大驼峰，如 title_large -> TitleLarge
+func PascalCase(name string) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	})
+
+	var builder strings.Builder
+	for _, part := range parts {
+		builder.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return builder.String()
+}
+
+// swiftKeywords 不能直接作为属性名的Swift关键字
+var swiftKeywords = map[string]bool{
+	"associatedtype": true, "class": true, "deinit": true, "enum": true, "extension": true, "fileprivate": true,
+	"func": true, "import": true, "init": true, "inout": true, "internal": true, "let": true, "open": true,
+	"operator": true, "private": true, "precedencegroup": true, "protocol": true, "public": true, "rethrows": true,
+	"static": true, "struct": true, "subscript": true, "typealias": true, "var": true, "break": true, "case": true,
+	"catch": true, "continue": true, "default": true, "defer": true, "do": true, "else": true, "fallthrough": true,
+	"for": true, "guard": true, "if": true, "in": true, "repeat": true, "return": true, "throw": true, "switch": true,
+	"where": true, "while": true, "as": true, "await": true, "false": true, "is": true, "nil": true, "self": true,
+	"super": true, "throws": true, "true": true, "try": true,
+}
+
+// kotlinKeywords 不能直接作为属性名的Kotlin硬关键字
+var kotlinKeywords = map[string]bool{
+	"as": true, "break": true, "class": true, "continue": true, "do": true, "else": true, "false": true, "for": true,
+	"fun": true, "if": true, "in": true, "interface": true, "is": true, "null": true, "object": true, "package": true,
+	"return": true, "super": true, "this": true, "throw": true, "true": true, "try": true, "typealias": true,
+	"typeof": true, "val": true, "var": true, "when": true, "while": true,
+}
+
+// SwiftIdentifier 获取Swift属性名，关键字用反引号转义，如 default -> `default`
+func SwiftIdentifier(name string) string {
+	return escapeKeyword(Identifier(name), swiftKeywords)
+}
+
+// KotlinIdentifier 获取Kotlin属性名，关键字用反引号转义，如 object -> `object`
+func KotlinIdentifier(name string) string {
+	return escapeKeyword(Identifier(name), kotlinKeywords)
+}
+
+// escapeKeyword 关键字用反引号转义
+func escapeKeyword(name string, keywords map[string]bool) string {
+	if keywords[name] {
+		return "`" + name + "`"
+	}
+	return name
+}
+
+// DocLines 将说明文字拆分为文档注释的各行，忽略空行
+func DocLines(doc string) []string {
+	var lines []string
+	for _, line := range strings.Split(doc, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// SingleLine 将多行文本合并为一行
+func SingleLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// XMLComment 转换为可以放在XML注释中的单行文本（注释中不允许出现"--"）
+func XMLComment(text string) string {
+	text = SingleLine(text)
+	for strings.Contains(text, "--") {
+		text = strings.ReplaceAll(text, "--", "- -")
+	}
+	return text
+}
@@ -0,0 +1,47 @@
+package naming
+
+import "testing"
+
+func TestNormalizeName(t *testing.T) {
+	tests := map[string]string{
+		"Color/Primary 500": "color_primary_500",
+		"--color-blue-500":  "color_blue_500",
+		"Source Sans 3":     "source_sans_3",
+		"  Brand//Accent  ": "brand_accent",
+		"500":               "_500",
+		"中文":                "",
+	}
+	for name, want := range tests {
+		if got := NormalizeName(name); got != want {
+			t.Errorf("NormalizeName(%q) = %q，应为 %q", name, got, want)
+		}
+	}
+}
+
+func TestIdentifiers(t *testing.T) {
+	tests := []struct {
+		name   string
+		swift  string
+		kotlin string
+	}{
+		{name: "color_black_mask_10", swift: "colorBlackMask10", kotlin: "colorBlackMask10"},
+		{name: "10_percent", swift: "_10Percent", kotlin: "_10Percent"},
+		{name: "default", swift: "`default`", kotlin: "default"},
+		{name: "object", swift: "object", kotlin: "`object`"},
+		{name: "in", swift: "`in`", kotlin: "`in`"},
+	}
+	for _, test := range tests {
+		if got := SwiftIdentifier(test.name); got != test.swift {
+			t.Errorf("SwiftIdentifier(%q) = %q，应为 %q", test.name, got, test.swift)
+		}
+		if got := KotlinIdentifier(test.name); got != test.kotlin {
+			t.Errorf("KotlinIdentifier(%q) = %q，应为 %q", test.name, got, test.kotlin)
+		}
+	}
+}
+
+func TestXMLComment(t *testing.T) {
+	if got, want := XMLComment("主色\n  用于按钮---和链接"), "主色 用于按钮- - -和链接"; got != want {
+		t.Errorf("XMLComment() = %q，应为 %q", got, want)
+	}
+}
@@ -0,0 +1,68 @@
+package yamlutil
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ParseHeadComment 解析条目前的注释块，返回分组标题和条目注释
+// 包含 ==== 或 ---- 分隔线的段落视为分组标题，其它段落视为条目注释；
+// 分组标题之前的注释不属于当前条目
+func ParseHeadComment(comment string) (section string, entryComment string) {
+	for _, paragraph := range strings.Split(comment, "\n\n") {
+		var lines []string
+		isSection := false
+		for _, line := range strings.Split(paragraph, "\n") {
+			text := strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "#"))
+			if text == "" {
+				continue
+			}
+			if isSeparatorLine(text) {
+				isSection = true
+				continue
+			}
+			lines = append(lines, text)
+		}
+		if len(lines) == 0 {
+			continue
+		}
+
+		if isSection {
+			section = strings.Join(lines, " ")
+			entryComment = ""
+		} else {
+			entryComment = strings.Join(lines, "\n")
+		}
+	}
+
+	return section, entryComment
+}
+
+// isSeparatorLine 判断是否为 ==== / ---- 之类的分隔线
+func isSeparatorLine(text string) bool {
+	return len(text) >= 3 && strings.Trim(text, "=-*~") == ""
+}
+
+// NodeOrSelf 节点不存在时回退到另一个节点（通常是父节点），用于定位缺失字段的问题
+func NodeOrSelf(node, fallback *yaml.Node) *yaml.Node {
+	if node != nil {
+		return node
+	}
+	return fallback
+}
+
+// linePattern yaml.v3错误信息中的行号
+var linePattern = regexp.MustCompile(`line (\d+)`)
+
+// ErrorLine 从yaml.v3的错误信息中提取行号，无法提取时返回0
+func ErrorLine(err error) int {
+	match := linePattern.FindStringSubmatch(err.Error())
+	if match == nil {
+		return 0
+	}
+	line, _ := strconv.Atoi(match[1])
+	return line
+}
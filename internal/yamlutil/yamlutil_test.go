@@ -0,0 +1,52 @@
+package yamlutil
+
+import (
+	"errors"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseHeadComment(t *testing.T) {
+	tests := []struct {
+		comment string
+		section string
+		entry   string
+	}{
+		{comment: "# 主色", entry: "主色"},
+		{comment: "# 第一行\n# 第二行", entry: "第一行\n第二行"},
+		{comment: "# ====\n# 品牌色\n# ====", section: "品牌色"},
+		{comment: "# ----\n# 品牌色\n# ----\n\n# 主色", section: "品牌色", entry: "主色"},
+		{comment: "# 文件说明\n\n# ====\n# 品牌色\n# ====", section: "品牌色"}, // 分组标题之前的注释不属于条目
+		{comment: "#\n#   缩进的注释  \n#", entry: "缩进的注释"},
+		{comment: "# ==", entry: "=="}, // 少于3个字符不是分隔线
+		{comment: ""},
+	}
+	for _, test := range tests {
+		section, entry := ParseHeadComment(test.comment)
+		if section != test.section || entry != test.entry {
+			t.Errorf("ParseHeadComment(%q) = %q, %q，应为 %q, %q", test.comment, section, entry, test.section, test.entry)
+		}
+	}
+}
+
+func TestErrorLine(t *testing.T) {
+	var value interface{}
+	err := yaml.Unmarshal([]byte("a: 1\nb: [\n"), &value)
+	if err == nil {
+		t.Fatal("应返回YAML解析错误")
+	}
+	if line := ErrorLine(err); line != 2 {
+		t.Errorf("ErrorLine(%q) = %d，应为 2", err, line)
+	}
+	if line := ErrorLine(errors.New("unexpected EOF")); line != 0 {
+		t.Errorf("没有行号的错误应返回0，实际为 %d", line)
+	}
+}
+
+func TestNodeOrSelf(t *testing.T) {
+	node, fallback := &yaml.Node{Line: 2}, &yaml.Node{Line: 1}
+	if NodeOrSelf(node, fallback) != node || NodeOrSelf(nil, fallback) != fallback {
+		t.Errorf("NodeOrSelf应优先返回存在的节点")
+	}
+}
@@ -0,0 +1,43 @@
+package brand
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+)
+
+// MainSourceSet Android共享资源所在的源码集
+const MainSourceSet = "main"
+
+// reservedNames 不能作为品牌名称的源码集和构建类型名称
+var reservedNames = []string{MainSourceSet, "test", "androidTest", "debug", "release"}
+
+// namePattern 品牌名称格式，与Gradle product flavor命名保持一致
+var namePattern = regexp.MustCompile(`^[a-z][A-Za-z0-9_]*$`)
+
+// ValidateName 验证品牌名称
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("品牌名称 %s 无效，必须以小写字母开头，只能包含字母、数字和下划线", name)
+	}
+	for _, reserved := range reservedNames {
+		if name == reserved {
+			return fmt.Errorf("品牌名称 %s 是保留的源码集名称", name)
+		}
+	}
+	return nil
+}
+
+// AndroidResPath 获取源码集的res目录，如 app/src/acme/res
+func AndroidResPath(moduleDir, sourceSet string) string {
+	return filepath.Join(moduleDir, "src", sourceSet, "res")
+}
+
+// CatalogPath 获取品牌的iOS资源目录
+// 指定了共享资源目录时与其位于同一目录，如 App/acme.xcassets；否则位于输出目录下，如 <输出目录>/acme.xcassets
+func CatalogPath(outputDir, sharedCatalog, name string) string {
+	if sharedCatalog == "" {
+		return filepath.Join(outputDir, name+".xcassets")
+	}
+	return filepath.Join(filepath.Dir(filepath.Clean(sharedCatalog)), name+".xcassets")
+}
@@ -0,0 +1,43 @@
+package brand
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateName(t *testing.T) {
+	tests := map[string]bool{
+		"acme":        true,
+		"acmePro":     true,
+		"acme_2":      true,
+		"Acme":        false,
+		"2acme":       false,
+		"acme-pro":    false,
+		"":            false,
+		"main":        false,
+		"androidTest": false,
+		"release":     false,
+	}
+	for name, valid := range tests {
+		if err := ValidateName(name); (err == nil) != valid {
+			t.Errorf("ValidateName(%q) 的错误为 %v", name, err)
+		}
+	}
+}
+
+func TestCatalogPath(t *testing.T) {
+	tests := []struct {
+		output, shared string
+		want           string
+	}{
+		{output: "out", want: filepath.Join("out", "acme.xcassets")},
+		{output: "out", shared: filepath.Join("App", "Assets.xcassets"), want: filepath.Join("App", "acme.xcassets")},
+		{output: "out", shared: filepath.Join("App", "Assets.xcassets") + "/", want: filepath.Join("App", "acme.xcassets")},
+		{output: "out", shared: "Assets.xcassets", want: "acme.xcassets"},
+	}
+	for _, test := range tests {
+		if got := CatalogPath(test.output, test.shared, "acme"); got != test.want {
+			t.Errorf("CatalogPath(%q, %q) = %q，应为 %q", test.output, test.shared, got, test.want)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package color
 
 import (
+	"app-assets-generator/internal/naming"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -10,6 +11,10 @@ import (
 // AndroidGenerator Android颜色资源生成器
 type AndroidGenerator struct {
 	outputPath string
+	
+	// 品牌源码集的基础颜色。设置后生成的颜色覆盖main源码集中的同名颜色：
+	// 深色值总是写入values-night，main中有平板值的颜色也写入values-sw600dp，避免main中带限定符的值优先生效
+	base map[string]*ColorDefinition
 }
 
 // NewAndroidGenerator 创建Android生成器
@@ -37,45 +42,119 @@ func (g *AndroidGenerator) Generate(colors map[string]*ColorDefinition) error {
 	defaultColors := make(map[string]string)
 	nightColors := make(map[string]string)
 	
+	// 平板变体（values-sw600dp / values-sw600dp-night）
+	tabletColors := make(map[string]string)
+	tabletNightColors := make(map[string]string)
+	
+	// 自动推导的深色值，会在colors.xml中标注
+	derivedNight := make(map[string]bool)
+	derivedTabletNight := make(map[string]bool)
+	
 	for name, color := range colors {
 		// 跳过渐变色
 		if color.IsGradient() {
 			continue
 		}
 		
-		// 获取默认/浅色主题颜色
-		lightColor := color.GetLight()
-		if lightColor.Hex != "" {
-			defaultColors[name] = g.formatAndroidColor(lightColor)
+		g.collectThemeColors(name, color.GetLight(), color.GetDark(), defaultColors, nightColors)
+		if color.DarkDerived {
+			derivedNight[name] = true
 		}
 		
-		// 获取深色主题颜色
-		darkColor := color.GetDark()
-		if darkColor.Hex != "" {
-			// 只有当深色主题颜色与浅色不同时才添加
-			if darkColor.Hex != lightColor.Hex || darkColor.Alpha != lightColor.Alpha {
-				nightColors[name] = g.formatAndroidColor(darkColor)
+		// Android只区分平板，其它设备类型和尺寸类别没有对应的资源限定符
+		hasTablet := false
+		for i := range color.Variants {
+			variant := &color.Variants[i]
+			if !isAndroidTabletVariant(variant) {
+				continue
+			}
+			hasTablet = true
+			g.collectThemeColors(name, variant.GetLight(color), variant.GetDark(color), tabletColors, tabletNightColors)
+			if variant.IsDarkDerived(color) {
+				derivedTabletNight[name] = true
+			}
+		}
+		
+		// 品牌颜色没有平板值而main中有时，平板上也使用品牌颜色
+		if base, ok := g.base[name]; ok && !hasTablet && hasAndroidTabletVariant(base) {
+			g.collectThemeColors(name, color.GetLight(), color.GetDark(), tabletColors, tabletNightColors)
+			if color.DarkDerived {
+				derivedTabletNight[name] = true
 			}
 		}
 	}
 	
 	// 生成默认colors.xml
-	if err := g.generateColorsXML(valuesPath, defaultColors); err != nil {
+	if err := g.generateColorsXML(valuesPath, defaultColors, colors, nil); err != nil {
 		return fmt.Errorf("生成默认colors.xml失败: %w", err)
 	}
 	
 	// 如果有深色主题颜色，生成values-night/colors.xml
 	if len(nightColors) > 0 {
-		if err := g.generateColorsXML(valuesNightPath, nightColors); err != nil {
+		if err := g.generateColorsXML(valuesNightPath, nightColors, colors, derivedNight); err != nil {
 			return fmt.Errorf("生成深色主题colors.xml失败: %w", err)
 		}
 	}
 	
+	// 如果有平板颜色，生成values-sw600dp/colors.xml
+	if len(tabletColors) > 0 {
+		tabletPath := filepath.Join(g.outputPath, "values-sw600dp")
+		if err := os.MkdirAll(tabletPath, 0755); err != nil {
+			return fmt.Errorf("创建values-sw600dp目录失败: %w", err)
+		}
+		if err := g.generateColorsXML(tabletPath, tabletColors, colors, nil); err != nil {
+			return fmt.Errorf("生成平板colors.xml失败: %w", err)
+		}
+	}
+	
+	if len(tabletNightColors) > 0 {
+		tabletNightPath := filepath.Join(g.outputPath, "values-sw600dp-night")
+		if err := os.MkdirAll(tabletNightPath, 0755); err != nil {
+			return fmt.Errorf("创建values-sw600dp-night目录失败: %w", err)
+		}
+		if err := g.generateColorsXML(tabletNightPath, tabletNightColors, colors, derivedTabletNight); err != nil {
+			return fmt.Errorf("生成平板深色主题colors.xml失败: %w", err)
+		}
+	}
+	
 	return nil
 }
 
+// isAndroidTabletVariant 判断变体是否对应Android的sw600dp
+func isAndroidTabletVariant(variant *ColorVariant) bool {
+	return variant.IsTablet() && variant.WidthClass == "" && variant.HeightClass == ""
+}
+
+// hasAndroidTabletVariant 判断颜色是否有对应sw600dp的变体
+func hasAndroidTabletVariant(color *ColorDefinition) bool {
+	for i := range color.Variants {
+		if isAndroidTabletVariant(&color.Variants[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// collectThemeColors 收集浅色和深色主题颜色到对应的集合
+func (g *AndroidGenerator) collectThemeColors(name string, lightColor, darkColor ColorValue, lightColors, nightColors map[string]string) {
+	// 获取默认/浅色主题颜色
+	if lightColor.Hex != "" {
+		lightColors[name] = g.formatAndroidColor(lightColor)
+	}
+	
+	// 获取深色主题颜色
+	if darkColor.Hex != "" {
+		// 只有当深色主题颜色与浅色不同时才添加，品牌颜色总是添加
+		if g.base != nil || darkColor.Hex != lightColor.Hex || darkColor.Alpha != lightColor.Alpha {
+			nightColors[name] = g.formatAndroidColor(darkColor)
+		}
+	}
+}
+
 // generateColorsXML 生成colors.xml文件
-func (g *AndroidGenerator) generateColorsXML(dirPath string, colors map[string]string) error {
+// 按颜色在YAML中的顺序输出，分组注释块输出为分组标题，说明文字输出为XML注释
+// derived中的颜色为自动推导的深色值，会额外标注
+func (g *AndroidGenerator) generateColorsXML(dirPath string, colors map[string]string, definitions map[string]*ColorDefinition, derived map[string]bool) error {
 	filePath := filepath.Join(dirPath, "colors.xml")
 	file, err := os.Create(filePath)
 	if err != nil {
@@ -87,13 +166,36 @@ func (g *AndroidGenerator) generateColorsXML(dirPath string, colors map[string]s
 	fmt.Fprintln(file, `<?xml version="1.0" encoding="utf-8"?>`)
 	fmt.Fprintln(file, `<resources>`)
 	
-	// 按字母顺序排序并写入颜色
-	sortedNames := g.sortColorNames(colors)
-	for _, name := range sortedNames {
-		colorValue := colors[name]
-		// 将下划线转换为更符合Android命名规范（可选）
-		androidName := name
-		fmt.Fprintf(file, `    <color name="%s">%s</color>`+"\n", androidName, colorValue)
+	// 按YAML中的顺序写入颜色
+	section := ""
+	written := 0
+	for _, name := range sortedColorNames(definitions) {
+		colorValue, ok := colors[name]
+		if !ok {
+			continue
+		}
+		definition := definitions[name]
+		
+		// 分组标题
+		if definition.Section != section {
+			section = definition.Section
+			if written > 0 {
+				fmt.Fprintln(file)
+			}
+			fmt.Fprintf(file, "    <!-- ===== %s ===== -->\n", naming.XMLComment(section))
+		}
+		
+		// 说明文字
+		if doc := definition.Doc(); doc != "" {
+			fmt.Fprintf(file, "    <!-- %s -->\n", naming.XMLComment(doc))
+		}
+		
+		fmt.Fprintf(file, `    <color name="%s">%s</color>`, name, colorValue)
+		if derived[name] {
+			fmt.Fprint(file, " <!-- 自动推导的深色值，待设计确认 -->")
+		}
+		fmt.Fprintln(file)
+		written++
 	}
 	
 	// 写入结束标签
@@ -117,22 +219,3 @@ func (g *AndroidGenerator) formatAndroidColor(color ColorValue) string {
 	
 	return hex
 }
-
-// sortColorNames 排序颜色名称
-func (g *AndroidGenerator) sortColorNames(colors map[string]string) []string {
-	names := make([]string, 0, len(colors))
-	for name := range colors {
-		names = append(names, name)
-	}
-	
-	// 简单的冒泡排序（因为颜色数量通常不多）
-	for i := 0; i < len(names)-1; i++ {
-		for j := i + 1; j < len(names); j++ {
-			if names[i] > names[j] {
-				names[i], names[j] = names[j], names[i]
-			}
-		}
-	}
-	
-	return names
-}
\ No newline at end of file
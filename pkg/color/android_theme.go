@@ -0,0 +1,79 @@
+package color
+
+import (
+	"app-assets-generator/internal/naming"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultAndroidThemePrefix 默认的主题覆盖样式名称前缀
+const DefaultAndroidThemePrefix = "App"
+
+// GenerateThemes 生成语义颜色的主题属性和主题覆盖样式
+// values/attrs.xml 为每个语义颜色声明 format="color" 的属性，
+// values/theme_overlays.xml 为每个主题生成一个 ThemeOverlay.<prefix>.<主题> 样式，将属性指向调色板颜色
+func (g *AndroidGenerator) GenerateThemes(themes []Theme, prefix string) error {
+	if prefix == "" {
+		prefix = DefaultAndroidThemePrefix
+	}
+
+	valuesPath := filepath.Join(g.outputPath, "values")
+	if err := os.MkdirAll(valuesPath, 0755); err != nil {
+		return fmt.Errorf("创建values目录失败: %w", err)
+	}
+
+	if err := g.generateAttrsXML(valuesPath, themes); err != nil {
+		return fmt.Errorf("生成attrs.xml失败: %w", err)
+	}
+	if err := g.generateThemeOverlaysXML(valuesPath, themes, prefix); err != nil {
+		return fmt.Errorf("生成theme_overlays.xml失败: %w", err)
+	}
+
+	return nil
+}
+
+// generateAttrsXML 生成语义颜色的属性声明
+func (g *AndroidGenerator) generateAttrsXML(dirPath string, themes []Theme) error {
+	var builder strings.Builder
+	builder.WriteString("<?xml version=\"1.0\" encoding=\"utf-8\"?>\n")
+	builder.WriteString("<resources>\n")
+
+	for _, token := range ThemeTokens(themes) {
+		if token.Comment != "" {
+			fmt.Fprintf(&builder, "    <!-- %s -->\n", naming.XMLComment(token.Comment))
+		}
+		fmt.Fprintf(&builder, "    <attr name=\"%s\" format=\"color\" />\n", token.Token)
+	}
+
+	builder.WriteString("</resources>\n")
+	return os.WriteFile(filepath.Join(dirPath, "attrs.xml"), []byte(builder.String()), 0644)
+}
+
+// generateThemeOverlaysXML 生成每个主题的覆盖样式
+func (g *AndroidGenerator) generateThemeOverlaysXML(dirPath string, themes []Theme, prefix string) error {
+	var builder strings.Builder
+	builder.WriteString("<?xml version=\"1.0\" encoding=\"utf-8\"?>\n")
+	builder.WriteString("<resources>\n")
+
+	for i, theme := range themes {
+		if i > 0 {
+			builder.WriteString("\n")
+		}
+		fmt.Fprintf(&builder, "    <style name=\"%s\" parent=\"\">\n", ThemeOverlayName(prefix, theme.Name))
+		for _, color := range theme.Colors {
+			fmt.Fprintf(&builder, "        <item name=\"%s\">@color/%s</item>\n", color.Token, color.Color)
+		}
+		builder.WriteString("    </style>\n")
+	}
+
+	builder.WriteString("</resources>\n")
+	return os.WriteFile(filepath.Join(dirPath, "theme_overlays.xml"), []byte(builder.String()), 0644)
+}
+
+// ThemeOverlayName 获取主题覆盖样式的名称，如 (App, ocean_dark) -> ThemeOverlay.App.OceanDark
+func ThemeOverlayName(prefix, theme string) string {
+	name := naming.Identifier(theme)
+	return fmt.Sprintf("ThemeOverlay.%s.%s", prefix, strings.ToUpper(name[:1])+name[1:])
+}
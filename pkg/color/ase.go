@@ -0,0 +1,69 @@
+package color
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"unicode/utf16"
+)
+
+// ASE (Adobe Swatch Exchange) 块类型
+const (
+	aseBlockColor      = 0x0001
+	aseBlockGroupStart = 0xC001
+	aseBlockGroupEnd   = 0xC002
+	aseColorTypeNormal = 2 // 0=global, 1=spot, 2=normal
+)
+
+// encodeASE 编码为Adobe Swatch Exchange文件
+// 所有颜色放在以调色板命名的分组中；ASE不支持透明度，alpha会被忽略
+func encodeASE(name string, palette []paletteEntry) []byte {
+	var blocks bytes.Buffer
+	blockCount := 0
+
+	// 分组开始
+	var group bytes.Buffer
+	writeASEString(&group, name)
+	writeASEBlock(&blocks, aseBlockGroupStart, group.Bytes())
+	blockCount++
+
+	for _, entry := range palette {
+		var body bytes.Buffer
+		writeASEString(&body, entry.Name)
+		body.WriteString("RGB ")
+		for _, component := range []float64{entry.Red, entry.Green, entry.Blue} {
+			binary.Write(&body, binary.BigEndian, math.Float32bits(float32(component)))
+		}
+		binary.Write(&body, binary.BigEndian, uint16(aseColorTypeNormal))
+		writeASEBlock(&blocks, aseBlockColor, body.Bytes())
+		blockCount++
+	}
+
+	// 分组结束
+	writeASEBlock(&blocks, aseBlockGroupEnd, nil)
+	blockCount++
+
+	var file bytes.Buffer
+	file.WriteString("ASEF")
+	binary.Write(&file, binary.BigEndian, uint16(1)) // 主版本
+	binary.Write(&file, binary.BigEndian, uint16(0)) // 次版本
+	binary.Write(&file, binary.BigEndian, uint32(blockCount))
+	file.Write(blocks.Bytes())
+	return file.Bytes()
+}
+
+// writeASEBlock 写入一个块：类型、长度、内容
+func writeASEBlock(buffer *bytes.Buffer, blockType uint16, body []byte) {
+	binary.Write(buffer, binary.BigEndian, blockType)
+	binary.Write(buffer, binary.BigEndian, uint32(len(body)))
+	buffer.Write(body)
+}
+
+// writeASEString 写入以0结尾的UTF-16BE字符串，长度包含结尾的0
+func writeASEString(buffer *bytes.Buffer, text string) {
+	units := append(utf16.Encode([]rune(text)), 0)
+	binary.Write(buffer, binary.BigEndian, uint16(len(units)))
+	for _, unit := range units {
+		binary.Write(buffer, binary.BigEndian, unit)
+	}
+}
@@ -0,0 +1,117 @@
+package color
+
+import (
+	"app-assets-generator/internal/diag"
+	"app-assets-generator/pkg/brand"
+	"fmt"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// brandsKey colors.yaml中保留的顶层字段，定义每个品牌覆盖的颜色
+const brandsKey = "brands"
+
+// Brand 品牌（白标）配置
+type Brand struct {
+	Name string // 品牌名称，对应Android product flavor和iOS资源目录
+
+	// 与基础颜色不同的颜色，包括品牌直接覆盖的颜色和因引用被覆盖颜色而变化的颜色
+	Colors map[string]*ColorDefinition
+}
+
+// OverriddenColors 获取任一品牌中与基础颜色不同的颜色名称
+func OverriddenColors(brands []Brand) map[string]bool {
+	overridden := make(map[string]bool)
+	for _, b := range brands {
+		for name := range b.Colors {
+			overridden[name] = true
+		}
+	}
+	return overridden
+}
+
+// parseBrands 解析brands字段
+// 每个品牌的颜色与基础颜色合并后重新计算引用和表达式，只保留与基础颜色不同的结果
+func (v *validator) parseBrands(node *yaml.Node, entries []colorEntry, base map[string]*ColorDefinition, options ParseOptions) []Brand {
+	node = resolveAlias(node)
+	if node == nil || node.Kind != yaml.MappingNode {
+		v.errorf(node, "brands必须是品牌名称到颜色定义的映射")
+		return nil
+	}
+
+	var brands []Brand
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], resolveAlias(node.Content[i+1])
+		name := keyNode.Value
+
+		if err := brand.ValidateName(name); err != nil {
+			v.errorf(keyNode, "%v", err)
+			continue
+		}
+		if valueNode == nil || valueNode.Kind != yaml.MappingNode {
+			v.errorf(keyNode, "品牌 %s 必须是颜色名称到颜色定义的映射", name)
+			continue
+		}
+
+		// 覆盖的颜色沿用基础颜色的注释、分组和顺序
+		overrides := make(map[string]colorEntry)
+		for j := 0; j+1 < len(valueNode.Content); j += 2 {
+			colorKey := valueNode.Content[j]
+			if !containsEntry(entries, colorKey.Value) {
+				v.errorf(colorKey, "品牌 %s 覆盖的颜色 %s 未在基础颜色中定义", name, colorKey.Value)
+				continue
+			}
+			overrides[colorKey.Value] = colorEntry{name: colorKey.Value, keyNode: colorKey, valueNode: valueNode.Content[j+1]}
+		}
+
+		brandEntries := make([]colorEntry, len(entries))
+		for j, entry := range entries {
+			if override, ok := overrides[entry.name]; ok {
+				override.comment, override.section = entry.comment, entry.section
+				entry = override
+			}
+			brandEntries[j] = entry
+		}
+
+		// 基础颜色中已经报告过的问题不再重复报告
+		brandValidator := &validator{file: v.file}
+		colors := brandValidator.buildColors(brandEntries, options)
+		for _, diagnostic := range brandValidator.diagnostics {
+			if !v.hasDiagnostic(diagnostic) {
+				diagnostic.Message = fmt.Sprintf("品牌 %s: %s", name, diagnostic.Message)
+				v.diagnostics = append(v.diagnostics, diagnostic)
+			}
+		}
+
+		b := Brand{Name: name, Colors: make(map[string]*ColorDefinition)}
+		for colorName, color := range colors {
+			if baseColor, ok := base[colorName]; ok && !reflect.DeepEqual(baseColor, color) {
+				b.Colors[colorName] = color
+			}
+		}
+		brands = append(brands, b)
+	}
+
+	return brands
+}
+
+// containsEntry 判断颜色是否已定义
+func containsEntry(entries []colorEntry, name string) bool {
+	for _, entry := range entries {
+		if entry.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// hasDiagnostic 判断是否已经记录过相同的问题
+func (v *validator) hasDiagnostic(diagnostic diag.Diagnostic) bool {
+	for _, existing := range v.diagnostics {
+		if existing == diagnostic {
+			return true
+		}
+	}
+	return false
+}
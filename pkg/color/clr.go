@@ -0,0 +1,135 @@
+package color
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// NSColor的颜色空间编号
+const (
+	nsColorSpaceCalibratedRGB = 1
+	nsColorSpaceIDSRGB        = 7 // NSColorSpace.sRGBColorSpace
+)
+
+// keyedArchive 以XML plist形式生成NSKeyedArchiver归档
+type keyedArchive struct {
+	objects []string       // $objects中的对象（XML片段）
+	classes map[string]int // 类名 -> 对象下标
+}
+
+// encodeCLR 编码为macOS调色板（NSColorList的NSKeyedArchiver归档）
+// 颜色使用sRGB颜色空间，名称和透明度都会保留
+func encodeCLR(name string, palette []paletteEntry) []byte {
+	archive := &keyedArchive{classes: make(map[string]int)}
+	archive.add("<string>$null</string>")
+
+	// 根对象需要先占位，保证下标为1
+	root := archive.add("")
+	colorSpace := archive.add(archive.dict(map[string]string{
+		"NSID":   integerXML(nsColorSpaceIDSRGB),
+		"$class": uidXML(archive.class("NSColorSpace")),
+	}, "NSID"))
+
+	keys := make([]int, 0, len(palette))
+	colors := make([]int, 0, len(palette))
+	for _, entry := range palette {
+		keys = append(keys, archive.add("<string>"+xmlEscape(entry.Name)+"</string>"))
+
+		components := fmt.Sprintf("%s %s %s %s", componentString(entry.Red), componentString(entry.Green), componentString(entry.Blue), componentString(entry.Alpha))
+		colors = append(colors, archive.add(archive.dict(map[string]string{
+			"NSColorSpace":       integerXML(nsColorSpaceCalibratedRGB),
+			"NSRGB":              dataXML(components + "\x00"),
+			"NSComponents":       dataXML(components),
+			"NSCustomColorSpace": uidXML(colorSpace),
+			"$class":             uidXML(archive.class("NSColor")),
+		}, "NSColorSpace", "NSRGB", "NSComponents", "NSCustomColorSpace")))
+	}
+
+	archive.objects[root] = archive.dict(map[string]string{
+		"NSName":   "<string>" + xmlEscape(name) + "</string>",
+		"NSKeys":   uidXML(archive.add(archive.array(keys))),
+		"NSColors": uidXML(archive.add(archive.array(colors))),
+		"$class":   uidXML(archive.class("NSColorList")),
+	}, "NSName", "NSKeys", "NSColors")
+
+	var builder strings.Builder
+	builder.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	builder.WriteString(`<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">` + "\n")
+	builder.WriteString(`<plist version="1.0">` + "\n<dict>\n")
+	builder.WriteString("<key>$archiver</key><string>NSKeyedArchiver</string>\n")
+	builder.WriteString("<key>$version</key><integer>100000</integer>\n")
+	builder.WriteString("<key>$top</key><dict><key>root</key>" + uidXML(root) + "</dict>\n")
+	builder.WriteString("<key>$objects</key>\n<array>\n")
+	for _, object := range archive.objects {
+		builder.WriteString(object + "\n")
+	}
+	builder.WriteString("</array>\n</dict>\n</plist>\n")
+
+	return []byte(builder.String())
+}
+
+// add 添加对象，返回其下标
+func (a *keyedArchive) add(object string) int {
+	a.objects = append(a.objects, object)
+	return len(a.objects) - 1
+}
+
+// class 获取类描述对象的下标，不存在时创建
+func (a *keyedArchive) class(name string) int {
+	if index, ok := a.classes[name]; ok {
+		return index
+	}
+	index := a.add("<dict><key>$classname</key><string>" + name + "</string>" +
+		"<key>$classes</key><array><string>" + name + "</string><string>NSObject</string></array></dict>")
+	a.classes[name] = index
+	return index
+}
+
+// array 生成NSArray对象
+func (a *keyedArchive) array(items []int) string {
+	var builder strings.Builder
+	builder.WriteString("<dict><key>NS.objects</key><array>")
+	for _, item := range items {
+		builder.WriteString(uidXML(item))
+	}
+	builder.WriteString("</array><key>$class</key>" + uidXML(a.class("NSArray")) + "</dict>")
+	return builder.String()
+}
+
+// dict 按给定的键顺序生成字典，$class始终放在最后
+func (a *keyedArchive) dict(values map[string]string, order ...string) string {
+	var builder strings.Builder
+	builder.WriteString("<dict>")
+	for _, key := range append(order, "$class") {
+		builder.WriteString("<key>" + key + "</key>" + values[key])
+	}
+	builder.WriteString("</dict>")
+	return builder.String()
+}
+
+// uidXML XML plist中的UID引用
+func uidXML(index int) string {
+	return fmt.Sprintf("<dict><key>CF$UID</key><integer>%d</integer></dict>", index)
+}
+
+// integerXML XML plist中的整数
+func integerXML(value int) string {
+	return fmt.Sprintf("<integer>%d</integer>", value)
+}
+
+// dataXML XML plist中的二进制数据
+func dataXML(value string) string {
+	return "<data>" + base64.StdEncoding.EncodeToString([]byte(value)) + "</data>"
+}
+
+// componentString 格式化颜色分量
+func componentString(value float64) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.6f", value), "0"), ".")
+}
+
+// xmlEscape 转义XML文本
+func xmlEscape(text string) string {
+	replacer := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+	return replacer.Replace(text)
+}
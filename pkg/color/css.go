@@ -0,0 +1,339 @@
+package color
+
+import (
+	"app-assets-generator/internal/diag"
+	"app-assets-generator/internal/naming"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// DefaultCSSPrefix 默认导入的CSS自定义属性前缀
+const DefaultCSSPrefix = "--color-"
+
+// maxVarDepth var()引用解析的最大深度，用于检测循环引用
+const maxVarDepth = 32
+
+// cssVarPattern 匹配不包含嵌套括号的var()引用，如 var(--blue) 或 var(--blue, #00f)
+var cssVarPattern = regexp.MustCompile(`var\(\s*(--[A-Za-z0-9_-]+)\s*(?:,\s*([^()]*(?:\([^()]*\))?[^()]*))?\)`)
+
+// cssScheme CSS变量所属的配色方案
+type cssScheme int
+
+const (
+	cssSchemeDefault cssScheme = iota // 不在配色方案媒体查询中
+	cssSchemeLight                    // prefers-color-scheme: light
+	cssSchemeDark                     // prefers-color-scheme: dark
+)
+
+// cssDeclaration 自定义属性声明
+type cssDeclaration struct {
+	Name   string
+	Value  string
+	Offset int
+}
+
+// cssImporter CSS自定义属性导入器
+type cssImporter struct {
+	file        string
+	data        []byte
+	prefix      string
+	schemes     [3]map[string]cssDeclaration // 各配色方案中的所有自定义属性
+	order       []string                     // 带前缀的自定义属性，按首次出现的顺序
+	diagnostics diag.Diagnostics
+}
+
+// ImportCSS 读取CSS文件中的颜色自定义属性并转换为颜色定义
+// 只导入以prefix开头的自定义属性，去掉前缀后作为颜色名称（如 --color-blue-500 -> blue_500）；
+// prefers-color-scheme: dark 媒体查询中的值映射为dark，light媒体查询中的值映射为light，其余映射为default
+// 只导入 :root、html、* 规则中的声明，其它选择器中的带前缀属性会被忽略并给出警告
+func ImportCSS(filePath, prefix string) (map[string]*ColorDefinition, diag.Diagnostics, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("读取文件失败: %w", err)
+	}
+	if prefix == "" {
+		prefix = DefaultCSSPrefix
+	}
+
+	importer := &cssImporter{file: filePath, data: data, prefix: prefix}
+	for i := range importer.schemes {
+		importer.schemes[i] = make(map[string]cssDeclaration)
+	}
+
+	if err := importer.parseBlock(stripCSSComments(string(data)), 0, cssSchemeDefault); err != nil {
+		return nil, nil, fmt.Errorf("解析 %s 失败: %w", filePath, err)
+	}
+
+	colors := make(map[string]*ColorDefinition)
+	sources := make(map[string]string)
+	section := strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))
+
+	for _, property := range importer.order {
+		name := naming.NormalizeName(strings.TrimPrefix(property, prefix))
+		if name == "" {
+			return nil, nil, fmt.Errorf("%s 无法转换为有效的颜色名称", property)
+		}
+		if source, exists := sources[name]; exists {
+			return nil, nil, fmt.Errorf("%s 和 %s 转换后的颜色名称重复: %s", source, property, name)
+		}
+
+		// var()在使用处解析，未在配色方案中重新声明的属性也可能因引用的变量不同而变化
+		values := make([]*ColorValue, len(importer.schemes))
+		for scheme := range importer.schemes {
+			declaration, declared := importer.schemes[scheme][property]
+			inherited := false
+			if !declared {
+				if declaration, inherited = importer.schemes[cssSchemeDefault][property]; !inherited {
+					continue
+				}
+			}
+			value, err := importer.resolve(cssScheme(scheme), declaration)
+			if err != nil {
+				if declared {
+					importer.warnf(declaration.Offset, "%s: %v，已忽略", property, err)
+				}
+				continue
+			}
+			if inherited && values[cssSchemeDefault] != nil && value.Equal(*values[cssSchemeDefault]) {
+				continue
+			}
+			values[scheme] = &value
+		}
+		if values[cssSchemeDefault] == nil && values[cssSchemeLight] == nil && values[cssSchemeDark] == nil {
+			continue
+		}
+		sources[name] = property
+
+		definition := &ColorDefinition{Section: section, Order: len(colors)}
+		if values[cssSchemeLight] == nil && values[cssSchemeDark] == nil {
+			definition.Hex = values[cssSchemeDefault].Hex
+			definition.Alpha = values[cssSchemeDefault].Alpha
+		} else {
+			definition.Default = values[cssSchemeDefault]
+			definition.Light = values[cssSchemeLight]
+			definition.Dark = values[cssSchemeDark]
+		}
+		colors[name] = definition
+	}
+
+	return colors, importer.diagnostics, nil
+}
+
+// stripCSSComments 将注释替换为空格，保持偏移不变
+func stripCSSComments(text string) string {
+	result := []byte(text)
+	for start := strings.Index(text, "/*"); start >= 0; {
+		end := strings.Index(text[start+2:], "*/")
+		stop := len(text)
+		if end >= 0 {
+			stop = start + 2 + end + 2
+		}
+		for i := start; i < stop; i++ {
+			if result[i] != '\n' {
+				result[i] = ' '
+			}
+		}
+		next := strings.Index(text[stop:], "/*")
+		if next < 0 {
+			break
+		}
+		start = stop + next
+	}
+	return string(result)
+}
+
+// parseBlock 解析一段规则或声明列表，base为text在文件中的偏移
+func (c *cssImporter) parseBlock(text string, base int, scheme cssScheme) error {
+	start := 0
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case ';':
+			c.declare(text[start:i], base+start, scheme)
+			start = i + 1
+		case '{':
+			end, err := matchingBrace(text, i)
+			if err != nil {
+				return fmt.Errorf("第 %d 行: %w", c.line(base+i), err)
+			}
+			prelude := strings.TrimSpace(text[start:i])
+			if blockScheme, ok := c.blockScheme(prelude, scheme); ok {
+				if err := c.parseBlock(text[i+1:end], base+i+1, blockScheme); err != nil {
+					return err
+				}
+			} else if !strings.HasPrefix(prelude, "@") {
+				c.warnIgnoredSelector(prelude, text[i+1:end], base+i+1)
+			}
+			i = end
+			start = end + 1
+		case '}':
+			return fmt.Errorf("第 %d 行: 多余的 }", c.line(base+i))
+		}
+	}
+	c.declare(text[start:], base+start, scheme)
+	return nil
+}
+
+// rootSelectors 声明全局自定义属性的选择器，其中的声明按所在的配色方案导入
+var rootSelectors = []string{":root", "html", "*"}
+
+// blockScheme 根据规则前导部分判断块内声明的配色方案，返回false表示忽略该块
+// 只导入 :root、html、* 规则中的声明；.dark、[data-theme=dark] 等选择器无法对应到配色方案，整块忽略
+func (c *cssImporter) blockScheme(prelude string, scheme cssScheme) (cssScheme, bool) {
+	if !strings.HasPrefix(prelude, "@") {
+		return scheme, isRootSelector(prelude)
+	}
+
+	compact := strings.ToLower(strings.Join(strings.Fields(prelude), ""))
+	switch {
+	case strings.HasPrefix(compact, "@media"):
+		if strings.Contains(compact, "prefers-color-scheme:dark") {
+			return cssSchemeDark, true
+		}
+		if strings.Contains(compact, "prefers-color-scheme:light") {
+			return cssSchemeLight, true
+		}
+		// 其它媒体查询（如屏幕宽度）不属于配色方案
+		return scheme, false
+	case strings.HasPrefix(compact, "@layer"), strings.HasPrefix(compact, "@theme"), strings.HasPrefix(compact, "@supports"):
+		return scheme, true
+	}
+	return scheme, false
+}
+
+// isRootSelector 判断选择器列表是否只包含 :root、html、*
+func isRootSelector(prelude string) bool {
+	for _, selector := range strings.Split(prelude, ",") {
+		if !slices.Contains(rootSelectors, strings.ToLower(strings.TrimSpace(selector))) {
+			return false
+		}
+	}
+	return true
+}
+
+// warnIgnoredSelector 被忽略的选择器规则中声明了带前缀的自定义属性时记录警告
+// 这些声明通常是另一套主题的值，导入为默认值会覆盖 :root 中的声明
+func (c *cssImporter) warnIgnoredSelector(selector, text string, base int) {
+	pattern := regexp.MustCompile(`(?:^|[;{}\s])(` + regexp.QuoteMeta(c.prefix) + `[A-Za-z0-9_-]*)\s*:`)
+	matches := pattern.FindAllStringSubmatchIndex(text, -1)
+	if len(matches) == 0 {
+		return
+	}
+
+	first := text[matches[0][2]:matches[0][3]]
+	properties := first
+	if len(matches) > 1 {
+		properties = fmt.Sprintf("%s 等 %d 个自定义属性", first, len(matches))
+	}
+	c.warnf(base+matches[0][2], "选择器 %s 中声明了 %s，已忽略（只导入 :root、html、* 以及配色方案媒体查询中的声明）",
+		strings.Join(strings.Fields(selector), " "), properties)
+}
+
+// declare 记录自定义属性声明，普通属性直接忽略
+func (c *cssImporter) declare(text string, offset int, scheme cssScheme) {
+	trimmed := strings.TrimSpace(text)
+	if !strings.HasPrefix(trimmed, "--") {
+		return
+	}
+	colon := strings.Index(trimmed, ":")
+	if colon < 0 {
+		return
+	}
+
+	offset += strings.Index(text, trimmed)
+	name := strings.TrimSpace(trimmed[:colon])
+	value := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(trimmed[colon+1:]), "!important"))
+
+	if strings.HasPrefix(name, c.prefix) && !c.seen(name) {
+		c.order = append(c.order, name)
+	}
+	c.schemes[scheme][name] = cssDeclaration{Name: name, Value: value, Offset: offset}
+}
+
+// seen 判断带前缀的自定义属性是否已记录顺序
+func (c *cssImporter) seen(name string) bool {
+	for _, property := range c.order {
+		if property == name {
+			return true
+		}
+	}
+	return false
+}
+
+// resolve 解析声明中的var()引用并转换为颜色值
+// 引用优先在同一配色方案中查找，找不到时回退到default
+func (c *cssImporter) resolve(scheme cssScheme, declaration cssDeclaration) (ColorValue, error) {
+	value := declaration.Value
+	for depth := 0; strings.Contains(value, "var("); depth++ {
+		if depth >= maxVarDepth {
+			return ColorValue{}, fmt.Errorf("var()引用层级过深，可能存在循环引用")
+		}
+
+		var missing string
+		value = cssVarPattern.ReplaceAllStringFunc(value, func(match string) string {
+			parts := cssVarPattern.FindStringSubmatch(match)
+			if referenced, ok := c.lookup(scheme, parts[1]); ok {
+				return referenced
+			}
+			if parts[2] != "" {
+				return strings.TrimSpace(parts[2])
+			}
+			missing = parts[1]
+			return match
+		})
+		if missing != "" {
+			return ColorValue{}, fmt.Errorf("引用的 %s 未定义", missing)
+		}
+	}
+
+	return ParseCSSColor(value)
+}
+
+// lookup 查找自定义属性的原始值
+func (c *cssImporter) lookup(scheme cssScheme, name string) (string, bool) {
+	if declaration, ok := c.schemes[scheme][name]; ok {
+		return declaration.Value, true
+	}
+	if declaration, ok := c.schemes[cssSchemeDefault][name]; ok {
+		return declaration.Value, true
+	}
+	return "", false
+}
+
+// line 偏移对应的行号
+func (c *cssImporter) line(offset int) int {
+	line, _ := offsetPosition(c.data, int64(offset))
+	return line
+}
+
+// warnf 记录带位置的警告
+func (c *cssImporter) warnf(offset int, format string, args ...interface{}) {
+	line, column := offsetPosition(c.data, int64(offset))
+	c.diagnostics = append(c.diagnostics, diag.Diagnostic{
+		File:     c.file,
+		Line:     line,
+		Column:   column,
+		Severity: diag.SeverityWarning,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// matchingBrace 查找与open位置的 { 匹配的 }
+func matchingBrace(text string, open int) (int, error) {
+	depth := 0
+	for i := open; i < len(text); i++ {
+		switch text[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("缺少匹配的 }")
+}
@@ -0,0 +1,126 @@
+package color
+
+import (
+	"app-assets-generator/internal/diag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// importTestCSS 将CSS写入临时文件并导入
+func importTestCSS(t *testing.T, css string) (map[string]*ColorDefinition, diag.Diagnostics) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "tokens.css")
+	if err := os.WriteFile(path, []byte(css), 0644); err != nil {
+		t.Fatal(err)
+	}
+	colors, diagnostics, err := ImportCSS(path, "")
+	if err != nil {
+		t.Fatalf("ImportCSS() 失败: %v", err)
+	}
+	return colors, diagnostics
+}
+
+func TestImportCSSSelectors(t *testing.T) {
+	tests := []struct {
+		name     string
+		css      string
+		want     map[string]ColorValue // 颜色名称 -> 默认值
+		dark     map[string]ColorValue // 颜色名称 -> 深色值
+		warnings []string              // 每条警告应包含的内容
+	}{
+		{
+			name: "根选择器",
+			css: `:root { --color-bg: #ffffff; }
+html, * { --color-fg: rgb(0 0 0 / 50%); }
+:ROOT { --other: #ff0000; }`,
+			want: map[string]ColorValue{
+				"bg": {Hex: "#ffffff", Alpha: 1},
+				"fg": {Hex: "#000000", Alpha: 0.5},
+			},
+		},
+		{
+			name: "主题类选择器不覆盖默认值",
+			css: `:root { --color-bg: #ffffff; --color-fg: #000000; }
+.dark {
+  --color-bg: #000000;
+  --color-fg: #ffffff;
+}
+[data-theme=dark] { --color-bg: #111111; }
+.card { color: red; --shadow: #000; }`,
+			want: map[string]ColorValue{
+				"bg": {Hex: "#ffffff", Alpha: 1},
+				"fg": {Hex: "#000000", Alpha: 1},
+			},
+			warnings: []string{
+				"选择器 .dark 中声明了 --color-bg 等 2 个自定义属性，已忽略",
+				"选择器 [data-theme=dark] 中声明了 --color-bg，已忽略",
+			},
+		},
+		{
+			name: "只在其它选择器中声明",
+			css:  `:root.dark { --color-accent: #ff0000; }`,
+			want: map[string]ColorValue{},
+			warnings: []string{
+				"选择器 :root.dark 中声明了 --color-accent，已忽略",
+			},
+		},
+		{
+			name: "配色方案媒体查询",
+			css: `@theme { --color-brand: #336699; }
+@media (prefers-color-scheme: dark) {
+  :root { --color-brand: #6699cc; }
+  .card { --color-brand: #000000; }
+}`,
+			want: map[string]ColorValue{"brand": {Hex: "#336699", Alpha: 1}},
+			dark: map[string]ColorValue{"brand": {Hex: "#6699cc", Alpha: 1}},
+			warnings: []string{
+				"选择器 .card 中声明了 --color-brand，已忽略",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			colors, diagnostics := importTestCSS(t, test.css)
+			if len(colors) != len(test.want) {
+				t.Errorf("导入了 %d 个颜色，应为 %d 个", len(colors), len(test.want))
+			}
+			for name, want := range test.want {
+				definition, ok := colors[name]
+				if !ok {
+					t.Errorf("没有导入 %s", name)
+					continue
+				}
+				if got := definition.GetDefault(); !got.Equal(want) {
+					t.Errorf("%s 的默认值为 %+v，应为 %+v", name, got, want)
+				}
+				if want, ok := test.dark[name]; ok {
+					if got := definition.GetDark(); !got.Equal(want) {
+						t.Errorf("%s 的深色值为 %+v，应为 %+v", name, got, want)
+					}
+				}
+			}
+
+			if len(diagnostics) != len(test.warnings) {
+				t.Fatalf("诊断为 %v，应有 %d 条", diagnostics, len(test.warnings))
+			}
+			for i, want := range test.warnings {
+				if diagnostics[i].Severity != diag.SeverityWarning || !strings.Contains(diagnostics[i].Message, want) {
+					t.Errorf("诊断 %d 为 %q，应为包含 %q 的警告", i, diagnostics[i].Message, want)
+				}
+			}
+		})
+	}
+}
+
+func TestImportCSSWarningPosition(t *testing.T) {
+	_, diagnostics := importTestCSS(t, ":root { --color-bg: #fff; }\n.dark {\n  --color-bg: #000;\n}\n")
+	if len(diagnostics) != 1 {
+		t.Fatalf("诊断为 %v，应有 1 条", diagnostics)
+	}
+	if diagnostics[0].Line != 3 || diagnostics[0].Column != 3 {
+		t.Errorf("警告位置为 %d:%d，应为 3:3", diagnostics[0].Line, diagnostics[0].Column)
+	}
+}
@@ -0,0 +1,214 @@
+package color
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// cssNamedColors 常用的CSS颜色名称
+var cssNamedColors = map[string]string{
+	"black":   "#000000",
+	"white":   "#ffffff",
+	"red":     "#ff0000",
+	"green":   "#008000",
+	"blue":    "#0000ff",
+	"yellow":  "#ffff00",
+	"cyan":    "#00ffff",
+	"magenta": "#ff00ff",
+	"gray":    "#808080",
+	"grey":    "#808080",
+	"orange":  "#ffa500",
+	"purple":  "#800080",
+}
+
+// ParseCSSColor 解析CSS颜色值
+// 支持 #rgb/#rgba/#rrggbb/#rrggbbaa、rgb()/rgba()、hsl()/hsla()、oklch()、transparent和常用颜色名称
+func ParseCSSColor(value string) (ColorValue, error) {
+	value = strings.TrimSpace(strings.ToLower(value))
+
+	if value == "transparent" {
+		return ColorValue{Hex: "#000000", Alpha: 0}, nil
+	}
+	if hex, ok := cssNamedColors[value]; ok {
+		return ColorValue{Hex: hex, Alpha: 1}, nil
+	}
+	if strings.HasPrefix(value, "#") {
+		return parseCSSHex(value)
+	}
+
+	open := strings.Index(value, "(")
+	if open < 0 || !strings.HasSuffix(value, ")") {
+		return ColorValue{}, fmt.Errorf("无法识别的颜色值: %s", value)
+	}
+	function := strings.TrimSpace(value[:open])
+	args, alpha, err := parseCSSArgs(value[open+1 : len(value)-1])
+	if err != nil {
+		return ColorValue{}, fmt.Errorf("颜色值 %s 无效: %w", value, err)
+	}
+	if len(args) != 3 {
+		return ColorValue{}, fmt.Errorf("颜色值 %s 需要3个分量", value)
+	}
+
+	switch function {
+	case "rgb", "rgba":
+		var components [3]float64
+		for i, arg := range args {
+			if components[i], err = parseCSSNumber(arg, 255); err != nil {
+				return ColorValue{}, fmt.Errorf("颜色值 %s 无效: %w", value, err)
+			}
+		}
+		return NewColorValue(components[0], components[1], components[2], alpha), nil
+	case "hsl", "hsla":
+		hue, err := strconv.ParseFloat(strings.TrimSuffix(args[0], "deg"), 64)
+		if err != nil {
+			return ColorValue{}, fmt.Errorf("颜色值 %s 的色相无效", value)
+		}
+		saturation, err := parseCSSNumber(args[1], 100)
+		if err != nil {
+			return ColorValue{}, fmt.Errorf("颜色值 %s 无效: %w", value, err)
+		}
+		lightness, err := parseCSSNumber(args[2], 100)
+		if err != nil {
+			return ColorValue{}, fmt.Errorf("颜色值 %s 无效: %w", value, err)
+		}
+		r, g, b := hslToRGB(hue, saturation, lightness)
+		return NewColorValue(r, g, b, alpha), nil
+	case "oklch":
+		lightness, err := parseCSSNumber(args[0], 1)
+		if err != nil {
+			return ColorValue{}, fmt.Errorf("颜色值 %s 无效: %w", value, err)
+		}
+		chroma, err := parseCSSNumber(args[1], 1)
+		if err != nil {
+			return ColorValue{}, fmt.Errorf("颜色值 %s 无效: %w", value, err)
+		}
+		if strings.HasSuffix(args[1], "%") {
+			// 色度百分比以0.4为100%
+			chroma *= 0.4
+		}
+		hue, err := strconv.ParseFloat(strings.TrimSuffix(args[2], "deg"), 64)
+		if err != nil {
+			return ColorValue{}, fmt.Errorf("颜色值 %s 的色相无效", value)
+		}
+		r, g, b := OKLCH{L: lightness, C: chroma, H: hue}.ToRGB()
+		return NewColorValue(r, g, b, alpha), nil
+	}
+
+	return ColorValue{}, fmt.Errorf("不支持的颜色函数: %s", function)
+}
+
+// parseCSSHex 解析十六进制颜色
+func parseCSSHex(value string) (ColorValue, error) {
+	digits := strings.TrimPrefix(value, "#")
+	if len(digits) == 3 || len(digits) == 4 {
+		var expanded strings.Builder
+		for _, digit := range digits {
+			expanded.WriteRune(digit)
+			expanded.WriteRune(digit)
+		}
+		digits = expanded.String()
+	}
+	if len(digits) != 6 && len(digits) != 8 {
+		return ColorValue{}, fmt.Errorf("无效的hex颜色值: %s", value)
+	}
+
+	parsed, err := strconv.ParseUint(digits, 16, 32)
+	if err != nil {
+		return ColorValue{}, fmt.Errorf("无效的hex颜色值: %s", value)
+	}
+	alpha := 1.0
+	if len(digits) == 8 {
+		alpha = float64(parsed&0xff) / 255
+	}
+
+	return ColorValue{
+		Hex:   "#" + digits[:6],
+		Alpha: math.Round(alpha*1000) / 1000,
+	}, nil
+}
+
+// parseCSSArgs 解析颜色函数参数，支持逗号分隔和空格分隔（以 / 分隔透明度）两种写法
+func parseCSSArgs(body string) (args []string, alpha float64, err error) {
+	alpha = 1
+	body = strings.TrimSpace(body)
+
+	var alphaText string
+	if slash := strings.Index(body, "/"); slash >= 0 {
+		alphaText = strings.TrimSpace(body[slash+1:])
+		body = body[:slash]
+	}
+
+	if strings.Contains(body, ",") {
+		for _, part := range strings.Split(body, ",") {
+			args = append(args, strings.TrimSpace(part))
+		}
+		if len(args) == 4 {
+			alphaText, args = args[3], args[:3]
+		}
+	} else {
+		args = strings.Fields(body)
+	}
+
+	if alphaText != "" {
+		if alpha, err = parseCSSNumber(alphaText, 1); err != nil {
+			return nil, 0, err
+		}
+	}
+	return args, alpha, nil
+}
+
+// parseCSSNumber 解析数值或百分比并归一化到0-1，scale为不带百分号时数值的满量程
+func parseCSSNumber(text string, scale float64) (float64, error) {
+	text = strings.TrimSpace(text)
+	if strings.HasSuffix(text, "%") {
+		value, err := strconv.ParseFloat(strings.TrimSuffix(text, "%"), 64)
+		if err != nil {
+			return 0, fmt.Errorf("无效的数值: %s", text)
+		}
+		return value / 100, nil
+	}
+
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return 0, fmt.Errorf("无效的数值: %s", text)
+	}
+	return value / scale, nil
+}
+
+// hslToRGB HSL转换为0-1的RGB分量，hue为角度，saturation和lightness为0-1
+func hslToRGB(hue, saturation, lightness float64) (r, g, b float64) {
+	hue = math.Mod(math.Mod(hue, 360)+360, 360) / 360
+	if saturation == 0 {
+		return lightness, lightness, lightness
+	}
+
+	var q float64
+	if lightness < 0.5 {
+		q = lightness * (1 + saturation)
+	} else {
+		q = lightness + saturation - lightness*saturation
+	}
+	p := 2*lightness - q
+
+	channel := func(t float64) float64 {
+		if t < 0 {
+			t++
+		}
+		if t > 1 {
+			t--
+		}
+		switch {
+		case t < 1.0/6:
+			return p + (q-p)*6*t
+		case t < 1.0/2:
+			return q
+		case t < 2.0/3:
+			return p + (q-p)*(2.0/3-t)*6
+		}
+		return p
+	}
+
+	return channel(hue + 1.0/3), channel(hue), channel(hue - 1.0/3)
+}
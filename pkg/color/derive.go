@@ -0,0 +1,152 @@
+package color
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DarkDerivation 深色值推导配置
+// 在OKLCH颜色空间中反转亮度并保持色相和色度，结果亮度限制在[MinLightness, MaxLightness]之间
+type DarkDerivation struct {
+	MinLightness float64 // 推导结果的最小亮度 0-1
+	MaxLightness float64 // 推导结果的最大亮度 0-1
+}
+
+// DefaultDarkDerivation 默认推导配置
+var DefaultDarkDerivation = DarkDerivation{
+	MinLightness: 0.15,
+	MaxLightness: 0.95,
+}
+
+// DerivedDark 一条推导出的深色值
+type DerivedDark struct {
+	Brand   string     // 品牌名称，基础颜色为空
+	Name    string     // 颜色名称
+	Variant int        // 变体下标，基础颜色为-1
+	Idiom   string     // 变体的设备类型
+	Source  ColorValue // 推导来源（浅色值）
+	Dark    ColorValue // 推导出的深色值
+}
+
+// Validate 验证推导配置
+func (d DarkDerivation) Validate() error {
+	if d.MinLightness < 0 || d.MaxLightness > 1 || d.MinLightness > d.MaxLightness {
+		return fmt.Errorf("亮度范围无效: [%g, %g] (必须满足 0 <= min <= max <= 1)", d.MinLightness, d.MaxLightness)
+	}
+	return nil
+}
+
+// Derive 根据浅色值推导深色值，透明度保持不变
+func (d DarkDerivation) Derive(value ColorValue) ColorValue {
+	r, g, b, err := hexToRGB(value.Hex)
+	if err != nil {
+		return value
+	}
+
+	oklch := RGBToOKLCH(r, g, b)
+	oklch.L = 1 - oklch.L
+	if oklch.L < d.MinLightness {
+		oklch.L = d.MinLightness
+	}
+	if oklch.L > d.MaxLightness {
+		oklch.L = d.MaxLightness
+	}
+
+	r, g, b = oklch.ToRGB()
+	return ColorValue{Hex: rgbToHex(r, g, b), Alpha: value.Alpha}
+}
+
+// DeriveDarkColors 为缺少dark值的主题颜色推导深色值，显式的dark值始终优先
+// 简单颜色（不区分主题）和渐变色不会被推导，返回按YAML顺序排列的推导结果
+func DeriveDarkColors(colors map[string]*ColorDefinition, derivation DarkDerivation) []DerivedDark {
+	var derived []DerivedDark
+
+	for _, name := range sortedColorNames(colors) {
+		color := colors[name]
+		if color.IsGradient() || color.IsSimple() {
+			continue
+		}
+
+		if color.Dark == nil && (color.Default != nil || color.Light != nil) {
+			source := color.GetLight()
+			dark := derivation.Derive(source)
+			color.Dark = &dark
+			color.DarkDerived = true
+			derived = append(derived, DerivedDark{Name: name, Variant: -1, Source: source, Dark: dark})
+		}
+
+		for i := range color.Variants {
+			variant := &color.Variants[i]
+			if variant.Dark != nil || (variant.Default == nil && variant.Light == nil) {
+				continue
+			}
+			source := variant.GetLight(color)
+			dark := derivation.Derive(source)
+			variant.Dark = &dark
+			variant.DarkDerived = true
+			derived = append(derived, DerivedDark{Name: name, Variant: i, Idiom: variant.GetIdiom(), Source: source, Dark: dark})
+		}
+	}
+
+	return derived
+}
+
+// WriteDerivedReport 将推导结果写为YAML片段，设计确认后可复制到colors.yaml中作为显式的dark值
+func WriteDerivedReport(filePath string, derived []DerivedDark) error {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return fmt.Errorf("创建输出目录失败: %w", err)
+	}
+
+	var builder strings.Builder
+	builder.WriteString("# 自动推导的深色值，设计确认后可复制到colors.yaml中作为显式的dark值\n")
+	for _, item := range derived {
+		builder.WriteString("\n")
+		if item.Brand != "" || item.Variant >= 0 {
+			fmt.Fprintf(&builder, "# %s\n", item.label())
+		}
+		fmt.Fprintf(&builder, "# 来源: %s alpha %s\n", item.Source.Hex, formatFloat(item.Source.Alpha))
+		if item.Brand == "" && item.Variant < 0 {
+			fmt.Fprintf(&builder, "%s:\n  dark:\n    hex: \"%s\"\n    alpha: %s\n", item.Name, item.Dark.Hex, formatFloat(item.Dark.Alpha))
+			continue
+		}
+
+		// 品牌颜色和变体位于嵌套的字段中，以注释形式给出完整路径，按需合并到colors.yaml
+		indent := ""
+		if item.Brand != "" {
+			fmt.Fprintf(&builder, "# %s:\n#   %s:\n", brandsKey, item.Brand)
+			indent = "    "
+		}
+		fmt.Fprintf(&builder, "# %s%s:\n", indent, item.Name)
+		if item.Variant >= 0 {
+			fmt.Fprintf(&builder, "# %s  variants[%d]:\n", indent, item.Variant)
+			indent += "  "
+		}
+		fmt.Fprintf(&builder, "# %s  dark:\n# %s    hex: \"%s\"\n# %s    alpha: %s\n",
+			indent, indent, item.Dark.Hex, indent, formatFloat(item.Dark.Alpha))
+	}
+
+	if err := os.WriteFile(filePath, []byte(builder.String()), 0644); err != nil {
+		return fmt.Errorf("写入 %s 失败: %w", filePath, err)
+	}
+
+	return nil
+}
+
+// String 格式化为 名称: 来源 -> 深色值
+func (d DerivedDark) String() string {
+	return fmt.Sprintf("%s: %s -> %s", d.label(), d.Source.Hex, d.Dark.Hex)
+}
+
+// label 推导结果的位置，如 primary、primary (variants[0] ipad)、品牌 acme 的 primary
+func (d DerivedDark) label() string {
+	name := d.Name
+	if d.Variant >= 0 {
+		name = fmt.Sprintf("%s (variants[%d] %s)", d.Name, d.Variant, d.Idiom)
+	}
+	if d.Brand != "" {
+		name = fmt.Sprintf("品牌 %s 的 %s", d.Brand, name)
+	}
+	return name
+}
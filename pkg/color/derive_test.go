@@ -0,0 +1,99 @@
+package color
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsDarkDerived(t *testing.T) {
+	light := &ColorValue{Hex: "#ffffff", Alpha: 1}
+	dark := &ColorValue{Hex: "#000000", Alpha: 1}
+
+	tests := []struct {
+		name    string
+		base    ColorDefinition
+		variant ColorVariant
+		want    bool
+	}{
+		{
+			name:    "变体沿用基础颜色推导出的深色值",
+			base:    ColorDefinition{Light: light, Dark: dark, DarkDerived: true},
+			variant: ColorVariant{Idiom: "ipad"},
+			want:    true,
+		},
+		{
+			name:    "变体自身推导",
+			base:    ColorDefinition{Light: light, Dark: dark},
+			variant: ColorVariant{Idiom: "ipad", Light: light, Dark: dark, DarkDerived: true},
+			want:    true,
+		},
+		{
+			name:    "变体有显式的深色值",
+			base:    ColorDefinition{Light: light, Dark: dark, DarkDerived: true},
+			variant: ColorVariant{Idiom: "ipad", Light: light, Dark: dark},
+			want:    false,
+		},
+		{
+			name:    "都没有推导",
+			base:    ColorDefinition{Light: light, Dark: dark},
+			variant: ColorVariant{Idiom: "ipad"},
+			want:    false,
+		},
+	}
+
+	for _, test := range tests {
+		if got := test.variant.IsDarkDerived(&test.base); got != test.want {
+			t.Errorf("%s: IsDarkDerived() = %v，应为 %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestWriteDerivedReport(t *testing.T) {
+	source := ColorValue{Hex: "#ff0000", Alpha: 1}
+	dark := ColorValue{Hex: "#7e0000", Alpha: 0.5}
+	derived := []DerivedDark{
+		{Name: "primary", Variant: -1, Source: source, Dark: dark},
+		{Name: "primary", Variant: 0, Idiom: "ipad", Source: source, Dark: dark},
+		{Brand: "acme", Name: "accent", Variant: 1, Idiom: "iphone", Source: source, Dark: dark},
+	}
+
+	path := filepath.Join(t.TempDir(), "report", "derived.yaml")
+	if err := WriteDerivedReport(path, derived); err != nil {
+		t.Fatalf("WriteDerivedReport() 失败: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# 自动推导的深色值，设计确认后可复制到colors.yaml中作为显式的dark值
+
+# 来源: #ff0000 alpha 1.0
+primary:
+  dark:
+    hex: "#7e0000"
+    alpha: 0.5
+
+# primary (variants[0] ipad)
+# 来源: #ff0000 alpha 1.0
+# primary:
+#   variants[0]:
+#     dark:
+#       hex: "#7e0000"
+#       alpha: 0.5
+
+# 品牌 acme 的 accent (variants[1] iphone)
+# 来源: #ff0000 alpha 1.0
+# brands:
+#   acme:
+#     accent:
+#       variants[1]:
+#         dark:
+#           hex: "#7e0000"
+#           alpha: 0.5
+`
+	if string(data) != want {
+		t.Errorf("报告内容为:\n%s\n应为:\n%s", data, want)
+	}
+}
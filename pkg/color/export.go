@@ -0,0 +1,98 @@
+package color
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ExportFormats 支持导出的调色板格式
+var ExportFormats = []string{"ase", "gpl", "clr"}
+
+// ExportThemes 支持导出的主题
+var ExportThemes = []string{"default", "light", "dark"}
+
+// paletteEntry 调色板中的一个颜色
+type paletteEntry struct {
+	Name  string
+	Red   float64 // 0-1
+	Green float64 // 0-1
+	Blue  float64 // 0-1
+	Alpha float64 // 0-1
+}
+
+// buildPalette 按YAML顺序构建指定主题的调色板，跳过渐变色
+func buildPalette(colors map[string]*ColorDefinition, theme string) ([]paletteEntry, error) {
+	var entries []paletteEntry
+	for _, name := range sortedColorNames(colors) {
+		color := colors[name]
+		if color.IsGradient() {
+			continue
+		}
+
+		var value ColorValue
+		switch theme {
+		case "default":
+			value = color.GetDefault()
+		case "light":
+			value = color.GetLight()
+		case "dark":
+			value = color.GetDark()
+		default:
+			return nil, fmt.Errorf("无效的主题: %s (必须是 default/light/dark)", theme)
+		}
+		if value.Hex == "" {
+			continue
+		}
+
+		r, g, b, err := hexToRGB(value.Hex)
+		if err != nil {
+			return nil, fmt.Errorf("颜色 %s: %w", name, err)
+		}
+		entries = append(entries, paletteEntry{Name: name, Red: r, Green: g, Blue: b, Alpha: value.Alpha})
+	}
+	return entries, nil
+}
+
+// Export 将颜色导出为设计工具使用的调色板，每个主题一个文件，返回写入的文件路径
+// 文件名为 <name>-<theme>.<format>，如 colors-dark.ase
+func (g *Generator) Export(name string, formats, themes []string) ([]string, error) {
+	if err := g.parseColors(); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(g.outputPath, 0755); err != nil {
+		return nil, fmt.Errorf("创建输出目录失败: %w", err)
+	}
+
+	var written []string
+	for _, theme := range themes {
+		palette, err := buildPalette(g.colors, theme)
+		if err != nil {
+			return written, err
+		}
+
+		paletteName := fmt.Sprintf("%s-%s", name, theme)
+		for _, format := range formats {
+			filePath := filepath.Join(g.outputPath, paletteName+"."+format)
+
+			var data []byte
+			switch format {
+			case "ase":
+				data = encodeASE(paletteName, palette)
+			case "gpl":
+				data = encodeGPL(paletteName, palette)
+			case "clr":
+				data = encodeCLR(paletteName, palette)
+			default:
+				return written, fmt.Errorf("无效的导出格式: %s (必须是 ase/gpl/clr)", format)
+			}
+
+			if err := os.WriteFile(filePath, data, 0644); err != nil {
+				return written, fmt.Errorf("写入 %s 失败: %w", filePath, err)
+			}
+			written = append(written, filePath)
+		}
+	}
+
+	return written, nil
+}
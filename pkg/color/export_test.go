@@ -0,0 +1,306 @@
+package color
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/xml"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode/utf16"
+)
+
+// testPalette 测试使用的调色板，包含半透明颜色和需要转义的名称
+var testPalette = []paletteEntry{
+	{Name: "primary", Red: 1, Green: 0.5, Blue: 0, Alpha: 1},
+	{Name: "mask & <overlay>", Red: 0, Green: 0, Blue: 0, Alpha: 0.5},
+}
+
+// aseColor ASE文件中解码出的颜色块
+type aseColor struct {
+	name       string
+	model      string
+	components [3]float32
+	colorType  uint16
+}
+
+func TestEncodeASE(t *testing.T) {
+	data := encodeASE("colors", testPalette)
+	reader := bytes.NewReader(data)
+
+	header := make([]byte, 4)
+	io.ReadFull(reader, header)
+	if string(header) != "ASEF" {
+		t.Fatalf("文件签名为 %q，应为 ASEF", header)
+	}
+	var major, minor uint16
+	var blockCount uint32
+	binary.Read(reader, binary.BigEndian, &major)
+	binary.Read(reader, binary.BigEndian, &minor)
+	binary.Read(reader, binary.BigEndian, &blockCount)
+	if major != 1 || minor != 0 {
+		t.Errorf("版本为 %d.%d，应为 1.0", major, minor)
+	}
+	if want := uint32(len(testPalette) + 2); blockCount != want {
+		t.Fatalf("块数量为 %d，应为 %d", blockCount, want)
+	}
+
+	var types []uint16
+	var group string
+	var colors []aseColor
+	for i := uint32(0); i < blockCount; i++ {
+		var blockType uint16
+		var length uint32
+		binary.Read(reader, binary.BigEndian, &blockType)
+		binary.Read(reader, binary.BigEndian, &length)
+		body := make([]byte, length)
+		if _, err := io.ReadFull(reader, body); err != nil {
+			t.Fatalf("读取第 %d 个块失败: %v", i, err)
+		}
+		types = append(types, blockType)
+
+		block := bytes.NewReader(body)
+		switch blockType {
+		case aseBlockGroupStart:
+			group = readASEString(t, block)
+		case aseBlockColor:
+			var color aseColor
+			color.name = readASEString(t, block)
+			model := make([]byte, 4)
+			io.ReadFull(block, model)
+			color.model = string(model)
+			binary.Read(block, binary.BigEndian, &color.components)
+			binary.Read(block, binary.BigEndian, &color.colorType)
+			colors = append(colors, color)
+		}
+	}
+	if reader.Len() != 0 {
+		t.Errorf("文件末尾有 %d 字节多余数据", reader.Len())
+	}
+
+	wantTypes := []uint16{aseBlockGroupStart, aseBlockColor, aseBlockColor, aseBlockGroupEnd}
+	if len(types) != len(wantTypes) {
+		t.Fatalf("块类型为 %x，应为 %x", types, wantTypes)
+	}
+	for i := range wantTypes {
+		if types[i] != wantTypes[i] {
+			t.Errorf("第 %d 个块类型为 %x，应为 %x", i, types[i], wantTypes[i])
+		}
+	}
+	if group != "colors" {
+		t.Errorf("分组名称为 %q，应为 colors", group)
+	}
+
+	for i, entry := range testPalette {
+		color := colors[i]
+		want := [3]float32{float32(entry.Red), float32(entry.Green), float32(entry.Blue)}
+		if color.name != entry.Name || color.model != "RGB " || color.components != want || color.colorType != aseColorTypeNormal {
+			t.Errorf("颜色 %d 为 %+v，应为 %s RGB %v", i, color, entry.Name, want)
+		}
+	}
+}
+
+// readASEString 读取以0结尾的UTF-16BE字符串
+func readASEString(t *testing.T, reader io.Reader) string {
+	t.Helper()
+	var length uint16
+	binary.Read(reader, binary.BigEndian, &length)
+	units := make([]uint16, length)
+	if err := binary.Read(reader, binary.BigEndian, units); err != nil {
+		t.Fatalf("读取字符串失败: %v", err)
+	}
+	if length == 0 || units[length-1] != 0 {
+		t.Fatalf("字符串没有以0结尾: %v", units)
+	}
+	return string(utf16.Decode(units[:length-1]))
+}
+
+func TestEncodeGPL(t *testing.T) {
+	tests := []struct {
+		name    string
+		palette []paletteEntry
+		want    string
+	}{
+		{
+			name:    "空调色板",
+			palette: nil,
+			want:    "GIMP Palette\nName: empty\nColumns: 0\n#\n",
+		},
+		{
+			name:    "不透明和半透明颜色",
+			palette: testPalette,
+			want: "GIMP Palette\nName: empty\nColumns: 0\n#\n" +
+				"255 128   0\tprimary\n" +
+				"# mask & <overlay> alpha: 0.5\n" +
+				"  0   0   0\tmask & <overlay>\n",
+		},
+		{
+			name:    "超出范围的分量",
+			palette: []paletteEntry{{Name: "wide", Red: 1.2, Green: -0.1, Blue: 0.2, Alpha: 1}},
+			want:    "GIMP Palette\nName: empty\nColumns: 0\n#\n255   0  51\twide\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := string(encodeGPL("empty", test.palette)); got != test.want {
+				t.Errorf("encodeGPL() =\n%s\n应为\n%s", got, test.want)
+			}
+		})
+	}
+}
+
+// plistValue XML plist中的值
+type plistValue struct {
+	kind     string
+	text     string
+	children []plistValue // dict为键值交替排列，array为元素
+}
+
+// parsePlist 解析XML plist，返回根dict
+func parsePlist(t *testing.T, data []byte) plistValue {
+	t.Helper()
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = true
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			t.Fatalf("解析plist失败: %v", err)
+		}
+		if start, ok := token.(xml.StartElement); ok && start.Name.Local == "plist" {
+			return readPlistValue(t, decoder, nextStart(t, decoder))
+		}
+	}
+}
+
+// nextStart 获取下一个开始标签
+func nextStart(t *testing.T, decoder *xml.Decoder) xml.StartElement {
+	t.Helper()
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			t.Fatalf("解析plist失败: %v", err)
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start
+		}
+	}
+}
+
+// readPlistValue 读取一个值，直到对应的结束标签
+func readPlistValue(t *testing.T, decoder *xml.Decoder, start xml.StartElement) plistValue {
+	t.Helper()
+	value := plistValue{kind: start.Name.Local}
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			t.Fatalf("解析plist失败: %v", err)
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			value.children = append(value.children, readPlistValue(t, decoder, token))
+		case xml.CharData:
+			value.text += string(token)
+		case xml.EndElement:
+			value.text = strings.TrimSpace(value.text)
+			return value
+		}
+	}
+}
+
+// get 获取dict中指定键的值
+func (v plistValue) get(t *testing.T, key string) plistValue {
+	t.Helper()
+	for i := 0; i+1 < len(v.children); i += 2 {
+		if v.children[i].kind == "key" && v.children[i].text == key {
+			return v.children[i+1]
+		}
+	}
+	t.Fatalf("%s 中没有键 %s", v.kind, key)
+	return plistValue{}
+}
+
+// object 获取UID引用指向的$objects中的对象
+func object(t *testing.T, objects, uid plistValue) plistValue {
+	t.Helper()
+	text := uid.get(t, "CF$UID").text
+	index, err := strconv.Atoi(text)
+	if err != nil || index < 0 || index >= len(objects.children) {
+		t.Fatalf("UID %s 超出$objects范围", text)
+	}
+	return objects.children[index]
+}
+
+func TestEncodeCLR(t *testing.T) {
+	root := parsePlist(t, encodeCLR("Brand & Co", testPalette))
+	if archiver := root.get(t, "$archiver").text; archiver != "NSKeyedArchiver" {
+		t.Errorf("$archiver 为 %q，应为 NSKeyedArchiver", archiver)
+	}
+
+	objects := root.get(t, "$objects")
+	if objects.children[0].text != "$null" {
+		t.Errorf("$objects[0] 为 %q，应为 $null", objects.children[0].text)
+	}
+	list := object(t, objects, root.get(t, "$top").get(t, "root"))
+	if class := object(t, objects, list.get(t, "$class")).get(t, "$classname").text; class != "NSColorList" {
+		t.Errorf("根对象类型为 %s，应为 NSColorList", class)
+	}
+	if name := list.get(t, "NSName").text; name != "Brand & Co" {
+		t.Errorf("NSName 为 %q，应为 Brand & Co", name)
+	}
+
+	keys := object(t, objects, list.get(t, "NSKeys")).get(t, "NS.objects").children
+	colors := object(t, objects, list.get(t, "NSColors")).get(t, "NS.objects").children
+	if len(keys) != len(testPalette) || len(colors) != len(testPalette) {
+		t.Fatalf("有 %d 个名称和 %d 个颜色，应各为 %d 个", len(keys), len(colors), len(testPalette))
+	}
+
+	wantComponents := []string{"1 0.5 0 1", "0 0 0 0.5"}
+	for i, entry := range testPalette {
+		if name := object(t, objects, keys[i]).text; name != entry.Name {
+			t.Errorf("颜色 %d 的名称为 %q，应为 %q", i, name, entry.Name)
+		}
+
+		color := object(t, objects, colors[i])
+		if class := object(t, objects, color.get(t, "$class")).get(t, "$classname").text; class != "NSColor" {
+			t.Errorf("颜色 %d 的类型为 %s，应为 NSColor", i, class)
+		}
+		components, err := base64.StdEncoding.DecodeString(color.get(t, "NSComponents").text)
+		if err != nil {
+			t.Fatalf("解码NSComponents失败: %v", err)
+		}
+		if string(components) != wantComponents[i] {
+			t.Errorf("颜色 %d 的分量为 %q，应为 %q", i, components, wantComponents[i])
+		}
+		rgb, _ := base64.StdEncoding.DecodeString(color.get(t, "NSRGB").text)
+		if string(rgb) != wantComponents[i]+"\x00" {
+			t.Errorf("颜色 %d 的NSRGB为 %q，应以0结尾", i, rgb)
+		}
+		space := object(t, objects, color.get(t, "NSCustomColorSpace"))
+		if id := space.get(t, "NSID").text; id != "7" {
+			t.Errorf("颜色 %d 的颜色空间为 %s，应为sRGB (7)", i, id)
+		}
+	}
+}
+
+func TestComponentString(t *testing.T) {
+	tests := []struct {
+		value float64
+		want  string
+	}{
+		{0, "0"},
+		{1, "1"},
+		{0.5, "0.5"},
+		{1.0 / 3, "0.333333"},
+		{math.Nextafter(1, 0), "1"},
+	}
+
+	for _, test := range tests {
+		if got := componentString(test.value); got != test.want {
+			t.Errorf("componentString(%v) = %q，应为 %q", test.value, got, test.want)
+		}
+	}
+}
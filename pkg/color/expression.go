@@ -0,0 +1,512 @@
+package color
+
+import (
+	"app-assets-generator/internal/yamlutil"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ColorSpace 颜色表达式的计算空间
+type ColorSpace string
+
+const (
+	ColorSpaceSRGB  ColorSpace = "srgb"  // 在sRGB/HSL中计算，与CSS预处理器的结果一致
+	ColorSpaceOKLCH ColorSpace = "oklch" // 在OKLCH中计算，亮度变化更均匀
+)
+
+// ParseColorSpace 解析颜色空间名称
+func ParseColorSpace(name string) (ColorSpace, error) {
+	switch space := ColorSpace(strings.ToLower(strings.TrimSpace(name))); space {
+	case "":
+		return ColorSpaceSRGB, nil
+	case ColorSpaceSRGB, ColorSpaceOKLCH:
+		return space, nil
+	}
+	return "", fmt.Errorf("不支持的颜色空间: %s (必须是 srgb/oklch)", name)
+}
+
+// 颜色值所属的主题
+const (
+	themeDefault = "default"
+	themeLight   = "light"
+	themeDark    = "dark"
+)
+
+// expressionSlot 值为表达式或引用的hex字段
+type expressionSlot struct {
+	theme      string      // 所属主题，引用的颜色按相同主题取值
+	value      *ColorValue // 计算结果写入的位置
+	hexNode    *yaml.Node  // hex字段节点，用于报告位置
+	alphaNode  *yaml.Node  // alpha字段节点，显式设置时覆盖表达式结果的透明度
+	prefix     string      // 字段路径前缀（如 "dark."）
+	simple     bool        // 是否为简单颜色的hex字段
+	expression string      // 表达式原文
+}
+
+// impliedThemes 简单颜色或只有default的颜色通过表达式引用主题颜色时，推导出的light/dark值
+type impliedThemes struct {
+	Light *ColorValue
+	Dark  *ColorValue
+}
+
+// 颜色的计算状态
+const (
+	evaluationPending = iota
+	evaluationRunning
+	evaluationDone
+)
+
+// evaluator 计算颜色中的引用和表达式
+type evaluator struct {
+	v       *validator
+	colors  map[string]*ColorDefinition
+	space   ColorSpace
+	slots   map[string][]*expressionSlot
+	state   map[string]int
+	failed  map[string]bool
+	implied map[string]*impliedThemes
+}
+
+// newEvaluator 创建表达式计算器
+func newEvaluator(v *validator, colors map[string]*ColorDefinition, space ColorSpace) *evaluator {
+	return &evaluator{
+		v:       v,
+		colors:  colors,
+		space:   space,
+		slots:   make(map[string][]*expressionSlot),
+		state:   make(map[string]int),
+		failed:  make(map[string]bool),
+		implied: make(map[string]*impliedThemes),
+	}
+}
+
+// isExpression 判断hex字段是否为引用或表达式（不以#开头）
+func isExpression(hex string) bool {
+	hex = strings.TrimSpace(hex)
+	return hex != "" && !strings.HasPrefix(hex, "#")
+}
+
+// collect 收集颜色中所有值为表达式的hex字段
+func (e *evaluator) collect(name string, node *yaml.Node, color *ColorDefinition) {
+	if color.IsGradient() {
+		return
+	}
+
+	if color.IsSimple() {
+		if isExpression(color.Hex) {
+			e.add(name, &expressionSlot{
+				theme:      themeDefault,
+				value:      &ColorValue{Hex: color.Hex, Alpha: color.Alpha},
+				hexNode:    yamlutil.NodeOrSelf(mappingValue(node, "hex"), node),
+				alphaNode:  mappingValue(node, "alpha"),
+				simple:     true,
+				expression: color.Hex,
+			})
+		}
+	} else {
+		e.collectThemes(name, "", node, color.Default, color.Light, color.Dark)
+	}
+
+	listNode := resolveAlias(mappingValue(node, "variants"))
+	for i := range color.Variants {
+		variant := &color.Variants[i]
+		var variantNode *yaml.Node
+		if listNode != nil && listNode.Kind == yaml.SequenceNode && i < len(listNode.Content) {
+			variantNode = resolveAlias(listNode.Content[i])
+		}
+		e.collectThemes(name, fmt.Sprintf("variants[%d].", i), variantNode, variant.Default, variant.Light, variant.Dark)
+	}
+}
+
+// collectThemes 收集default/light/dark中值为表达式的hex字段
+func (e *evaluator) collectThemes(name, prefix string, node *yaml.Node, defaultValue, lightValue, darkValue *ColorValue) {
+	themes := map[string]*ColorValue{themeDefault: defaultValue, themeLight: lightValue, themeDark: darkValue}
+	for _, theme := range []string{themeDefault, themeLight, themeDark} {
+		value := themes[theme]
+		if value == nil || !isExpression(value.Hex) {
+			continue
+		}
+		themeNode := resolveAlias(mappingValue(node, theme))
+		e.add(name, &expressionSlot{
+			theme:      theme,
+			value:      value,
+			hexNode:    yamlutil.NodeOrSelf(mappingValue(themeNode, "hex"), themeNode),
+			alphaNode:  mappingValue(themeNode, "alpha"),
+			prefix:     prefix + theme + ".",
+			expression: value.Hex,
+		})
+	}
+}
+
+// add 记录表达式字段
+func (e *evaluator) add(name string, slot *expressionSlot) {
+	e.slots[name] = append(e.slots[name], slot)
+	if e.v.expressions == nil {
+		e.v.expressions = make(map[*yaml.Node]bool)
+	}
+	e.v.expressions[slot.hexNode] = true
+}
+
+// evaluateAll 计算所有颜色中的表达式
+func (e *evaluator) evaluateAll() {
+	for _, name := range sortedColorNames(e.colors) {
+		e.evaluateColor(name)
+	}
+}
+
+// evaluateColor 计算一个颜色中的所有表达式，引用的颜色会先被计算
+func (e *evaluator) evaluateColor(name string) {
+	if e.state[name] != evaluationPending {
+		return
+	}
+	e.state[name] = evaluationRunning
+	defer func() { e.state[name] = evaluationDone }()
+
+	color := e.colors[name]
+	for _, slot := range e.slots[name] {
+		value, err := e.evaluate(slot.expression, slot.theme)
+		if err != nil {
+			e.v.errorf(slot.hexNode, "颜色 %s 的%shex表达式无效: %v", name, slot.prefix, err)
+			e.failed[name] = true
+			continue
+		}
+		if slot.alphaNode != nil {
+			value.Alpha = slot.value.Alpha
+		}
+		*slot.value = value
+
+		if !slot.simple && slot.prefix != themeDefault+"." {
+			continue
+		}
+		if slot.simple {
+			color.Hex, color.Alpha = value.Hex, value.Alpha
+		}
+
+		// 只定义了default的颜色，按引用颜色的light/dark值推导对应主题
+		if color.Light != nil || color.Dark != nil {
+			continue
+		}
+		implied := &impliedThemes{}
+		for _, theme := range []string{themeLight, themeDark} {
+			themed, err := e.evaluate(slot.expression, theme)
+			if err != nil || themed.Equal(value) {
+				continue
+			}
+			if slot.alphaNode != nil {
+				themed.Alpha = value.Alpha
+			}
+			if theme == themeLight {
+				implied.Light = &themed
+			} else {
+				implied.Dark = &themed
+			}
+		}
+		if implied.Light != nil || implied.Dark != nil {
+			e.implied[name] = implied
+		}
+	}
+}
+
+// applyImplied 将推导出的light/dark值写入颜色定义，简单颜色转换为主题颜色
+// 在校验之后调用，避免对YAML中不存在的字段报告位置
+func (e *evaluator) applyImplied() {
+	for name, implied := range e.implied {
+		color := e.colors[name]
+		if color.IsSimple() {
+			color.Default = &ColorValue{Hex: color.Hex, Alpha: color.Alpha}
+			color.Hex, color.Alpha = "", 0
+		}
+		color.Light = implied.Light
+		color.Dark = implied.Dark
+	}
+}
+
+// resolve 获取引用的颜色在指定主题中的值
+func (e *evaluator) resolve(name, theme string) (ColorValue, error) {
+	color := e.colors[name]
+	if color.IsGradient() {
+		return ColorValue{}, fmt.Errorf("不能引用渐变色 %s", name)
+	}
+	if e.state[name] == evaluationRunning {
+		return ColorValue{}, fmt.Errorf("颜色 %s 存在循环引用", name)
+	}
+	e.evaluateColor(name)
+	if e.failed[name] {
+		return ColorValue{}, fmt.Errorf("引用的颜色 %s 存在错误", name)
+	}
+
+	var value ColorValue
+	implied := e.implied[name]
+	switch {
+	case theme == themeLight && implied != nil && implied.Light != nil && color.Light == nil:
+		value = *implied.Light
+	case theme == themeDark && implied != nil && implied.Dark != nil && color.Dark == nil:
+		value = *implied.Dark
+	case theme == themeLight:
+		value = color.GetLight()
+	case theme == themeDark:
+		value = color.GetDark()
+	default:
+		value = color.GetDefault()
+	}
+
+	if !isValidHex(value.Hex) {
+		return ColorValue{}, fmt.Errorf("引用的颜色 %s 的值无效: %s", name, value.Hex)
+	}
+	return value, nil
+}
+
+// evaluate 计算表达式在指定主题中的值
+// 表达式可以是颜色名称引用、CSS颜色值，或者 mix/alpha/lighten/darken/saturate/desaturate 函数调用
+func (e *evaluator) evaluate(expression, theme string) (ColorValue, error) {
+	expression = strings.TrimSpace(expression)
+
+	if function, args, ok := splitCall(expression); ok {
+		if _, known := expressionFunctions[strings.ToLower(function)]; known {
+			return e.call(strings.ToLower(function), args, theme)
+		}
+		// 其它函数按CSS颜色解析，如 rgb()、hsl()
+		return ParseCSSColor(expression)
+	}
+
+	if isIdentifier(expression) {
+		if _, exists := e.colors[expression]; exists {
+			return e.resolve(expression, theme)
+		}
+		if _, named := cssNamedColors[strings.ToLower(expression)]; !named && expression != "transparent" {
+			return ColorValue{}, fmt.Errorf("引用的颜色 %s 未定义", expression)
+		}
+	}
+	return ParseCSSColor(expression)
+}
+
+// expressionFunctions 支持的函数及参数个数（不含可选的颜色空间参数）
+var expressionFunctions = map[string]int{
+	"mix":        3, // mix(颜色1, 颜色2, 颜色2的比例)
+	"alpha":      2, // alpha(颜色, 透明度)
+	"lighten":    2, // lighten(颜色, 亮度增量)
+	"darken":     2, // darken(颜色, 亮度减量)
+	"saturate":   2, // saturate(颜色, 饱和度增量)
+	"desaturate": 2, // desaturate(颜色, 饱和度减量)
+}
+
+// call 计算函数调用
+func (e *evaluator) call(function string, args []string, theme string) (ColorValue, error) {
+	count := expressionFunctions[function]
+	space := e.space
+	if len(args) == count+1 {
+		var err error
+		if space, err = ParseColorSpace(args[count]); err != nil {
+			return ColorValue{}, fmt.Errorf("%s(): %w", function, err)
+		}
+		args = args[:count]
+	}
+	if len(args) != count {
+		return ColorValue{}, fmt.Errorf("%s() 需要 %d 个参数", function, count)
+	}
+
+	base, err := e.evaluate(args[0], theme)
+	if err != nil {
+		return ColorValue{}, err
+	}
+	amount, err := parseAmount(args[count-1])
+	if err != nil {
+		return ColorValue{}, fmt.Errorf("%s(): %w", function, err)
+	}
+
+	switch function {
+	case "mix":
+		other, err := e.evaluate(args[1], theme)
+		if err != nil {
+			return ColorValue{}, err
+		}
+		return mixColors(base, other, amount, space)
+	case "alpha":
+		return ColorValue{Hex: base.Hex, Alpha: math.Round(clamp01(amount)*1000) / 1000}, nil
+	case "lighten":
+		return adjustLightness(base, amount, space)
+	case "darken":
+		return adjustLightness(base, -amount, space)
+	case "saturate":
+		return adjustSaturation(base, amount, space)
+	default:
+		return adjustSaturation(base, -amount, space)
+	}
+}
+
+// splitCall 拆分函数调用为函数名和顶层参数
+func splitCall(expression string) (function string, args []string, ok bool) {
+	open := strings.Index(expression, "(")
+	if open <= 0 || !strings.HasSuffix(expression, ")") || !isIdentifier(strings.TrimSpace(expression[:open])) {
+		return "", nil, false
+	}
+
+	depth, start := 0, open+1
+	for i := open; i < len(expression); i++ {
+		switch expression[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 && i != len(expression)-1 {
+				return "", nil, false // 如 a(b) c(d)
+			}
+		case ',':
+			if depth == 1 {
+				args = append(args, strings.TrimSpace(expression[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return "", nil, false
+	}
+	args = append(args, strings.TrimSpace(expression[start:len(expression)-1]))
+	return strings.TrimSpace(expression[:open]), args, true
+}
+
+// isIdentifier 判断是否为颜色名称或函数名
+func isIdentifier(text string) bool {
+	if text == "" {
+		return false
+	}
+	for i, r := range text {
+		if !(r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (i > 0 && (r >= '0' && r <= '9' || r == '-'))) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseAmount 解析比例参数，支持 20% 和 0.2 两种写法
+func parseAmount(text string) (float64, error) {
+	text = strings.TrimSpace(text)
+	if strings.HasSuffix(text, "%") {
+		value, err := strconv.ParseFloat(strings.TrimSuffix(text, "%"), 64)
+		if err != nil {
+			return 0, fmt.Errorf("无效的比例: %s", text)
+		}
+		return value / 100, nil
+	}
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return 0, fmt.Errorf("无效的比例: %s", text)
+	}
+	return value, nil
+}
+
+// mixColors 按比例混合两个颜色，amount为第二个颜色所占比例
+func mixColors(a, b ColorValue, amount float64, space ColorSpace) (ColorValue, error) {
+	if amount < 0 || amount > 1 {
+		return ColorValue{}, fmt.Errorf("mix() 的比例必须在0-100%%之间")
+	}
+	ar, ag, ab, err := a.RGB()
+	if err != nil {
+		return ColorValue{}, err
+	}
+	br, bg, bb, err := b.RGB()
+	if err != nil {
+		return ColorValue{}, err
+	}
+
+	alpha := lerp(a.Alpha, b.Alpha, amount)
+	if space == ColorSpaceOKLCH {
+		ca, cb := RGBToOKLCH(ar, ag, ab), RGBToOKLCH(br, bg, bb)
+		// 无彩色没有有意义的色相，使用另一个颜色的色相
+		const achromatic = 1e-4
+		if ca.C < achromatic {
+			ca.H = cb.H
+		}
+		if cb.C < achromatic {
+			cb.H = ca.H
+		}
+		hueDelta := math.Mod(cb.H-ca.H+540, 360) - 180
+		mixed := OKLCH{L: lerp(ca.L, cb.L, amount), C: lerp(ca.C, cb.C, amount), H: ca.H + hueDelta*amount}
+		r, g, bl := mixed.ToRGB()
+		return NewColorValue(r, g, bl, alpha), nil
+	}
+
+	// sRGB中按预乘透明度混合，与CSS color-mix()一致
+	if alpha == 0 {
+		return NewColorValue(lerp(ar, br, amount), lerp(ag, bg, amount), lerp(ab, bb, amount), 0), nil
+	}
+	mix := func(x, y float64) float64 {
+		return lerp(x*a.Alpha, y*b.Alpha, amount) / alpha
+	}
+	return NewColorValue(mix(ar, br), mix(ag, bg), mix(ab, bb), alpha), nil
+}
+
+// adjustLightness 调整亮度，sRGB中调整HSL亮度，OKLCH中调整L
+func adjustLightness(value ColorValue, amount float64, space ColorSpace) (ColorValue, error) {
+	r, g, b, err := value.RGB()
+	if err != nil {
+		return ColorValue{}, err
+	}
+	if space == ColorSpaceOKLCH {
+		c := RGBToOKLCH(r, g, b)
+		c.L = clamp01(c.L + amount)
+		r, g, b = c.ToRGB()
+		return NewColorValue(r, g, b, value.Alpha), nil
+	}
+
+	h, s, l := rgbToHSL(r, g, b)
+	r, g, b = hslToRGB(h, s, clamp01(l+amount))
+	return NewColorValue(r, g, b, value.Alpha), nil
+}
+
+// adjustSaturation 调整饱和度，sRGB中调整HSL饱和度，OKLCH中调整色度（100%对应色度0.4）
+func adjustSaturation(value ColorValue, amount float64, space ColorSpace) (ColorValue, error) {
+	r, g, b, err := value.RGB()
+	if err != nil {
+		return ColorValue{}, err
+	}
+	if space == ColorSpaceOKLCH {
+		c := RGBToOKLCH(r, g, b)
+		c.C = math.Max(0, c.C+amount*0.4)
+		r, g, b = c.ToRGB()
+		return NewColorValue(r, g, b, value.Alpha), nil
+	}
+
+	h, s, l := rgbToHSL(r, g, b)
+	r, g, b = hslToRGB(h, clamp01(s+amount), l)
+	return NewColorValue(r, g, b, value.Alpha), nil
+}
+
+// rgbToHSL 0-1的RGB分量转换为HSL，hue为角度，saturation和lightness为0-1
+func rgbToHSL(r, g, b float64) (hue, saturation, lightness float64) {
+	max := math.Max(r, math.Max(g, b))
+	min := math.Min(r, math.Min(g, b))
+	lightness = (max + min) / 2
+	if max == min {
+		return 0, 0, lightness
+	}
+
+	delta := max - min
+	if lightness > 0.5 {
+		saturation = delta / (2 - max - min)
+	} else {
+		saturation = delta / (max + min)
+	}
+
+	switch max {
+	case r:
+		hue = (g - b) / delta
+		if g < b {
+			hue += 6
+		}
+	case g:
+		hue = (b-r)/delta + 2
+	default:
+		hue = (r-g)/delta + 4
+	}
+	return hue * 60, saturation, lightness
+}
+
+// lerp 线性插值
+func lerp(a, b, t float64) float64 {
+	return a + (b-a)*t
+}
@@ -0,0 +1,235 @@
+package color
+
+import (
+	"app-assets-generator/internal/diag"
+	"strings"
+	"testing"
+)
+
+// parseTestConfig 解析YAML颜色配置
+func parseTestConfig(t *testing.T, yaml string, space ColorSpace) (*Config, diag.Diagnostics) {
+	t.Helper()
+	return parseConfig("colors.yaml", []byte(strings.TrimLeft(yaml, "\n")), ParseOptions{ColorSpace: space})
+}
+
+func TestExpressionFunctions(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		space      ColorSpace
+		want       ColorValue
+	}{
+		{name: "引用", expression: "base", want: ColorValue{Hex: "#336699", Alpha: 1}},
+		{name: "CSS颜色名", expression: "white", want: ColorValue{Hex: "#ffffff", Alpha: 1}},
+		{name: "rgb()", expression: "rgb(255 0 0 / 50%)", want: ColorValue{Hex: "#ff0000", Alpha: 0.5}},
+		{name: "lighten", expression: "lighten(base, 20%)", want: ColorValue{Hex: "#6699cc", Alpha: 1}},
+		{name: "darken", expression: "darken(base, 10%)", want: ColorValue{Hex: "#264d73", Alpha: 1}},
+		{name: "darken小数比例", expression: "darken(base, 0.1)", want: ColorValue{Hex: "#264d73", Alpha: 1}},
+		{name: "darken舍入中间值", expression: "darken(base, 20%)", want: ColorValue{Hex: "#1a334d", Alpha: 1}},
+		{name: "darken超出范围", expression: "darken(base, 80%)", want: ColorValue{Hex: "#000000", Alpha: 1}},
+		{name: "saturate", expression: "saturate(base, 20%)", want: ColorValue{Hex: "#1f66ad", Alpha: 1}},
+		{name: "desaturate", expression: "desaturate(base, 20%)", want: ColorValue{Hex: "#476685", Alpha: 1}},
+		{name: "desaturate为灰色", expression: "desaturate(base, 100%)", want: ColorValue{Hex: "#666666", Alpha: 1}},
+		{name: "mix", expression: "mix(#ff0000, #0000ff, 50%)", want: ColorValue{Hex: "#800080", Alpha: 1}},
+		{name: "mix比例为0", expression: "mix(base, white, 0%)", want: ColorValue{Hex: "#336699", Alpha: 1}},
+		{name: "mix比例为1", expression: "mix(base, white, 100%)", want: ColorValue{Hex: "#ffffff", Alpha: 1}},
+		{name: "mix预乘透明度", expression: "mix(#ff0000, transparent, 50%)", want: ColorValue{Hex: "#ff0000", Alpha: 0.5}},
+		{name: "mix指定OKLCH", expression: "mix(black, white, 0%, oklch)", want: ColorValue{Hex: "#000000", Alpha: 1}},
+		{name: "OKLCH中lighten", expression: "lighten(black, 100%)", space: ColorSpaceOKLCH, want: ColorValue{Hex: "#ffffff", Alpha: 1}},
+		{name: "OKLCH中desaturate", expression: "desaturate(#808080, 50%)", space: ColorSpaceOKLCH, want: ColorValue{Hex: "#808080", Alpha: 1}},
+		{name: "alpha", expression: "alpha(base, 0.25)", want: ColorValue{Hex: "#336699", Alpha: 0.25}},
+		{name: "嵌套调用", expression: "alpha(darken(base, 10%), 50%)", want: ColorValue{Hex: "#264d73", Alpha: 0.5}},
+		{name: "函数名不区分大小写", expression: "Lighten(base, 20%)", want: ColorValue{Hex: "#6699cc", Alpha: 1}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config, diagnostics := parseTestConfig(t, `
+base:
+  hex: "#336699"
+  alpha: 1
+result: "`+test.expression+`"
+`, test.space)
+			if diagnostics.HasErrors() {
+				t.Fatalf("解析失败: %v", diagnostics)
+			}
+			if got := config.Colors["result"].GetDefault(); !got.Equal(test.want) {
+				t.Errorf("%s = %+v，应为 %+v", test.expression, got, test.want)
+			}
+		})
+	}
+}
+
+func TestExpressionErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want []string // 每条错误应包含的内容，按报告顺序
+	}{
+		{
+			name: "未定义的引用",
+			yaml: `
+a: darken(missing, 10%)
+`,
+			want: []string{"引用的颜色 missing 未定义"},
+		},
+		{
+			name: "自引用",
+			yaml: `
+a: lighten(a, 10%)
+`,
+			want: []string{"颜色 a 存在循环引用"},
+		},
+		{
+			name: "循环引用",
+			yaml: `
+a: b
+b: mix(a, white, 50%)
+`,
+			want: []string{"颜色 a 存在循环引用", "引用的颜色 b 存在错误"},
+		},
+		{
+			name: "引用存在错误的颜色",
+			yaml: `
+a: darken(missing, 10%)
+b: alpha(a, 0.5)
+`,
+			want: []string{"missing 未定义", "引用的颜色 a 存在错误"},
+		},
+		{
+			name: "引用渐变色",
+			yaml: `
+hero:
+  type: linear
+  angle: 90
+  stops:
+    - color: "#000000"
+      position: 0
+    - color: "#ffffff"
+      position: 1
+a: hero
+`,
+			want: []string{"不能引用渐变色 hero"},
+		},
+		{
+			name: "参数个数错误",
+			yaml: `
+a: mix(white, black)
+`,
+			want: []string{"mix() 需要 3 个参数"},
+		},
+		{
+			name: "无效的比例",
+			yaml: `
+a: darken(white, much)
+`,
+			want: []string{"无效的比例: much"},
+		},
+		{
+			name: "mix比例超出范围",
+			yaml: `
+a: mix(white, black, 150%)
+`,
+			want: []string{"比例必须在0-100%之间"},
+		},
+		{
+			name: "无效的颜色空间",
+			yaml: `
+a: lighten(white, 10%, hsv)
+`,
+			want: []string{"不支持的颜色空间: hsv"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, diagnostics := parseTestConfig(t, test.yaml, ColorSpaceSRGB)
+			errors := diagnostics.Errors()
+			if len(errors) != len(test.want) {
+				t.Fatalf("得到 %d 个错误，应为 %d 个: %v", len(errors), len(test.want), errors)
+			}
+			for i, want := range test.want {
+				if !strings.Contains(errors[i].Message, want) {
+					t.Errorf("错误 %d 为 %q，应包含 %q", i, errors[i].Message, want)
+				}
+			}
+		})
+	}
+}
+
+func TestExpressionThemes(t *testing.T) {
+	config, diagnostics := parseTestConfig(t, `
+primary:
+  light:
+    hex: "#336699"
+    alpha: 1
+  dark:
+    hex: "#6699cc"
+    alpha: 1
+# 只有默认值的表达式，按被引用颜色的light/dark分别计算
+primary_hover: darken(primary, 10%)
+# 各主题的表达式使用相同主题的值
+primary_mask:
+  light:
+    hex: alpha(primary, 0.5)
+    alpha: 1
+  dark:
+    hex: primary
+    alpha: 0.3
+`, ColorSpaceSRGB)
+	if diagnostics.HasErrors() {
+		t.Fatalf("解析失败: %v", diagnostics)
+	}
+
+	tests := []struct {
+		name  string
+		theme string
+		want  ColorValue
+	}{
+		{name: "primary_hover", theme: themeDefault, want: ColorValue{Hex: "#264d73", Alpha: 1}},
+		{name: "primary_hover", theme: themeLight, want: ColorValue{Hex: "#264d73", Alpha: 1}},
+		{name: "primary_hover", theme: themeDark, want: ColorValue{Hex: "#4080bf", Alpha: 1}},
+		// 显式设置的alpha覆盖表达式结果的透明度
+		{name: "primary_mask", theme: themeLight, want: ColorValue{Hex: "#336699", Alpha: 1}},
+		{name: "primary_mask", theme: themeDark, want: ColorValue{Hex: "#6699cc", Alpha: 0.3}},
+	}
+	for _, test := range tests {
+		color := config.Colors[test.name]
+		var got ColorValue
+		switch test.theme {
+		case themeLight:
+			got = color.GetLight()
+		case themeDark:
+			got = color.GetDark()
+		default:
+			got = color.GetDefault()
+		}
+		if !got.Equal(test.want) {
+			t.Errorf("%s 的 %s 值为 %+v，应为 %+v", test.name, test.theme, got, test.want)
+		}
+	}
+}
+
+func TestSplitCall(t *testing.T) {
+	tests := []struct {
+		expression string
+		function   string
+		args       []string
+		ok         bool
+	}{
+		{expression: "mix(a, b, 50%)", function: "mix", args: []string{"a", "b", "50%"}, ok: true},
+		{expression: "alpha(darken(a, 10%), 0.5)", function: "alpha", args: []string{"darken(a, 10%)", "0.5"}, ok: true},
+		{expression: "rgb(0 0 0 / 50%)", function: "rgb", args: []string{"0 0 0 / 50%"}, ok: true},
+		{expression: "a(b) c(d)"},
+		{expression: "mix(a, b"},
+		{expression: "(a)"},
+		{expression: "primary"},
+	}
+
+	for _, test := range tests {
+		function, args, ok := splitCall(test.expression)
+		if ok != test.ok || function != test.function || strings.Join(args, "|") != strings.Join(test.args, "|") {
+			t.Errorf("splitCall(%q) = %q, %q, %v，应为 %q, %q, %v", test.expression, function, args, ok, test.function, test.args, test.ok)
+		}
+	}
+}
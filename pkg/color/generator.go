@@ -1,22 +1,50 @@
 package color
 
 import (
+	"app-assets-generator/internal/diag"
+	"app-assets-generator/pkg/brand"
+	"app-assets-generator/pkg/xcassets"
 	"fmt"
 	"path/filepath"
+	"sort"
 )
 
 // Generator 颜色资源生成器
 type Generator struct {
 	inputPath  string                      // 输入文件路径
 	outputPath string                      // 输出目录路径
+	options    Options                     // 生成选项
 	colors     map[string]*ColorDefinition // 解析后的颜色数据
+	themes     []Theme                     // 主题
+	brands     []Brand                     // 品牌
+	warnings   diag.Diagnostics            // 解析时发现的警告
+	derived    []DerivedDark               // 自动推导的深色值
+}
+
+// Options 生成选项
+type Options struct {
+	CatalogPath string // 已有的Assets.xcassets路径，设置后iOS资源合并写入该目录
+	Folder      string // 资源目录内的子文件夹，如 Colors
+	
+	SwiftOutput      string // Swift访问器输出文件，为空时不生成
+	KotlinOutput     string // Kotlin访问器输出文件，为空时不生成
+	KotlinPackage    string // Kotlin访问器的包名
+	AndroidNamespace string // R类所在的包名，为空时与KotlinPackage相同
+	
+	AndroidThemePrefix string // Android主题覆盖样式的名称前缀，如 App -> ThemeOverlay.App.Ocean
+	
+	DeriveDark     bool           // 是否为缺少dark值的颜色自动推导深色值
+	DarkDerivation DarkDerivation // 深色值推导配置
+	
+	ColorSpace ColorSpace // 颜色表达式的默认计算空间，为空时使用sRGB
 }
 
 // NewGenerator 创建新的生成器
-func NewGenerator(inputPath, outputPath string) *Generator {
+func NewGenerator(inputPath, outputPath string, options Options) *Generator {
 	return &Generator{
 		inputPath:  inputPath,
 		outputPath: outputPath,
+		options:    options,
 	}
 }
 
@@ -27,9 +55,108 @@ func (g *Generator) GenerateIOS() error {
 		return err
 	}
 	
+	// 确定iOS输出目录
+	outputPath, err := g.iosOutputPath()
+	if err != nil {
+		return err
+	}
+	
+	// 被任一品牌覆盖的颜色不写入共享资源目录，避免与品牌资源目录中的同名颜色冲突
+	overridden := OverriddenColors(g.brands)
+	shared := make(map[string]*ColorDefinition, len(g.colors))
+	for name, color := range g.colors {
+		if !overridden[name] {
+			shared[name] = color
+		}
+	}
+	
 	// 生成iOS资源
-	iosGen := NewIOSGenerator(g.outputPath)
-	return iosGen.Generate(g.colors)
+	iosGen := NewIOSGenerator(outputPath)
+	iosGen.merge = g.options.CatalogPath != ""
+	if err := iosGen.Generate(shared); err != nil {
+		return err
+	}
+	
+	// 每个品牌一个资源目录，包含所有被覆盖的颜色
+	for _, b := range g.brands {
+		if err := g.generateBrandIOS(b, overridden); err != nil {
+			return fmt.Errorf("生成品牌 %s 的iOS资源失败: %w", b.Name, err)
+		}
+	}
+	
+	// 生成Swift访问器
+	if g.options.SwiftOutput != "" {
+		swiftGen := NewSwiftGenerator(g.options.SwiftOutput)
+		if err := swiftGen.Generate(g.colors); err != nil {
+			return fmt.Errorf("生成Swift访问器失败: %w", err)
+		}
+	}
+	
+	return nil
+}
+
+// iosOutputPath 获取iOS输出目录
+// 指定了资源目录时，确保根Contents.json和子文件夹存在，并写入子文件夹
+func (g *Generator) iosOutputPath() (string, error) {
+	if g.options.CatalogPath == "" {
+		return g.outputPath, nil
+	}
+	
+	if err := xcassets.EnsureCatalog(g.options.CatalogPath); err != nil {
+		return "", err
+	}
+	return xcassets.EnsureFolder(g.options.CatalogPath, g.options.Folder)
+}
+
+// generateBrandIOS 生成品牌的iOS资源目录，品牌未覆盖的颜色使用基础颜色
+func (g *Generator) generateBrandIOS(b Brand, overridden map[string]bool) error {
+	catalogPath := g.BrandCatalogPath(b.Name)
+	if err := xcassets.EnsureCatalog(catalogPath); err != nil {
+		return err
+	}
+	outputPath, err := xcassets.EnsureFolder(catalogPath, g.options.Folder)
+	if err != nil {
+		return err
+	}
+	
+	colors := make(map[string]*ColorDefinition, len(overridden))
+	for name := range overridden {
+		if color, ok := b.Colors[name]; ok {
+			colors[name] = color
+		} else {
+			colors[name] = g.colors[name]
+		}
+	}
+	
+	iosGen := NewIOSGenerator(outputPath)
+	iosGen.merge = true
+	return iosGen.Generate(colors)
+}
+
+// BrandCatalogPath 获取品牌的iOS资源目录，指定了--catalog时与其位于同一目录，否则位于输出目录下
+func (g *Generator) BrandCatalogPath(name string) string {
+	return brand.CatalogPath(g.outputPath, g.options.CatalogPath, name)
+}
+
+// AndroidResPath 获取Android资源目录，配置了品牌时为 <输出目录>/src/<源码集>/res
+func (g *Generator) AndroidResPath(sourceSet string) string {
+	if len(g.brands) == 0 {
+		return g.outputPath
+	}
+	return brand.AndroidResPath(g.outputPath, sourceSet)
+}
+
+// Brands 获取配置的品牌名称
+func (g *Generator) Brands() ([]string, error) {
+	if err := g.parseColors(); err != nil {
+		return nil, err
+	}
+	
+	names := make([]string, 0, len(g.brands))
+	for _, b := range g.brands {
+		names = append(names, b.Name)
+	}
+	return names, nil
 }
 
 // GenerateAndroid 生成Android颜色资源
@@ -39,9 +166,40 @@ func (g *Generator) GenerateAndroid() error {
 		return err
 	}
 	
-	// 生成Android资源
-	androidGen := NewAndroidGenerator(g.outputPath)
-	return androidGen.Generate(g.colors)
+	// 生成Android资源，配置了品牌时共享资源写入main源码集
+	androidGen := NewAndroidGenerator(g.AndroidResPath(brand.MainSourceSet))
+	if err := androidGen.Generate(g.colors); err != nil {
+		return err
+	}
+	
+	// 品牌源码集只包含与基础颜色不同的颜色
+	for _, b := range g.brands {
+		if len(b.Colors) == 0 {
+			continue
+		}
+		brandGen := NewAndroidGenerator(g.AndroidResPath(b.Name))
+		brandGen.base = g.colors
+		if err := brandGen.Generate(b.Colors); err != nil {
+			return fmt.Errorf("生成品牌 %s 的Android资源失败: %w", b.Name, err)
+		}
+	}
+	
+	// 生成语义颜色的主题属性和主题覆盖样式
+	if len(g.themes) > 0 {
+		if err := androidGen.GenerateThemes(g.themes, g.options.AndroidThemePrefix); err != nil {
+			return fmt.Errorf("生成Android主题失败: %w", err)
+		}
+	}
+	
+	// 生成Kotlin访问器
+	if g.options.KotlinOutput != "" {
+		kotlinGen := NewKotlinGenerator(g.options.KotlinOutput, g.options.KotlinPackage, g.options.AndroidNamespace)
+		if err := kotlinGen.Generate(g.colors); err != nil {
+			return fmt.Errorf("生成Kotlin访问器失败: %w", err)
+		}
+	}
+	
+	return nil
 }
 
 // parseColors 解析颜色配置（如果还没有解析）
@@ -50,15 +208,63 @@ func (g *Generator) parseColors() error {
 		return nil // 已经解析过了
 	}
 	
-	colors, err := ParseYAML(g.inputPath)
+	config, diagnostics, err := parseConfigFile(g.inputPath, ParseOptions{ColorSpace: g.options.ColorSpace})
 	if err != nil {
 		return fmt.Errorf("解析颜色配置失败: %w", err)
 	}
+	if diagnostics.HasErrors() {
+		return fmt.Errorf("解析颜色配置失败: %w", &diag.ValidationError{Diagnostics: diagnostics})
+	}
+	
+	// 推导缺少的深色值
+	if g.options.DeriveDark {
+		if err := g.options.DarkDerivation.Validate(); err != nil {
+			return fmt.Errorf("深色值推导配置无效: %w", err)
+		}
+		g.derived = DeriveDarkColors(config.Colors, g.options.DarkDerivation)
+		for _, b := range config.Brands {
+			for _, item := range DeriveDarkColors(b.Colors, g.options.DarkDerivation) {
+				item.Brand = b.Name
+				g.derived = append(g.derived, item)
+			}
+		}
+	}
 	
-	g.colors = colors
+	g.colors = config.Colors
+	g.themes = config.Themes
+	g.brands = config.Brands
+	g.warnings = diagnostics.Warnings()
 	return nil
 }
 
+// DerivedDarkColors 获取自动推导的深色值，供设计确认
+func (g *Generator) DerivedDarkColors() []DerivedDark {
+	return g.derived
+}
+
+// Warnings 获取解析颜色配置时发现的警告
+func (g *Generator) Warnings() diag.Diagnostics {
+	return g.warnings
+}
+
+// sortedColorNames 按颜色在YAML中的顺序排序颜色名称
+func sortedColorNames(colors map[string]*ColorDefinition) []string {
+	names := make([]string, 0, len(colors))
+	for name := range colors {
+		names = append(names, name)
+	}
+	
+	sort.Slice(names, func(i, j int) bool {
+		a, b := colors[names[i]], colors[names[j]]
+		if a.Order != b.Order {
+			return a.Order < b.Order
+		}
+		return names[i] < names[j]
+	})
+	
+	return names
+}
+
 // hexToRGB 将十六进制颜色转换为RGB值
 func hexToRGB(hex string) (r, g, b float64, err error) {
 	if len(hex) != 7 || hex[0] != '#' {
@@ -0,0 +1,25 @@
+package color
+
+import (
+	"fmt"
+	"strings"
+)
+
+// encodeGPL 编码为GIMP/Inkscape调色板文件
+// GPL不支持透明度，非不透明颜色的alpha以注释形式保留在颜色行之前
+func encodeGPL(name string, palette []paletteEntry) []byte {
+	var builder strings.Builder
+	builder.WriteString("GIMP Palette\n")
+	fmt.Fprintf(&builder, "Name: %s\n", name)
+	builder.WriteString("Columns: 0\n")
+	builder.WriteString("#\n")
+
+	for _, entry := range palette {
+		if entry.Alpha < 1 {
+			fmt.Fprintf(&builder, "# %s alpha: %s\n", entry.Name, formatFloat(entry.Alpha))
+		}
+		fmt.Fprintf(&builder, "%3d %3d %3d\t%s\n", toByte(entry.Red), toByte(entry.Green), toByte(entry.Blue), entry.Name)
+	}
+
+	return []byte(builder.String())
+}
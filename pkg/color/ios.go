@@ -1,7 +1,7 @@
 package color
 
 import (
-	"encoding/json"
+	"app-assets-generator/pkg/xcassets"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -10,6 +10,7 @@ import (
 // IOSGenerator iOS颜色资源生成器
 type IOSGenerator struct {
 	outputPath string
+	merge      bool // 是否与已有的Contents.json合并
 }
 
 // NewIOSGenerator 创建iOS生成器
@@ -29,7 +30,10 @@ type iOSColorSet struct {
 type iOSColor struct {
 	Color       *iOSColorValue       `json:"color,omitempty"`
 	Appearances []iOSAppearance      `json:"appearances,omitempty"`
+	HeightClass string               `json:"height-class,omitempty"`
 	Idiom       string               `json:"idiom"`
+	WidthClass  string               `json:"width-class,omitempty"`
+	DerivedDark bool                 `json:"derived-dark,omitempty"` // 自动推导的深色值，待设计确认（Xcode会忽略该字段）
 }
 
 // iOSColorValue iOS颜色值
@@ -91,20 +95,7 @@ func (g *IOSGenerator) generateColorSet(outputPath, name string, color *ColorDef
 	
 	// 生成Contents.json
 	contentsPath := filepath.Join(colorsetPath, "Contents.json")
-	file, err := os.Create(contentsPath)
-	if err != nil {
-		return fmt.Errorf("创建Contents.json失败: %w", err)
-	}
-	defer file.Close()
-	
-	// 写入JSON（格式化输出）
-	encoder := json.NewEncoder(file)
-	encoder.SetIndent("", "  ")
-	if err := encoder.Encode(colorSet); err != nil {
-		return fmt.Errorf("写入JSON失败: %w", err)
-	}
-	
-	return nil
+	return xcassets.WriteContents(contentsPath, colorSet, g.merge)
 }
 
 // buildColorSet 构建iOS颜色集数据
@@ -117,48 +108,58 @@ func (g *IOSGenerator) buildColorSet(color *ColorDefinition) iOSColorSet {
 		},
 	}
 	
-	// 获取各主题颜色
-	defaultColor := color.GetDefault()
-	lightColor := color.GetLight()
-	darkColor := color.GetDark()
+	// 通用颜色
+	g.appendThemeColors(&colorSet, iOSColor{Idiom: "universal"}, color.GetDefault(), color.GetLight(), color.GetDark(), color.DarkDerived)
+	
+	// 设备类型/尺寸类别变体
+	for i := range color.Variants {
+		variant := &color.Variants[i]
+		slot := iOSColor{
+			Idiom:       variant.GetIdiom(),
+			WidthClass:  variant.WidthClass,
+			HeightClass: variant.HeightClass,
+		}
+		g.appendThemeColors(&colorSet, slot, variant.GetDefault(color), variant.GetLight(color), variant.GetDark(color), variant.IsDarkDerived(color))
+	}
 	
+	return colorSet
+}
+
+// appendThemeColors 按主题添加颜色条目，slot携带设备类型和尺寸类别，darkDerived为深色值是否自动推导
+func (g *IOSGenerator) appendThemeColors(colorSet *iOSColorSet, slot iOSColor, defaultColor, lightColor, darkColor ColorValue, darkDerived bool) {
 	// 添加默认颜色（Any Appearance）
 	if defaultColor.Hex != "" {
-		colorSet.Colors = append(colorSet.Colors, iOSColor{
-			Color: g.buildColorValue(defaultColor),
-			Idiom: "universal",
-		})
+		entry := slot
+		entry.Color = g.buildColorValue(defaultColor)
+		colorSet.Colors = append(colorSet.Colors, entry)
 	}
 	
 	// 如果有不同的light颜色，添加Light Appearance
 	if lightColor.Hex != "" && (lightColor.Hex != defaultColor.Hex || lightColor.Alpha != defaultColor.Alpha) {
-		colorSet.Colors = append(colorSet.Colors, iOSColor{
-			Appearances: []iOSAppearance{
-				{
-					Appearance: "luminosity",
-					Value:      "light",
-				},
+		entry := slot
+		entry.Appearances = []iOSAppearance{
+			{
+				Appearance: "luminosity",
+				Value:      "light",
 			},
-			Color: g.buildColorValue(lightColor),
-			Idiom: "universal",
-		})
+		}
+		entry.Color = g.buildColorValue(lightColor)
+		colorSet.Colors = append(colorSet.Colors, entry)
 	}
 	
 	// 如果有不同的dark颜色，添加Dark Appearance
 	if darkColor.Hex != "" && (darkColor.Hex != defaultColor.Hex || darkColor.Alpha != defaultColor.Alpha) {
-		colorSet.Colors = append(colorSet.Colors, iOSColor{
-			Appearances: []iOSAppearance{
-				{
-					Appearance: "luminosity",
-					Value:      "dark",
-				},
+		entry := slot
+		entry.Appearances = []iOSAppearance{
+			{
+				Appearance: "luminosity",
+				Value:      "dark",
 			},
-			Color: g.buildColorValue(darkColor),
-			Idiom: "universal",
-		})
+		}
+		entry.Color = g.buildColorValue(darkColor)
+		entry.DerivedDark = darkDerived
+		colorSet.Colors = append(colorSet.Colors, entry)
 	}
-	
-	return colorSet
 }
 
 // buildColorValue 构建iOS颜色值
@@ -0,0 +1,95 @@
+package color
+
+import (
+	"app-assets-generator/internal/naming"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// KotlinGenerator Kotlin(Jetpack Compose)颜色访问器生成器
+type KotlinGenerator struct {
+	outputPath  string // 输出的.kt文件路径
+	packageName string // 生成代码的包名
+	namespace   string // R类所在的包名（Android namespace）
+}
+
+// NewKotlinGenerator 创建Kotlin访问器生成器，namespace为空时使用packageName
+func NewKotlinGenerator(outputPath, packageName, namespace string) *KotlinGenerator {
+	if namespace == "" {
+		namespace = packageName
+	}
+	return &KotlinGenerator{
+		outputPath:  outputPath,
+		packageName: packageName,
+		namespace:   namespace,
+	}
+}
+
+// Generate 生成AppColors对象，通过colorResource读取颜色以支持深色模式
+func (g *KotlinGenerator) Generate(colors map[string]*ColorDefinition) error {
+	if g.packageName == "" {
+		return fmt.Errorf("生成Kotlin代码需要指定包名")
+	}
+	if err := checkAccessorNames(colors); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(g.outputPath), 0755); err != nil {
+		return fmt.Errorf("创建输出目录失败: %w", err)
+	}
+
+	var builder strings.Builder
+	builder.WriteString("// 由 app-assets-generator 自动生成，请勿手动修改\n\n")
+	fmt.Fprintf(&builder, "package %s\n\n", g.packageName)
+	builder.WriteString("import androidx.compose.runtime.Composable\n")
+	builder.WriteString("import androidx.compose.runtime.ReadOnlyComposable\n")
+	builder.WriteString("import androidx.compose.ui.graphics.Color\n")
+	builder.WriteString("import androidx.compose.ui.res.colorResource\n")
+	if g.namespace != g.packageName {
+		fmt.Fprintf(&builder, "import %s.R\n", g.namespace)
+	}
+	builder.WriteString("\nobject AppColors {\n")
+
+	section := ""
+	written := 0
+	for _, name := range sortedColorNames(colors) {
+		color := colors[name]
+		if color.IsGradient() {
+			continue
+		}
+
+		if color.Section != section {
+			if section != "" {
+				builder.WriteString("    // endregion\n")
+			}
+			section = color.Section
+			if written > 0 {
+				builder.WriteString("\n")
+			}
+			fmt.Fprintf(&builder, "    // region %s\n\n", naming.SingleLine(section))
+		}
+
+		if lines := naming.DocLines(accessorDoc(color)); len(lines) > 0 {
+			builder.WriteString("    /**\n")
+			for _, line := range lines {
+				fmt.Fprintf(&builder, "     * %s\n", strings.ReplaceAll(line, "*/", "* /"))
+			}
+			builder.WriteString("     */\n")
+		}
+		fmt.Fprintf(&builder, "    val %s: Color\n", naming.KotlinIdentifier(name))
+		fmt.Fprintf(&builder, "        @Composable @ReadOnlyComposable\n")
+		fmt.Fprintf(&builder, "        get() = colorResource(R.color.%s)\n", name)
+		written++
+	}
+	if section != "" {
+		builder.WriteString("    // endregion\n")
+	}
+	builder.WriteString("}\n")
+
+	if err := os.WriteFile(g.outputPath, []byte(builder.String()), 0644); err != nil {
+		return fmt.Errorf("写入 %s 失败: %w", g.outputPath, err)
+	}
+
+	return nil
+}
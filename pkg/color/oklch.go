@@ -0,0 +1,108 @@
+package color
+
+import (
+	"fmt"
+	"math"
+)
+
+// OKLCH OKLCH颜色空间中的颜色
+type OKLCH struct {
+	L float64 // 感知亮度 0-1
+	C float64 // 色度 >= 0
+	H float64 // 色相角度 0-360
+}
+
+// srgbToLinear sRGB分量转换为线性分量
+func srgbToLinear(c float64) float64 {
+	if c <= 0.04045 {
+		return c / 12.92
+	}
+	return math.Pow((c+0.055)/1.055, 2.4)
+}
+
+// linearToSRGB 线性分量转换为sRGB分量
+func linearToSRGB(c float64) float64 {
+	if c <= 0.0031308 {
+		return 12.92 * c
+	}
+	return 1.055*math.Pow(c, 1/2.4) - 0.055
+}
+
+// RGBToOKLCH 将0-1的sRGB分量转换为OKLCH
+func RGBToOKLCH(r, g, b float64) OKLCH {
+	lr, lg, lb := srgbToLinear(r), srgbToLinear(g), srgbToLinear(b)
+
+	// 线性sRGB -> LMS -> OKLab
+	l := math.Cbrt(0.4122214708*lr + 0.5363325363*lg + 0.0514459929*lb)
+	m := math.Cbrt(0.2119034982*lr + 0.6806995451*lg + 0.1073969566*lb)
+	s := math.Cbrt(0.0883024619*lr + 0.2817188376*lg + 0.6299787005*lb)
+
+	L := 0.2104542553*l + 0.7936177850*m - 0.0040720468*s
+	a := 1.9779984951*l - 2.4285922050*m + 0.4505937099*s
+	bb := 0.0259040371*l + 0.7827717662*m - 0.8086757660*s
+
+	h := math.Atan2(bb, a) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	return OKLCH{L: L, C: math.Hypot(a, bb), H: h}
+}
+
+// toLinearRGB 转换为线性sRGB分量（可能超出0-1）
+func (c OKLCH) toLinearRGB() (r, g, b float64) {
+	a := c.C * math.Cos(c.H*math.Pi/180)
+	bb := c.C * math.Sin(c.H*math.Pi/180)
+
+	l := c.L + 0.3963377774*a + 0.2158037573*bb
+	m := c.L - 0.1055613458*a - 0.0638541728*bb
+	s := c.L - 0.0894841775*a - 1.2914855480*bb
+	l, m, s = l*l*l, m*m*m, s*s*s
+
+	r = 4.0767416621*l - 3.3077115913*m + 0.2309699292*s
+	g = -1.2684380046*l + 2.6097574011*m - 0.3413193965*s
+	b = -0.0041960863*l - 0.7034186147*m + 1.7076147010*s
+	return r, g, b
+}
+
+// inGamut 判断是否在sRGB色域内
+func (c OKLCH) inGamut() bool {
+	const epsilon = 1e-6
+	r, g, b := c.toLinearRGB()
+	return r >= -epsilon && r <= 1+epsilon && g >= -epsilon && g <= 1+epsilon && b >= -epsilon && b <= 1+epsilon
+}
+
+// ToRGB 转换为0-1的sRGB分量，超出色域时保持亮度和色相、降低色度
+func (c OKLCH) ToRGB() (r, g, b float64) {
+	c.L = clamp01(c.L)
+	if !c.inGamut() {
+		low, high := 0.0, c.C
+		for i := 0; i < 24; i++ {
+			mid := (low + high) / 2
+			if (OKLCH{L: c.L, C: mid, H: c.H}).inGamut() {
+				low = mid
+			} else {
+				high = mid
+			}
+		}
+		c.C = low
+	}
+
+	lr, lg, lb := c.toLinearRGB()
+	return clamp01(linearToSRGB(lr)), clamp01(linearToSRGB(lg)), clamp01(linearToSRGB(lb))
+}
+
+// clamp01 限制在0-1之间
+func clamp01(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
+
+// rgbToHex 将0-1的sRGB分量转换为#rrggbb
+func rgbToHex(r, g, b float64) string {
+	return fmt.Sprintf("#%02x%02x%02x", toByte(r), toByte(g), toByte(b))
+}
+
+// toByte 将0-1的分量转换为0-255
+// 先舍去浮点误差再四舍五入，避免 76.49999999 这样的中间值被舍入到另一侧，与CSS预处理器的结果一致
+func toByte(v float64) int {
+	return int(math.Round(math.Round(clamp01(v)*255*1e6) / 1e6))
+}
@@ -1,92 +1,401 @@
 package color
 
 import (
+	"app-assets-generator/internal/diag"
+	"app-assets-generator/internal/naming"
+	"app-assets-generator/internal/yamlutil"
 	"fmt"
 	"os"
+	"regexp"
+	"slices"
+	"strings"
 	
 	"gopkg.in/yaml.v3"
 )
 
-// ParseYAML 解析YAML颜色配置文件
+// ParseYAML 解析YAML颜色配置文件，存在错误时返回*diag.ValidationError
 func ParseYAML(filePath string) (map[string]*ColorDefinition, error) {
-	// 读取文件
+	colors, diagnostics, err := ParseYAMLWithDiagnostics(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if diagnostics.HasErrors() {
+		return nil, &diag.ValidationError{Diagnostics: diagnostics}
+	}
+	
+	return colors, nil
+}
+
+// ParseYAMLWithDiagnostics 解析YAML颜色配置文件，一次性收集所有带位置信息的错误和警告
+// 只有读取文件失败时才返回error
+func ParseYAMLWithDiagnostics(filePath string) (map[string]*ColorDefinition, diag.Diagnostics, error) {
+	return ParseYAMLWithOptions(filePath, ParseOptions{})
+}
+
+// ParseOptions 解析配置
+type ParseOptions struct {
+	ColorSpace ColorSpace // 颜色表达式的默认计算空间，为空时使用sRGB
+}
+
+// ParseYAMLWithOptions 按指定配置解析YAML颜色配置文件，一次性收集所有带位置信息的错误和警告
+// 只有读取文件失败时才返回error
+func ParseYAMLWithOptions(filePath string, options ParseOptions) (map[string]*ColorDefinition, diag.Diagnostics, error) {
+	config, diagnostics, err := parseConfigFile(filePath, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	return config.Colors, diagnostics, nil
+}
+
+// Config colors.yaml中的完整配置
+type Config struct {
+	Colors map[string]*ColorDefinition // 调色板颜色
+	Themes []Theme                     // 主题（来自保留字段themes）
+	Brands []Brand                     // 品牌（来自保留字段brands）
+}
+
+// parseConfigFile 读取并解析完整配置，只有读取文件失败时才返回error
+func parseConfigFile(filePath string, options ParseOptions) (*Config, diag.Diagnostics, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
-		return nil, fmt.Errorf("读取文件失败: %w", err)
+		return nil, nil, fmt.Errorf("读取文件失败: %w", err)
+	}
+	if options.ColorSpace == "" {
+		options.ColorSpace = ColorSpaceSRGB
 	}
 	
+	config, diagnostics := parseConfig(filePath, data, options)
+	return config, diagnostics, nil
+}
+
+// colorEntry YAML中的一个颜色定义
+type colorEntry struct {
+	name      string
+	keyNode   *yaml.Node
+	valueNode *yaml.Node
+	comment   string // 颜色前的注释
+	section   string // 所属分组
+}
+
+// parsedColor 解析后待校验的颜色
+type parsedColor struct {
+	colorEntry
+	color *ColorDefinition
+}
+
+// parseConfig 通过yaml.Node解析颜色配置，保留每个节点的位置信息
+// 先解码所有颜色，再计算引用和表达式，最后校验颜色、主题和品牌
+func parseConfig(filePath string, data []byte, options ParseOptions) (*Config, diag.Diagnostics) {
+	v := &validator{file: filePath}
+	var entries []colorEntry
+	var themesNode, brandsNode *yaml.Node
+	
 	// 解析YAML
-	var colors map[string]*ColorDefinition
-	if err := yaml.Unmarshal(data, &colors); err != nil {
-		return nil, fmt.Errorf("解析YAML失败: %w", err)
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		v.errorAt(yamlutil.ErrorLine(err), 1, "解析YAML失败: %s", strings.TrimPrefix(err.Error(), "yaml: "))
+		return &Config{}, v.diagnostics
+	}
+	if len(document.Content) == 0 {
+		return &Config{Colors: make(map[string]*ColorDefinition)}, v.diagnostics // 空文件
 	}
 	
-	// 验证颜色值
-	for name, color := range colors {
-		if err := validateColor(name, color); err != nil {
-			return nil, err
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		v.errorf(root, "顶层必须是颜色名称到颜色定义的映射")
+		return &Config{}, v.diagnostics
+	}
+	
+	// 文档开头的注释属于第一个颜色
+	section := ""
+	headComment := document.HeadComment
+	defined := make(map[string]bool)
+	
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		keyNode, valueNode := root.Content[i], root.Content[i+1]
+		name := keyNode.Value
+		
+		// 保留字段
+		switch name {
+		case themesKey:
+			themesNode = valueNode
+			continue
+		case brandsKey:
+			brandsNode = valueNode
+			continue
+		}
+		
+		// 解析注释：分组注释块对后续所有颜色生效，其它注释属于当前颜色
+		comments := []string{headComment, keyNode.HeadComment}
+		headComment = ""
+		var comment string
+		for _, block := range comments {
+			blockSection, blockComment := yamlutil.ParseHeadComment(block)
+			if blockSection != "" {
+				section = blockSection
+			}
+			if blockComment != "" {
+				comment = blockComment
+			}
 		}
+		
+		if defined[name] {
+			v.errorf(keyNode, "颜色 %s 重复定义", name)
+			continue
+		}
+		defined[name] = true
+		if !isValidResourceName(name) {
+			v.warnf(keyNode, "颜色名称 %s 不是有效的资源名称，在Android/Swift中可能无法使用", name)
+		}
+		
+		entries = append(entries, colorEntry{name: name, keyNode: keyNode, valueNode: valueNode, comment: comment, section: section})
 	}
 	
-	return colors, nil
+	config := &Config{Colors: v.buildColors(entries, options)}
+	v.checkIdentifiers(entries, config.Colors)
+	if themesNode != nil {
+		config.Themes = v.parseThemes(themesNode, config.Colors)
+	}
+	if brandsNode != nil {
+		config.Brands = v.parseBrands(brandsNode, entries, config.Colors, options)
+	}
+	
+	return config, v.diagnostics
 }
 
-// validateColor 验证颜色定义
-func validateColor(name string, color *ColorDefinition) error {
-	if color == nil {
-		return fmt.Errorf("颜色 %s 定义为空", name)
+// checkIdentifiers 检查生成相同Swift/Kotlin访问器名称的颜色，如 primary_bg 和 primaryBg
+func (v *validator) checkIdentifiers(entries []colorEntry, colors map[string]*ColorDefinition) {
+	seen := make(map[string]string)
+	for _, entry := range entries {
+		color, ok := colors[entry.name]
+		if !ok || color.IsGradient() {
+			continue // 渐变色不生成访问器
+		}
+		id := naming.Identifier(entry.name)
+		if other, ok := seen[id]; ok {
+			v.warnf(entry.keyNode, "颜色 %s 和 %s 生成相同的Swift/Kotlin访问器名称 %s，生成访问器时会失败", entry.name, other, id)
+			continue
+		}
+		seen[id] = entry.name
+	}
+}
+
+// buildColors 解码颜色定义，计算引用和表达式后校验
+func (v *validator) buildColors(entries []colorEntry, options ParseOptions) map[string]*ColorDefinition {
+	colors := make(map[string]*ColorDefinition)
+	var parsed []parsedColor
+	
+	for _, entry := range entries {
+		name, keyNode, valueNode := entry.name, entry.keyNode, entry.valueNode
+		if valueNode.Tag == "!!null" {
+			v.errorf(keyNode, "颜色 %s 定义为空", name)
+			continue
+		}
+		
+		// 标量简写：值直接为引用或表达式，如 primary_hover: darken(primary, 10%)
+		var color ColorDefinition
+		if scalar := resolveAlias(valueNode); scalar != nil && scalar.Kind == yaml.ScalarNode && isExpression(scalar.Value) {
+			color.Hex = scalar.Value
+		} else if err := valueNode.Decode(&color); err != nil {
+			// 类型错误时yaml.v3仍会继续解码其余字段，因此可以继续校验
+			v.decodeError(valueNode, name, err)
+			if _, ok := err.(*yaml.TypeError); !ok {
+				continue
+			}
+		}
+		
+		color.Comment = entry.comment
+		color.Section = entry.section
+		color.Order = len(colors)
+		
+		colors[name] = &color
+		parsed = append(parsed, parsedColor{colorEntry: entry, color: &color})
+	}
+	
+	// 计算引用和表达式
+	evaluator := newEvaluator(v, colors, options.ColorSpace)
+	for _, entry := range parsed {
+		evaluator.collect(entry.name, entry.valueNode, entry.color)
+	}
+	evaluator.evaluateAll()
+	
+	// 验证颜色值
+	for _, entry := range parsed {
+		v.validateColor(entry.name, entry.keyNode, entry.valueNode, entry.color)
+	}
+	evaluator.applyImplied()
+	
+	return colors
+}
+
+// 各层级允许的字段
+var (
+	colorKeys   = []string{"hex", "alpha", "default", "light", "dark", "variants", "description", "type", "angle", "opacity", "stops"}
+	valueKeys   = []string{"hex", "alpha"}
+	variantKeys = []string{"idiom", "width_class", "height_class", "default", "light", "dark"}
+)
+
+// validIdioms 支持的设备类型
+var validIdioms = []string{"universal", "iphone", "ipad", "mac", "watch", "tv", "vision"}
+
+// validator 收集校验问题
+type validator struct {
+	file        string
+	diagnostics diag.Diagnostics
+	expressions map[*yaml.Node]bool // 值为引用或表达式的hex字段，已在计算时校验
+}
+
+// errorf 在节点位置记录错误
+func (v *validator) errorf(node *yaml.Node, format string, args ...interface{}) {
+	line, column := nodePosition(node)
+	v.add(diag.SeverityError, line, column, format, args...)
+}
+
+// warnf 在节点位置记录警告
+func (v *validator) warnf(node *yaml.Node, format string, args ...interface{}) {
+	line, column := nodePosition(node)
+	v.add(diag.SeverityWarning, line, column, format, args...)
+}
+
+// errorAt 在指定位置记录错误
+func (v *validator) errorAt(line, column int, format string, args ...interface{}) {
+	v.add(diag.SeverityError, line, column, format, args...)
+}
+
+// add 记录问题
+func (v *validator) add(severity diag.Severity, line, column int, format string, args ...interface{}) {
+	v.diagnostics = append(v.diagnostics, diag.Diagnostic{
+		File:     v.file,
+		Line:     line,
+		Column:   column,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// decodeError 将yaml.v3的类型错误转换为带位置的问题
+func (v *validator) decodeError(node *yaml.Node, name string, err error) {
+	typeErr, ok := err.(*yaml.TypeError)
+	if !ok {
+		v.errorf(node, "颜色 %s 解析失败: %v", name, err)
+		return
 	}
 	
+	for _, message := range typeErr.Errors {
+		line := yamlutil.ErrorLine(fmt.Errorf("yaml: %s", message))
+		column := node.Column
+		if found := findNodeAtLine(node, line); found != nil {
+			column = found.Column
+		}
+		if line == 0 {
+			line = node.Line
+		}
+		message = strings.TrimSpace(message[strings.Index(message, ":")+1:])
+		v.errorAt(line, column, "颜色 %s 解析失败: %s", name, message)
+	}
+}
+
+// checkKeys 检查映射节点中的未知字段
+func (v *validator) checkKeys(node *yaml.Node, allowed []string, name, prefix string) {
+	node = resolveAlias(node)
+	if node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i]
+		if key.Value == "<<" || slices.Contains(allowed, key.Value) {
+			continue
+		}
+		v.warnf(key, "颜色 %s 包含未知字段: %s%s", name, prefix, key.Value)
+	}
+}
+
+// validateColor 验证颜色定义
+func (v *validator) validateColor(name string, keyNode, node *yaml.Node, color *ColorDefinition) {
+	v.checkKeys(node, colorKeys, name, "")
+	
 	// 渐变色暂时跳过
 	if color.IsGradient() {
-		return nil
+		return
 	}
 	
 	// 简单颜色验证
 	if color.IsSimple() {
-		if !isValidHex(color.Hex) {
-			return fmt.Errorf("颜色 %s 的hex值无效: %s", name, color.Hex)
-		}
-		if color.Alpha < 0 || color.Alpha > 1 {
-			return fmt.Errorf("颜色 %s 的alpha值必须在0-1之间: %f", name, color.Alpha)
-		}
-		return nil
+		v.validateValue(name, "", node, &ColorValue{Hex: color.Hex, Alpha: color.Alpha})
+		v.validateVariants(name, mappingValue(node, "variants"), color.Variants)
+		return
 	}
 	
 	// 主题颜色验证
 	if color.Default == nil && color.Light == nil && color.Dark == nil {
-		return fmt.Errorf("颜色 %s 必须至少定义一个主题颜色", name)
+		v.errorf(keyNode, "颜色 %s 必须至少定义一个主题颜色", name)
+		return
 	}
 	
 	// 验证各主题颜色
-	if color.Default != nil {
-		if !isValidHex(color.Default.Hex) {
-			return fmt.Errorf("颜色 %s 的default.hex值无效: %s", name, color.Default.Hex)
-		}
-		if color.Default.Alpha < 0 || color.Default.Alpha > 1 {
-			return fmt.Errorf("颜色 %s 的default.alpha值必须在0-1之间: %f", name, color.Default.Alpha)
+	v.validateThemes(name, "", node, color.Default, color.Light, color.Dark)
+	v.validateVariants(name, mappingValue(node, "variants"), color.Variants)
+}
+
+// validateThemes 验证default/light/dark三个主题的颜色值
+func (v *validator) validateThemes(name, prefix string, node *yaml.Node, defaultValue, lightValue, darkValue *ColorValue) {
+	themes := map[string]*ColorValue{"default": defaultValue, "light": lightValue, "dark": darkValue}
+	for _, theme := range []string{"default", "light", "dark"} {
+		if value := themes[theme]; value != nil {
+			v.validateValue(name, prefix+theme+".", mappingValue(node, theme), value)
 		}
 	}
+}
+
+// validateValue 验证单个颜色值，prefix为字段路径前缀（如 "dark."）
+func (v *validator) validateValue(name, prefix string, node *yaml.Node, value *ColorValue) {
+	// 简单颜色的字段与颜色定义处于同一层级，已经在validateColor中检查过
+	if prefix != "" {
+		v.checkKeys(node, valueKeys, name, prefix)
+	}
 	
-	if color.Light != nil {
-		if !isValidHex(color.Light.Hex) {
-			return fmt.Errorf("颜色 %s 的light.hex值无效: %s", name, color.Light.Hex)
-		}
-		if color.Light.Alpha < 0 || color.Light.Alpha > 1 {
-			return fmt.Errorf("颜色 %s 的light.alpha值必须在0-1之间: %f", name, color.Light.Alpha)
-		}
+	hexNode := yamlutil.NodeOrSelf(mappingValue(node, "hex"), node)
+	expression := v.expressions[hexNode]
+	if !expression && !isValidHex(value.Hex) {
+		v.errorf(hexNode, "颜色 %s 的%shex值无效: %s", name, prefix, value.Hex)
 	}
 	
-	if color.Dark != nil {
-		if !isValidHex(color.Dark.Hex) {
-			return fmt.Errorf("颜色 %s 的dark.hex值无效: %s", name, color.Dark.Hex)
+	// 引用和表达式未设置alpha时使用计算结果的透明度
+	alphaNode := mappingValue(node, "alpha")
+	if alphaNode == nil && !expression {
+		v.warnf(hexNode, "颜色 %s 的%salpha未设置，将按0（完全透明）处理", name, prefix)
+	} else if alphaNode != nil && (value.Alpha < 0 || value.Alpha > 1) {
+		v.errorf(alphaNode, "颜色 %s 的%salpha值必须在0-1之间: %f", name, prefix, value.Alpha)
+	}
+}
+
+// validateVariants 验证设备类型/尺寸类别变体
+func (v *validator) validateVariants(name string, node *yaml.Node, variants []ColorVariant) {
+	listNode := resolveAlias(node)
+	for i, variant := range variants {
+		variantNode := listNode
+		if listNode != nil && listNode.Kind == yaml.SequenceNode && i < len(listNode.Content) {
+			variantNode = resolveAlias(listNode.Content[i])
+		}
+		prefix := fmt.Sprintf("variants[%d].", i)
+		v.checkKeys(variantNode, variantKeys, name, prefix)
+		
+		if !slices.Contains(validIdioms, variant.GetIdiom()) {
+			v.errorf(yamlutil.NodeOrSelf(mappingValue(variantNode, "idiom"), variantNode), "颜色 %s 的%sidiom无效: %s", name, prefix, variant.Idiom)
 		}
-		if color.Dark.Alpha < 0 || color.Dark.Alpha > 1 {
-			return fmt.Errorf("颜色 %s 的dark.alpha值必须在0-1之间: %f", name, color.Dark.Alpha)
+		if variant.WidthClass != "" && variant.WidthClass != "compact" && variant.WidthClass != "regular" {
+			v.errorf(yamlutil.NodeOrSelf(mappingValue(variantNode, "width_class"), variantNode), "颜色 %s 的%swidth_class无效: %s (必须是 compact/regular)", name, prefix, variant.WidthClass)
 		}
+		if variant.HeightClass != "" && variant.HeightClass != "compact" && variant.HeightClass != "regular" {
+			v.errorf(yamlutil.NodeOrSelf(mappingValue(variantNode, "height_class"), variantNode), "颜色 %s 的%sheight_class无效: %s (必须是 compact/regular)", name, prefix, variant.HeightClass)
+		}
+		if variant.Default == nil && variant.Light == nil && variant.Dark == nil {
+			v.errorf(variantNode, "颜色 %s 的variants[%d]必须至少定义一个主题颜色", name, i)
+			continue
+		}
+		
+		v.validateThemes(name, prefix, variantNode, variant.Default, variant.Light, variant.Dark)
 	}
-	
-	return nil
 }
 
 // isValidHex 验证十六进制颜色值
@@ -103,4 +412,56 @@ func isValidHex(hex string) bool {
 	}
 	
 	return true
-}
\ No newline at end of file
+}
+
+// resourceNamePattern 在Android资源和Swift/Kotlin标识符中都可用的名称
+var resourceNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// isValidResourceName 验证颜色名称
+func isValidResourceName(name string) bool {
+	return resourceNamePattern.MatchString(name)
+}
+
+// mappingValue 获取映射节点中指定字段的值节点
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	node = resolveAlias(node)
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return resolveAlias(node.Content[i+1])
+		}
+	}
+	return nil
+}
+
+// resolveAlias 解析锚点别名，返回实际的节点
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	for node != nil && node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	return node
+}
+
+// nodePosition 获取节点位置，节点不存在时返回0
+func nodePosition(node *yaml.Node) (line, column int) {
+	if node == nil {
+		return 0, 0
+	}
+	return node.Line, node.Column
+}
+
+// findNodeAtLine 查找指定行的最后一个节点（同一行的键值对中即为值节点）
+func findNodeAtLine(node *yaml.Node, line int) *yaml.Node {
+	var found *yaml.Node
+	if node.Line == line {
+		found = node
+	}
+	for _, child := range node.Content {
+		if match := findNodeAtLine(child, line); match != nil {
+			found = match
+		}
+	}
+	return found
+}
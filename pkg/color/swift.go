@@ -0,0 +1,119 @@
+package color
+
+import (
+	"app-assets-generator/internal/naming"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SwiftGenerator Swift颜色访问器生成器
+type SwiftGenerator struct {
+	outputPath string // 输出的.swift文件路径
+}
+
+// NewSwiftGenerator 创建Swift访问器生成器
+func NewSwiftGenerator(outputPath string) *SwiftGenerator {
+	return &SwiftGenerator{
+		outputPath: outputPath,
+	}
+}
+
+// Generate 生成SwiftUI Color和UIColor的静态访问器
+func (g *SwiftGenerator) Generate(colors map[string]*ColorDefinition) error {
+	if err := checkAccessorNames(colors); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(g.outputPath), 0755); err != nil {
+		return fmt.Errorf("创建输出目录失败: %w", err)
+	}
+
+	var builder strings.Builder
+	builder.WriteString("// 由 app-assets-generator 自动生成，请勿手动修改\n\n")
+	builder.WriteString("import SwiftUI\n")
+	builder.WriteString("#if canImport(UIKit)\nimport UIKit\n#endif\n\n")
+
+	builder.WriteString("public extension Color {\n")
+	g.writeAccessors(&builder, colors, func(name string) string {
+		return fmt.Sprintf("static let %s = Color(\"%s\")", naming.SwiftIdentifier(name), name)
+	})
+	builder.WriteString("}\n\n")
+
+	builder.WriteString("#if canImport(UIKit)\n")
+	builder.WriteString("public extension UIColor {\n")
+	g.writeAccessors(&builder, colors, func(name string) string {
+		return fmt.Sprintf("static let %s = UIColor(named: \"%s\")!", naming.SwiftIdentifier(name), name)
+	})
+	builder.WriteString("}\n")
+	builder.WriteString("#endif\n")
+
+	if err := os.WriteFile(g.outputPath, []byte(builder.String()), 0644); err != nil {
+		return fmt.Errorf("写入 %s 失败: %w", g.outputPath, err)
+	}
+
+	return nil
+}
+
+// writeAccessors 按YAML顺序写入访问器，分组注释块输出为MARK，说明文字输出为文档注释
+func (g *SwiftGenerator) writeAccessors(builder *strings.Builder, colors map[string]*ColorDefinition, declaration func(name string) string) {
+	section := ""
+	written := 0
+	for _, name := range sortedColorNames(colors) {
+		color := colors[name]
+		if color.IsGradient() {
+			continue
+		}
+
+		if color.Section != section {
+			section = color.Section
+			if written > 0 {
+				builder.WriteString("\n")
+			}
+			fmt.Fprintf(builder, "    // MARK: - %s\n\n", naming.SingleLine(section))
+		}
+
+		for _, line := range naming.DocLines(accessorDoc(color)) {
+			fmt.Fprintf(builder, "    /// %s\n", line)
+		}
+		fmt.Fprintf(builder, "    %s\n", declaration(name))
+		written++
+	}
+}
+
+// checkAccessorNames 检查不同颜色是否生成相同的访问器名称，如 primary_bg 和 primaryBg
+func checkAccessorNames(colors map[string]*ColorDefinition) error {
+	seen := make(map[string]string)
+	for _, name := range sortedColorNames(colors) {
+		if colors[name].IsGradient() {
+			continue
+		}
+		id := naming.Identifier(name)
+		if other, ok := seen[id]; ok {
+			return fmt.Errorf("颜色 %s 和 %s 生成相同的访问器名称 %s，请重命名其中一个", other, name, id)
+		}
+		seen[id] = name
+	}
+	return nil
+}
+
+// accessorDoc 获取访问器的文档注释，包含自动推导深色值的标注
+func accessorDoc(color *ColorDefinition) string {
+	doc := color.Doc()
+	if color.DarkDerived {
+		doc = strings.TrimSpace(doc + "\n深色值为自动推导，待设计确认")
+	} else if hasDerivedVariant(color) {
+		doc = strings.TrimSpace(doc + "\n部分设备变体的深色值为自动推导，待设计确认")
+	}
+	return doc
+}
+
+// hasDerivedVariant 判断是否有变体的深色值为自动推导
+func hasDerivedVariant(color *ColorDefinition) bool {
+	for i := range color.Variants {
+		if color.Variants[i].DarkDerived {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,246 @@
+package color
+
+import (
+	"app-assets-generator/internal/diag"
+	"app-assets-generator/internal/naming"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// jsonNode 保留键顺序和位置的JSON节点
+type jsonNode struct {
+	Keys   []string    // 对象的键，按文件中的顺序
+	Values []*jsonNode // 对象的值，与Keys一一对应
+	Value  interface{} // 非对象节点的值（数组按nil处理）
+	Object bool        // 是否为对象
+	Offset int64       // 在文件中的字节偏移
+}
+
+// get 获取对象中指定键的值
+func (n *jsonNode) get(key string) *jsonNode {
+	if n == nil || !n.Object {
+		return nil
+	}
+	for i, k := range n.Keys {
+		if k == key {
+			return n.Values[i]
+		}
+	}
+	return nil
+}
+
+// decodeJSONNode 按顺序解码JSON，记录每个值的位置
+func decodeJSONNode(decoder *json.Decoder, data []byte) (*jsonNode, error) {
+	offset := skipJSONSpace(data, decoder.InputOffset())
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	node := &jsonNode{Offset: offset}
+	switch delim := token.(type) {
+	case json.Delim:
+		if delim == '[' {
+			for decoder.More() {
+				if _, err := decodeJSONNode(decoder, data); err != nil {
+					return nil, err
+				}
+			}
+			_, err = decoder.Token()
+			return node, err
+		}
+		node.Object = true
+		for decoder.More() {
+			keyToken, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeJSONNode(decoder, data)
+			if err != nil {
+				return nil, err
+			}
+			node.Keys = append(node.Keys, keyToken.(string))
+			node.Values = append(node.Values, value)
+		}
+		_, err = decoder.Token()
+		return node, err
+	default:
+		node.Value = token
+		return node, nil
+	}
+}
+
+// skipJSONSpace 跳过空白和分隔符，得到下一个值的起始偏移
+func skipJSONSpace(data []byte, offset int64) int64 {
+	for offset < int64(len(data)) && strings.IndexByte(" \t\r\n,:", data[offset]) >= 0 {
+		offset++
+	}
+	return offset
+}
+
+// offsetPosition 将字节偏移转换为行号和列号（从1开始）
+func offsetPosition(data []byte, offset int64) (line, column int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	before := data[:offset]
+	line = bytes.Count(before, []byte("\n")) + 1
+	column = int(offset) - (bytes.LastIndexByte(before, '\n') + 1) + 1
+	return line, column
+}
+
+// tailwindImporter Tailwind颜色导入器
+type tailwindImporter struct {
+	file        string
+	data        []byte
+	colors      map[string]*ColorDefinition
+	sources     map[string]string // 颜色名称 -> Tailwind键路径，用于检测重名
+	base        map[string]bool   // 来自theme.colors的颜色，可被theme.extend.colors中相同的键覆盖
+	extending   bool              // 是否正在导入theme.extend.colors
+	diagnostics diag.Diagnostics
+}
+
+// ImportTailwind 读取Tailwind theme.colors导出的JSON并转换为颜色定义
+// 支持直接导出的colors对象，以及包含theme.colors/theme.extend.colors的完整配置，
+// 与Tailwind合并配置的方式一样，theme.extend.colors中相同的键覆盖theme.colors中的值；
+// 嵌套的键以下划线连接（如 blue.500 -> blue_500），DEFAULT表示上一级名称本身
+func ImportTailwind(filePath string) (map[string]*ColorDefinition, diag.Diagnostics, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("读取文件失败: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	root, err := decodeJSONNode(decoder, data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("解析JSON失败: %w", err)
+	}
+	if !root.Object {
+		return nil, nil, fmt.Errorf("%s 的顶层必须是对象", filePath)
+	}
+
+	importer := &tailwindImporter{
+		file:    filePath,
+		data:    data,
+		colors:  make(map[string]*ColorDefinition),
+		sources: make(map[string]string),
+		base:    make(map[string]bool),
+	}
+
+	// 定位颜色对象，theme.extend.colors放在最后
+	var palettes []*jsonNode
+	extendIndex := -1
+	if theme := root.get("theme"); theme != nil {
+		palettes = append(palettes, theme.get("colors"), theme.get("extend").get("colors"))
+		extendIndex = 1
+	} else if colors := root.get("colors"); colors != nil {
+		palettes = append(palettes, colors)
+	} else {
+		palettes = append(palettes, root)
+	}
+
+	section := strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))
+	for index, palette := range palettes {
+		importer.extending = index == extendIndex
+		if palette == nil {
+			continue
+		}
+		if !palette.Object {
+			importer.warnf(palette, "颜色配置必须是对象，已忽略")
+			continue
+		}
+		for i, key := range palette.Keys {
+			value := palette.Values[i]
+			if value.Object {
+				// 嵌套的色板以顶层键作为分组
+				if err := importer.walk([]string{key}, value, key); err != nil {
+					return nil, nil, err
+				}
+			} else if err := importer.add([]string{key}, value, section); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	return importer.colors, importer.diagnostics, nil
+}
+
+// walk 递归展开嵌套的颜色对象
+func (t *tailwindImporter) walk(path []string, node *jsonNode, section string) error {
+	for i, key := range node.Keys {
+		childPath := append(append([]string{}, path...), key)
+		if key == "DEFAULT" {
+			childPath = path
+		}
+
+		value := node.Values[i]
+		if value.Object {
+			if err := t.walk(childPath, value, section); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := t.add(childPath, value, section); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// add 添加一个颜色，无法识别的值记为警告并跳过
+func (t *tailwindImporter) add(path []string, node *jsonNode, section string) error {
+	keyPath := strings.Join(path, ".")
+
+	text, ok := node.Value.(string)
+	if !ok {
+		t.warnf(node, "%s 不是颜色字符串，已忽略", keyPath)
+		return nil
+	}
+	value, err := ParseCSSColor(text)
+	if err != nil {
+		t.warnf(node, "%s: %v，已忽略", keyPath, err)
+		return nil
+	}
+
+	name := naming.NormalizeName(strings.Join(path, "_"))
+	if name == "" {
+		return fmt.Errorf("%s 无法转换为有效的颜色名称", keyPath)
+	}
+	order := len(t.colors)
+	if source, exists := t.sources[name]; exists {
+		if !t.extending || !t.base[name] || source != keyPath {
+			return fmt.Errorf("%s 和 %s 转换后的颜色名称重复: %s", source, keyPath, name)
+		}
+		// theme.extend.colors覆盖theme.colors中相同的键，保留原来的顺序
+		order = t.colors[name].Order
+		delete(t.base, name)
+	} else if !t.extending {
+		t.base[name] = true
+	}
+	t.sources[name] = keyPath
+
+	t.colors[name] = &ColorDefinition{
+		Hex:     value.Hex,
+		Alpha:   value.Alpha,
+		Section: section,
+		Order:   order,
+	}
+	return nil
+}
+
+// warnf 记录带位置的警告
+func (t *tailwindImporter) warnf(node *jsonNode, format string, args ...interface{}) {
+	line, column := offsetPosition(t.data, node.Offset)
+	t.diagnostics = append(t.diagnostics, diag.Diagnostic{
+		File:     t.file,
+		Line:     line,
+		Column:   column,
+		Severity: diag.SeverityWarning,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
@@ -0,0 +1,118 @@
+package color
+
+import (
+	"app-assets-generator/internal/yamlutil"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// themesKey colors.yaml中保留的顶层字段，定义语义颜色到调色板颜色的映射
+const themesKey = "themes"
+
+// Theme 主题，为每个语义颜色指定调色板中的颜色
+type Theme struct {
+	Name   string       // 主题名称
+	Colors []ThemeColor // 语义颜色，按文件中的顺序
+}
+
+// ThemeColor 语义颜色到调色板颜色的映射
+type ThemeColor struct {
+	Token   string // 语义颜色名称
+	Color   string // 引用的调色板颜色名称
+	Comment string // 语义颜色前的注释
+}
+
+// Lookup 获取语义颜色引用的调色板颜色
+func (t *Theme) Lookup(token string) (string, bool) {
+	for _, color := range t.Colors {
+		if color.Token == token {
+			return color.Color, true
+		}
+	}
+	return "", false
+}
+
+// ThemeTokens 获取所有主题中出现的语义颜色，按首次出现的顺序
+func ThemeTokens(themes []Theme) []ThemeColor {
+	var tokens []ThemeColor
+	seen := make(map[string]bool)
+	for _, theme := range themes {
+		for _, color := range theme.Colors {
+			if !seen[color.Token] {
+				seen[color.Token] = true
+				tokens = append(tokens, color)
+			}
+		}
+	}
+	return tokens
+}
+
+// parseThemes 解析themes字段并检查引用的调色板颜色
+func (v *validator) parseThemes(node *yaml.Node, colors map[string]*ColorDefinition) []Theme {
+	node = resolveAlias(node)
+	if node == nil || node.Kind != yaml.MappingNode {
+		v.errorf(node, "themes必须是主题名称到语义颜色映射的映射")
+		return nil
+	}
+
+	var themes []Theme
+	themeKeys := make([]*yaml.Node, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], resolveAlias(node.Content[i+1])
+		theme := Theme{Name: keyNode.Value}
+
+		if !isValidResourceName(theme.Name) {
+			v.errorf(keyNode, "主题名称 %s 无效，只能包含字母、数字和下划线", theme.Name)
+		}
+		if valueNode == nil || valueNode.Kind != yaml.MappingNode {
+			v.errorf(keyNode, "主题 %s 必须是语义颜色到调色板颜色的映射", theme.Name)
+			continue
+		}
+
+		for j := 0; j+1 < len(valueNode.Content); j += 2 {
+			tokenNode, colorNode := valueNode.Content[j], resolveAlias(valueNode.Content[j+1])
+			token := tokenNode.Value
+			if !isValidResourceName(token) {
+				v.errorf(tokenNode, "主题 %s 的语义颜色名称 %s 无效，只能包含字母、数字和下划线", theme.Name, token)
+				continue
+			}
+			if colorNode == nil || colorNode.Kind != yaml.ScalarNode {
+				v.errorf(tokenNode, "主题 %s 的 %s 必须是调色板颜色名称", theme.Name, token)
+				continue
+			}
+
+			reference := strings.TrimSpace(colorNode.Value)
+			definition, exists := colors[reference]
+			if !exists {
+				v.errorf(colorNode, "主题 %s 的 %s 引用的颜色 %s 未定义", theme.Name, token, reference)
+				continue
+			}
+			if definition.IsGradient() {
+				v.errorf(colorNode, "主题 %s 的 %s 不能引用渐变色 %s", theme.Name, token, reference)
+				continue
+			}
+
+			_, comment := yamlutil.ParseHeadComment(tokenNode.HeadComment)
+			theme.Colors = append(theme.Colors, ThemeColor{Token: token, Color: reference, Comment: comment})
+		}
+
+		themes = append(themes, theme)
+		themeKeys = append(themeKeys, keyNode)
+	}
+
+	// 主题覆盖样式中缺少的语义颜色在运行时无法解析
+	for i, theme := range themes {
+		var missing []string
+		for _, token := range ThemeTokens(themes) {
+			if _, ok := theme.Lookup(token.Token); !ok {
+				missing = append(missing, token.Token)
+			}
+		}
+		if len(missing) > 0 {
+			v.warnf(themeKeys[i], "主题 %s 缺少语义颜色: %s", theme.Name, strings.Join(missing, ", "))
+		}
+	}
+
+	return themes
+}
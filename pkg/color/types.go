@@ -1,11 +1,34 @@
 package color
 
+import (
+	"math"
+	"strings"
+)
+
 // ColorValue 颜色值定义
 type ColorValue struct {
 	Hex   string  `yaml:"hex"`   // 十六进制颜色值
 	Alpha float64 `yaml:"alpha"` // 透明度 0.0-1.0
 }
 
+// NewColorValue 根据0-1的RGB分量和透明度创建颜色值，透明度保留3位小数
+func NewColorValue(r, g, b, alpha float64) ColorValue {
+	return ColorValue{
+		Hex:   rgbToHex(r, g, b),
+		Alpha: math.Round(clamp01(alpha)*1000) / 1000,
+	}
+}
+
+// RGB 获取0-1的RGB分量
+func (v ColorValue) RGB() (r, g, b float64, err error) {
+	return hexToRGB(v.Hex)
+}
+
+// Equal 判断两个颜色值是否相同（hex不区分大小写，透明度按3位小数比较）
+func (v ColorValue) Equal(other ColorValue) bool {
+	return strings.EqualFold(v.Hex, other.Hex) && math.Round(v.Alpha*1000) == math.Round(other.Alpha*1000)
+}
+
 // ColorDefinition 颜色定义（支持主题）
 type ColorDefinition struct {
 	// 简单模式（不区分主题）
@@ -17,6 +40,19 @@ type ColorDefinition struct {
 	Light   *ColorValue `yaml:"light,omitempty"`   // 浅色主题
 	Dark    *ColorValue `yaml:"dark,omitempty"`    // 深色主题
 	
+	// 设备类型/尺寸类别变体
+	Variants []ColorVariant `yaml:"variants,omitempty"`
+	
+	// 说明文字，会输出到生成的资源文件和代码注释中
+	Description string `yaml:"description,omitempty"`
+	
+	// 以下字段由解析器根据YAML填充
+	Comment string `yaml:"-"` // 颜色定义前的注释
+	Section string `yaml:"-"` // 所属分组（来自YAML中的分组注释块）
+	Order   int    `yaml:"-"` // 在文件中的顺序
+	
+	DarkDerived bool `yaml:"-"` // dark值是否为自动推导
+	
 	// 渐变模式（暂时忽略）
 	Type     string                   `yaml:"type,omitempty"`     // 渐变类型
 	Angle    string                   `yaml:"angle,omitempty"`    // 渐变角度
@@ -24,6 +60,14 @@ type ColorDefinition struct {
 	Stops    []map[string]interface{} `yaml:"stops,omitempty"`    // 渐变停止点
 }
 
+// Doc 获取说明文字，优先使用description字段，其次使用YAML注释
+func (c *ColorDefinition) Doc() string {
+	if c.Description != "" {
+		return c.Description
+	}
+	return c.Comment
+}
+
 // IsSimple 判断是否为简单颜色（不区分主题）
 func (c *ColorDefinition) IsSimple() bool {
 	return c.Hex != "" && c.Default == nil && c.Type == ""
@@ -71,4 +115,71 @@ func (c *ColorDefinition) GetDark() ColorValue {
 	}
 	// 如果没有dark，使用default
 	return c.GetDefault()
-}
\ No newline at end of file
+}
+
+// ColorVariant 颜色变体（按设备类型和尺寸类别区分）
+type ColorVariant struct {
+	Idiom       string `yaml:"idiom,omitempty"`        // 设备类型: universal/iphone/ipad/mac/watch/tv/vision
+	WidthClass  string `yaml:"width_class,omitempty"`  // 宽度尺寸类别: compact/regular
+	HeightClass string `yaml:"height_class,omitempty"` // 高度尺寸类别: compact/regular
+
+	Default *ColorValue `yaml:"default,omitempty"` // 默认颜色
+	Light   *ColorValue `yaml:"light,omitempty"`   // 浅色主题
+	Dark    *ColorValue `yaml:"dark,omitempty"`    // 深色主题
+	
+	DarkDerived bool `yaml:"-"` // dark值是否为自动推导
+}
+
+// GetIdiom 获取设备类型，未设置时为universal
+func (v *ColorVariant) GetIdiom() string {
+	if v.Idiom == "" {
+		return "universal"
+	}
+	return v.Idiom
+}
+
+// IsTablet 判断是否为平板变体（对应Android的sw600dp）
+func (v *ColorVariant) IsTablet() bool {
+	return v.Idiom == "ipad"
+}
+
+// GetDefault 获取变体的默认颜色，未设置时回退到基础颜色
+func (v *ColorVariant) GetDefault(base *ColorDefinition) ColorValue {
+	if v.Default != nil {
+		return *v.Default
+	}
+	if v.Light != nil {
+		return *v.Light
+	}
+	return base.GetDefault()
+}
+
+// GetLight 获取变体的浅色主题颜色
+func (v *ColorVariant) GetLight(base *ColorDefinition) ColorValue {
+	if v.Light != nil {
+		return *v.Light
+	}
+	if v.Default != nil {
+		return *v.Default
+	}
+	return base.GetLight()
+}
+
+// IsDarkDerived 判断变体使用的深色值是否为自动推导（变体自身推导，或沿用基础颜色推导出的深色值）
+func (v *ColorVariant) IsDarkDerived(base *ColorDefinition) bool {
+	if v.Dark != nil || v.Default != nil || v.Light != nil {
+		return v.DarkDerived
+	}
+	return base.DarkDerived
+}
+
+// GetDark 获取变体的深色主题颜色
+func (v *ColorVariant) GetDark(base *ColorDefinition) ColorValue {
+	if v.Dark != nil {
+		return *v.Dark
+	}
+	if v.Default != nil || v.Light != nil {
+		return v.GetDefault(base)
+	}
+	return base.GetDark()
+}
@@ -0,0 +1,92 @@
+package color
+
+import (
+	"app-assets-generator/internal/naming"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// sectionSeparator 分组注释块的分隔线，与示例colors.yaml保持一致
+const sectionSeparator = "# ================================"
+
+// EncodeYAML 按颜色顺序将颜色定义编码为colors.yaml格式
+// 分组输出为分组注释块，说明文字输出为description字段，YAML注释输出为颜色前的注释
+func EncodeYAML(colors map[string]*ColorDefinition) []byte {
+	var builder strings.Builder
+
+	section := ""
+	for i, name := range sortedColorNames(colors) {
+		color := colors[name]
+
+		if color.Section != section {
+			section = color.Section
+			if i > 0 {
+				builder.WriteString("\n")
+			}
+			fmt.Fprintf(&builder, "%s\n# %s\n%s\n", sectionSeparator, naming.SingleLine(section), sectionSeparator)
+		}
+		if i > 0 || section != "" {
+			builder.WriteString("\n")
+		}
+
+		for _, line := range naming.DocLines(color.Comment) {
+			fmt.Fprintf(&builder, "# %s\n", line)
+		}
+		fmt.Fprintf(&builder, "%s:\n", name)
+		if color.Description != "" {
+			fmt.Fprintf(&builder, "  description: %s\n", strconv.Quote(color.Description))
+		}
+
+		if color.IsSimple() {
+			writeYAMLValue(&builder, "  ", ColorValue{Hex: color.Hex, Alpha: color.Alpha})
+		}
+		writeYAMLThemes(&builder, "  ", color.Default, color.Light, color.Dark)
+
+		if len(color.Variants) > 0 {
+			builder.WriteString("  variants:\n")
+			for _, variant := range color.Variants {
+				fmt.Fprintf(&builder, "    - idiom: %s\n", variant.GetIdiom())
+				if variant.WidthClass != "" {
+					fmt.Fprintf(&builder, "      width_class: %s\n", variant.WidthClass)
+				}
+				if variant.HeightClass != "" {
+					fmt.Fprintf(&builder, "      height_class: %s\n", variant.HeightClass)
+				}
+				writeYAMLThemes(&builder, "      ", variant.Default, variant.Light, variant.Dark)
+			}
+		}
+	}
+
+	return []byte(builder.String())
+}
+
+// WriteYAML 将颜色定义写入colors.yaml文件
+func WriteYAML(filePath string, colors map[string]*ColorDefinition) error {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return fmt.Errorf("创建输出目录失败: %w", err)
+	}
+	if err := os.WriteFile(filePath, EncodeYAML(colors), 0644); err != nil {
+		return fmt.Errorf("写入 %s 失败: %w", filePath, err)
+	}
+	return nil
+}
+
+// writeYAMLThemes 写入default/light/dark三个主题
+func writeYAMLThemes(builder *strings.Builder, indent string, defaultValue, lightValue, darkValue *ColorValue) {
+	themes := map[string]*ColorValue{"default": defaultValue, "light": lightValue, "dark": darkValue}
+	for _, theme := range []string{"default", "light", "dark"} {
+		if value := themes[theme]; value != nil {
+			fmt.Fprintf(builder, "%s%s:\n", indent, theme)
+			writeYAMLValue(builder, indent+"  ", *value)
+		}
+	}
+}
+
+// writeYAMLValue 写入hex和alpha字段
+func writeYAMLValue(builder *strings.Builder, indent string, value ColorValue) {
+	fmt.Fprintf(builder, "%shex: \"%s\"\n", indent, strings.ToLower(value.Hex))
+	fmt.Fprintf(builder, "%salpha: %s\n", indent, formatFloat(value.Alpha))
+}
@@ -0,0 +1,88 @@
+package dimens
+
+import (
+	"app-assets-generator/internal/naming"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// AndroidGenerator Android尺寸资源生成器
+type AndroidGenerator struct {
+	outputPath string
+}
+
+// NewAndroidGenerator 创建Android生成器
+func NewAndroidGenerator(outputPath string) *AndroidGenerator {
+	return &AndroidGenerator{
+		outputPath: outputPath,
+	}
+}
+
+// Generate 生成values/dimens.xml，有平板值的令牌另外写入values-sw600dp/dimens.xml
+func (g *AndroidGenerator) Generate(tokens []*Token) error {
+	if err := g.generateDimensXML("values", tokens, func(token *Token) *Dimension {
+		return &token.Value
+	}); err != nil {
+		return err
+	}
+
+	hasTablet := false
+	for _, token := range tokens {
+		if token.Tablet != nil {
+			hasTablet = true
+			break
+		}
+	}
+	if !hasTablet {
+		return nil
+	}
+
+	return g.generateDimensXML("values-sw600dp", tokens, func(token *Token) *Dimension {
+		return token.Tablet
+	})
+}
+
+// generateDimensXML 生成指定目录的dimens.xml，value返回nil的令牌不写入
+// 按令牌在YAML中的顺序输出，分组注释块输出为分组标题，说明文字输出为XML注释
+func (g *AndroidGenerator) generateDimensXML(directory string, tokens []*Token, value func(token *Token) *Dimension) error {
+	dirPath := filepath.Join(g.outputPath, directory)
+	if err := os.MkdirAll(dirPath, 0755); err != nil {
+		return fmt.Errorf("创建%s目录失败: %w", directory, err)
+	}
+
+	var builder strings.Builder
+	builder.WriteString("<?xml version=\"1.0\" encoding=\"utf-8\"?>\n")
+	builder.WriteString("<resources>\n")
+
+	section := ""
+	written := 0
+	for _, token := range tokens {
+		dimension := value(token)
+		if dimension == nil {
+			continue
+		}
+
+		if token.Section != section {
+			section = token.Section
+			if written > 0 {
+				builder.WriteString("\n")
+			}
+			fmt.Fprintf(&builder, "    <!-- ===== %s ===== -->\n", naming.XMLComment(section))
+		}
+		if doc := token.Doc(); doc != "" {
+			fmt.Fprintf(&builder, "    <!-- %s -->\n", naming.XMLComment(doc))
+		}
+		fmt.Fprintf(&builder, "    <dimen name=\"%s\">%s</dimen>\n", token.Name, dimension)
+		written++
+	}
+
+	builder.WriteString("</resources>\n")
+
+	filePath := filepath.Join(dirPath, "dimens.xml")
+	if err := os.WriteFile(filePath, []byte(builder.String()), 0644); err != nil {
+		return fmt.Errorf("写入 %s 失败: %w", filePath, err)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package dimens
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// resolver 解析表达式中引用的其它尺寸
+type resolver func(name string) (Dimension, error)
+
+// evaluate 计算尺寸表达式
+// 支持数值（可带dp/sp单位）、其它尺寸的引用、+ - * / 四则运算和括号，如 spacing_unit * 4、(page_margin - 2dp) / 2
+func evaluate(expression string, resolve resolver) (Dimension, error) {
+	p := &expressionParser{text: expression, resolve: resolve}
+	result, err := p.parseSum()
+	if err != nil {
+		return Dimension{}, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.text) {
+		return Dimension{}, fmt.Errorf("表达式 %s 在 %q 处无法解析", expression, p.text[p.pos:])
+	}
+	return result, nil
+}
+
+// expressionParser 递归下降的表达式解析器
+type expressionParser struct {
+	text    string
+	pos     int
+	resolve resolver
+}
+
+// parseSum 解析加减法
+func (p *expressionParser) parseSum() (Dimension, error) {
+	left, err := p.parseProduct()
+	if err != nil {
+		return Dimension{}, err
+	}
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.text) || (p.text[p.pos] != '+' && p.text[p.pos] != '-') {
+			return left, nil
+		}
+		operator := p.text[p.pos]
+		p.pos++
+		right, err := p.parseProduct()
+		if err != nil {
+			return Dimension{}, err
+		}
+		if left.Unit != right.Unit {
+			return Dimension{}, fmt.Errorf("不能对 %s 和 %s 做加减运算，单位不同", left, right)
+		}
+		if operator == '+' {
+			left.Value += right.Value
+		} else {
+			left.Value -= right.Value
+		}
+	}
+}
+
+// parseProduct 解析乘除法
+func (p *expressionParser) parseProduct() (Dimension, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return Dimension{}, err
+	}
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.text) || (p.text[p.pos] != '*' && p.text[p.pos] != '/') {
+			return left, nil
+		}
+		operator := p.text[p.pos]
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return Dimension{}, err
+		}
+
+		if operator == '*' {
+			// 乘法至少有一边是无单位的倍数
+			if left.Unit != UnitNone && right.Unit != UnitNone {
+				return Dimension{}, fmt.Errorf("不能将 %s 和 %s 相乘，至少一边必须是无单位的数值", left, right)
+			}
+			unit := left.Unit
+			if unit == UnitNone {
+				unit = right.Unit
+			}
+			left = Dimension{Value: left.Value * right.Value, Unit: unit}
+			continue
+		}
+
+		// 除法：尺寸除以数值得到尺寸，相同单位的尺寸相除得到比例
+		if right.Value == 0 {
+			return Dimension{}, fmt.Errorf("除数不能为0")
+		}
+		switch {
+		case right.Unit == UnitNone:
+			left.Value /= right.Value
+		case left.Unit == right.Unit:
+			left = Dimension{Value: left.Value / right.Value}
+		default:
+			return Dimension{}, fmt.Errorf("不能将 %s 除以 %s", left, right)
+		}
+	}
+}
+
+// parseUnary 解析负号
+func (p *expressionParser) parseUnary() (Dimension, error) {
+	p.skipSpace()
+	if p.pos < len(p.text) && p.text[p.pos] == '-' {
+		p.pos++
+		value, err := p.parseUnary()
+		value.Value = -value.Value
+		return value, err
+	}
+	return p.parsePrimary()
+}
+
+// parsePrimary 解析数值、引用和括号
+func (p *expressionParser) parsePrimary() (Dimension, error) {
+	p.skipSpace()
+	if p.pos >= len(p.text) {
+		return Dimension{}, fmt.Errorf("表达式 %s 不完整", p.text)
+	}
+
+	c := p.text[p.pos]
+	switch {
+	case c == '(':
+		p.pos++
+		value, err := p.parseSum()
+		if err != nil {
+			return Dimension{}, err
+		}
+		p.skipSpace()
+		if p.pos >= len(p.text) || p.text[p.pos] != ')' {
+			return Dimension{}, fmt.Errorf("表达式 %s 缺少右括号", p.text)
+		}
+		p.pos++
+		return value, nil
+
+	case c >= '0' && c <= '9' || c == '.':
+		start := p.pos
+		for p.pos < len(p.text) && (p.text[p.pos] >= '0' && p.text[p.pos] <= '9' || p.text[p.pos] == '.') {
+			p.pos++
+		}
+		value, err := strconv.ParseFloat(p.text[start:p.pos], 64)
+		if err != nil {
+			return Dimension{}, fmt.Errorf("无效的数值: %s", p.text[start:p.pos])
+		}
+		unit := p.readWord()
+		switch Unit(unit) {
+		case UnitNone, UnitDP, UnitSP:
+			return Dimension{Value: value, Unit: Unit(unit)}, nil
+		}
+		return Dimension{}, fmt.Errorf("不支持的单位 %s，只支持dp和sp", unit)
+
+	case isWordStart(c):
+		return p.resolve(p.readWord())
+	}
+
+	return Dimension{}, fmt.Errorf("表达式 %s 在 %q 处无法解析", p.text, p.text[p.pos:])
+}
+
+// readWord 读取标识符或单位
+func (p *expressionParser) readWord() string {
+	start := p.pos
+	for p.pos < len(p.text) && (isWordStart(p.text[p.pos]) || p.text[p.pos] >= '0' && p.text[p.pos] <= '9') {
+		p.pos++
+	}
+	return p.text[start:p.pos]
+}
+
+// skipSpace 跳过空白
+func (p *expressionParser) skipSpace() {
+	for p.pos < len(p.text) && strings.ContainsRune(" \t", rune(p.text[p.pos])) {
+		p.pos++
+	}
+}
+
+// isWordStart 判断是否为标识符的首字符
+func isWordStart(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_'
+}
@@ -0,0 +1,86 @@
+package dimens
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestEvaluate(t *testing.T) {
+	tokens := map[string]Dimension{
+		"unit":   {Value: 4, Unit: UnitDP},
+		"body":   {Value: 16, Unit: UnitSP},
+		"factor": {Value: 1.5},
+	}
+	resolve := func(name string) (Dimension, error) {
+		if value, ok := tokens[name]; ok {
+			return value, nil
+		}
+		return Dimension{}, fmt.Errorf("引用的尺寸 %s 未定义", name)
+	}
+
+	tests := []struct {
+		expression string
+		want       string // 结果，或错误应包含的内容
+		err        bool
+	}{
+		{expression: "16dp", want: "16dp"},
+		{expression: ".5sp", want: "0.5sp"},
+		{expression: "unit * 4", want: "16dp"},
+		{expression: "4 * unit", want: "16dp"},
+		{expression: "unit*factor", want: "6dp"},
+		{expression: "2 + 3 * unit", err: true, want: "不能对 2 和 12dp 做加减运算"},
+		{expression: "(unit + 2dp) / 2", want: "3dp"},
+		{expression: "unit - 10dp", want: "-6dp"},
+		{expression: "-unit * 2", want: "-8dp"},
+		{expression: "- -unit", want: "4dp"},
+		{expression: "10dp - 2dp - 3dp", want: "5dp"},
+		{expression: "24dp / 3 / 2", want: "4dp"},
+		{expression: "12dp / unit", want: "3"}, // 相同单位相除得到比例
+		{expression: "body * 1.25", want: "20sp"},
+		{expression: "  unit  ", want: "4dp"},
+		{expression: "10dp / 3", want: "3.33dp"},
+		{expression: "unit + body", err: true, want: "单位不同"},
+		{expression: "unit * unit", err: true, want: "至少一边必须是无单位的数值"},
+		{expression: "unit / 0", err: true, want: "除数不能为0"},
+		{expression: "unit / body", err: true, want: "不能将 4dp 除以 16sp"},
+		{expression: "4px", err: true, want: "不支持的单位 px"},
+		{expression: "1.2.3dp", err: true, want: "无效的数值"},
+		{expression: "(unit + 2dp", err: true, want: "缺少右括号"},
+		{expression: "unit +", err: true, want: "不完整"},
+		{expression: "unit 2", err: true, want: "在 \"2\" 处无法解析"},
+		{expression: "unit % 2", err: true, want: "无法解析"},
+		{expression: "missing * 2", err: true, want: "引用的尺寸 missing 未定义"},
+	}
+
+	for _, test := range tests {
+		got, err := evaluate(test.expression, resolve)
+		if test.err {
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Errorf("evaluate(%q) 的错误为 %v，应包含 %q", test.expression, err, test.want)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("evaluate(%q) 失败: %v", test.expression, err)
+		} else if got.String() != test.want {
+			t.Errorf("evaluate(%q) = %s，应为 %s", test.expression, got, test.want)
+		}
+	}
+}
+
+func TestFormatNumber(t *testing.T) {
+	tests := map[float64]string{
+		16:       "16",
+		0.5:      "0.5",
+		1.005:    "1",
+		3.333333: "3.33",
+		-0.001:   "0",
+		-2.5:     "-2.5",
+	}
+	for value, want := range tests {
+		if got := FormatNumber(value); got != want {
+			t.Errorf("FormatNumber(%g) = %q，应为 %q", value, got, want)
+		}
+	}
+}
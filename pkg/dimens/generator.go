@@ -0,0 +1,95 @@
+package dimens
+
+import (
+	"app-assets-generator/internal/diag"
+	"fmt"
+)
+
+// Generator 尺寸资源生成器
+type Generator struct {
+	inputPath  string           // 输入文件路径
+	outputPath string           // Android res目录路径
+	options    Options          // 生成选项
+	tokens     []*Token         // 解析后的令牌，按文件顺序
+	warnings   diag.Diagnostics // 解析时发现的警告
+}
+
+// Options 生成选项
+type Options struct {
+	SwiftOutput string // Swift常量输出文件，为空时不生成
+	SwiftType   string // Swift枚举名称，为空时使用 DefaultSwiftType
+
+	KotlinOutput     string // Kotlin(Compose)访问器输出文件，为空时不生成
+	KotlinPackage    string // Kotlin访问器的包名
+	AndroidNamespace string // R类所在的包名，为空时与KotlinPackage相同
+}
+
+// NewGenerator 创建新的生成器
+func NewGenerator(inputPath, outputPath string, options Options) *Generator {
+	return &Generator{
+		inputPath:  inputPath,
+		outputPath: outputPath,
+		options:    options,
+	}
+}
+
+// GenerateIOS 生成Swift尺寸常量
+func (g *Generator) GenerateIOS() error {
+	if err := g.parseTokens(); err != nil {
+		return err
+	}
+	if g.options.SwiftOutput == "" {
+		return fmt.Errorf("生成iOS尺寸需要指定Swift输出文件")
+	}
+
+	swiftGen := NewSwiftGenerator(g.options.SwiftOutput, g.options.SwiftType)
+	if err := swiftGen.Generate(g.tokens); err != nil {
+		return fmt.Errorf("生成Swift尺寸常量失败: %w", err)
+	}
+	return nil
+}
+
+// GenerateAndroid 生成Android尺寸资源和Compose访问器
+func (g *Generator) GenerateAndroid() error {
+	if err := g.parseTokens(); err != nil {
+		return err
+	}
+
+	androidGen := NewAndroidGenerator(g.outputPath)
+	if err := androidGen.Generate(g.tokens); err != nil {
+		return err
+	}
+
+	if g.options.KotlinOutput != "" {
+		kotlinGen := NewKotlinGenerator(g.options.KotlinOutput, g.options.KotlinPackage, g.options.AndroidNamespace)
+		if err := kotlinGen.Generate(g.tokens); err != nil {
+			return fmt.Errorf("生成Kotlin尺寸访问器失败: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Warnings 获取解析尺寸配置时发现的警告
+func (g *Generator) Warnings() diag.Diagnostics {
+	return g.warnings
+}
+
+// parseTokens 解析尺寸配置
+func (g *Generator) parseTokens() error {
+	if g.tokens != nil {
+		return nil // 已经解析过了
+	}
+
+	tokens, diagnostics, err := ParseYAML(g.inputPath)
+	if err != nil {
+		return fmt.Errorf("解析尺寸配置失败: %w", err)
+	}
+	if diagnostics.HasErrors() {
+		return fmt.Errorf("解析尺寸配置失败: %w", &diag.ValidationError{Diagnostics: diagnostics})
+	}
+
+	g.tokens = tokens
+	g.warnings = diagnostics.Warnings()
+	return nil
+}
@@ -0,0 +1,109 @@
+package dimens
+
+import (
+	"app-assets-generator/internal/naming"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// KotlinGenerator Kotlin(Jetpack Compose)尺寸访问器生成器
+type KotlinGenerator struct {
+	outputPath  string // 输出的.kt文件路径
+	packageName string // 生成代码的包名
+	namespace   string // R类所在的包名（Android namespace）
+}
+
+// NewKotlinGenerator 创建Kotlin访问器生成器，namespace为空时使用packageName
+func NewKotlinGenerator(outputPath, packageName, namespace string) *KotlinGenerator {
+	if namespace == "" {
+		namespace = packageName
+	}
+	return &KotlinGenerator{
+		outputPath:  outputPath,
+		packageName: packageName,
+		namespace:   namespace,
+	}
+}
+
+// Generate 生成AppDimens对象，通过dimensionResource读取尺寸以支持values-sw600dp
+// dp令牌为Dp，sp令牌转换为TextUnit（资源中的sp已包含字体缩放，toSp会按当前缩放还原）
+func (g *KotlinGenerator) Generate(tokens []*Token) error {
+	if g.packageName == "" {
+		return fmt.Errorf("生成Kotlin代码需要指定包名")
+	}
+	if err := os.MkdirAll(filepath.Dir(g.outputPath), 0755); err != nil {
+		return fmt.Errorf("创建输出目录失败: %w", err)
+	}
+
+	hasSP := false
+	for _, token := range tokens {
+		if token.Value.Unit == UnitSP {
+			hasSP = true
+			break
+		}
+	}
+
+	var builder strings.Builder
+	builder.WriteString("// 由 app-assets-generator 自动生成，请勿手动修改\n\n")
+	fmt.Fprintf(&builder, "package %s\n\n", g.packageName)
+	builder.WriteString("import androidx.compose.runtime.Composable\n")
+	builder.WriteString("import androidx.compose.runtime.ReadOnlyComposable\n")
+	if hasSP {
+		builder.WriteString("import androidx.compose.ui.platform.LocalDensity\n")
+	}
+	builder.WriteString("import androidx.compose.ui.res.dimensionResource\n")
+	builder.WriteString("import androidx.compose.ui.unit.Dp\n")
+	if hasSP {
+		builder.WriteString("import androidx.compose.ui.unit.TextUnit\n")
+	}
+	if g.namespace != g.packageName {
+		fmt.Fprintf(&builder, "import %s.R\n", g.namespace)
+	}
+	builder.WriteString("\nobject AppDimens {\n")
+
+	section := ""
+	written := 0
+	for _, token := range tokens {
+		if token.Section != section {
+			if section != "" {
+				builder.WriteString("    // endregion\n")
+			}
+			section = token.Section
+			if written > 0 {
+				builder.WriteString("\n")
+			}
+			fmt.Fprintf(&builder, "    // region %s\n\n", naming.SingleLine(section))
+		}
+
+		if lines := naming.DocLines(token.Doc()); len(lines) > 0 {
+			builder.WriteString("    /**\n")
+			for _, line := range lines {
+				fmt.Fprintf(&builder, "     * %s\n", strings.ReplaceAll(line, "*/", "* /"))
+			}
+			builder.WriteString("     */\n")
+		}
+		name := naming.Identifier(token.Name)
+		if token.Value.Unit == UnitSP {
+			fmt.Fprintf(&builder, "    val %s: TextUnit\n", name)
+			builder.WriteString("        @Composable @ReadOnlyComposable\n")
+			fmt.Fprintf(&builder, "        get() = with(LocalDensity.current) { dimensionResource(R.dimen.%s).toSp() }\n", token.Name)
+		} else {
+			fmt.Fprintf(&builder, "    val %s: Dp\n", name)
+			builder.WriteString("        @Composable @ReadOnlyComposable\n")
+			fmt.Fprintf(&builder, "        get() = dimensionResource(R.dimen.%s)\n", token.Name)
+		}
+		written++
+	}
+	if section != "" {
+		builder.WriteString("    // endregion\n")
+	}
+	builder.WriteString("}\n")
+
+	if err := os.WriteFile(g.outputPath, []byte(builder.String()), 0644); err != nil {
+		return fmt.Errorf("写入 %s 失败: %w", g.outputPath, err)
+	}
+
+	return nil
+}
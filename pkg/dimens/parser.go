@@ -0,0 +1,265 @@
+package dimens
+
+import (
+	"app-assets-generator/internal/diag"
+	"app-assets-generator/internal/yamlutil"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// tokenKeys 令牌映射中允许的字段
+var tokenKeys = []string{"value", "tablet", "description"}
+
+// namePattern 在Android资源和Swift/Kotlin标识符中都可用的名称
+var namePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// sizeClass 计算表达式时的尺寸类别
+type sizeClass string
+
+const (
+	classDefault sizeClass = "default"
+	classTablet  sizeClass = "tablet"
+)
+
+// ParseYAML 解析尺寸配置文件，按文件中的顺序返回令牌
+// 一次性收集所有带位置信息的错误和警告，只有读取文件失败时才返回error
+func ParseYAML(filePath string) ([]*Token, diag.Diagnostics, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("读取文件失败: %w", err)
+	}
+
+	p := &parser{file: filePath, entries: make(map[string]*entry)}
+	tokens := p.parse(data)
+	return tokens, p.diagnostics, nil
+}
+
+// entry YAML中的一个令牌定义
+type entry struct {
+	token      *Token
+	keyNode    *yaml.Node
+	valueNode  *yaml.Node // 默认值表达式
+	tabletNode *yaml.Node // 平板值表达式，可选
+
+	values  map[sizeClass]Dimension
+	state   map[sizeClass]int // 0未计算 1计算中 2已完成 3失败
+	invalid bool              // 定义本身有误，不参与计算
+}
+
+const (
+	stateVisiting = 1
+	stateDone     = 2
+	stateFailed   = 3
+)
+
+// parser 尺寸配置解析器
+type parser struct {
+	file        string
+	entries     map[string]*entry
+	order       []*entry
+	diagnostics diag.Diagnostics
+}
+
+// errorf 记录节点位置的错误
+func (p *parser) errorf(node *yaml.Node, format string, args ...interface{}) {
+	p.add(diag.SeverityError, node, format, args...)
+}
+
+// warnf 记录节点位置的警告
+func (p *parser) warnf(node *yaml.Node, format string, args ...interface{}) {
+	p.add(diag.SeverityWarning, node, format, args...)
+}
+
+// add 记录问题
+func (p *parser) add(severity diag.Severity, node *yaml.Node, format string, args ...interface{}) {
+	diagnostic := diag.Diagnostic{File: p.file, Severity: severity, Message: fmt.Sprintf(format, args...)}
+	if node != nil {
+		diagnostic.Line, diagnostic.Column = node.Line, node.Column
+	}
+	// 没有平板值的令牌在两个尺寸类别下计算同一个表达式，相同的问题只报告一次
+	for _, existing := range p.diagnostics {
+		if existing == diagnostic {
+			return
+		}
+	}
+	p.diagnostics = append(p.diagnostics, diagnostic)
+}
+
+// parse 解析所有令牌并计算表达式
+func (p *parser) parse(data []byte) []*Token {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		p.diagnostics = append(p.diagnostics, diag.Diagnostic{
+			File:     p.file,
+			Line:     yamlutil.ErrorLine(err),
+			Column:   1,
+			Severity: diag.SeverityError,
+			Message:  "解析YAML失败: " + strings.TrimPrefix(err.Error(), "yaml: "),
+		})
+		return nil
+	}
+	if len(document.Content) == 0 {
+		return nil // 空文件
+	}
+
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		p.errorf(root, "顶层必须是尺寸名称到尺寸定义的映射")
+		return nil
+	}
+
+	// 文档开头的注释属于第一个令牌，分组注释块对后续所有令牌生效
+	section := ""
+	headComment := document.HeadComment
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		keyNode, valueNode := root.Content[i], root.Content[i+1]
+		comments := []string{headComment, keyNode.HeadComment}
+		headComment = ""
+		comment := ""
+		for _, block := range comments {
+			blockSection, blockComment := yamlutil.ParseHeadComment(block)
+			if blockSection != "" {
+				section = blockSection
+			}
+			if blockComment != "" {
+				comment = blockComment
+			}
+		}
+
+		name := keyNode.Value
+		if !namePattern.MatchString(name) {
+			p.errorf(keyNode, "尺寸名称 %s 无效，只能包含字母、数字和下划线，且不能以数字开头", name)
+			continue
+		}
+		if _, exists := p.entries[name]; exists {
+			p.errorf(keyNode, "尺寸 %s 重复定义", name)
+			continue
+		}
+
+		e := &entry{
+			token:   &Token{Name: name, Comment: comment, Section: section},
+			keyNode: keyNode,
+			values:  make(map[sizeClass]Dimension),
+			state:   make(map[sizeClass]int),
+		}
+		p.decode(e, valueNode)
+		p.entries[name] = e
+		p.order = append(p.order, e)
+	}
+
+	// 计算所有令牌的默认值和平板值
+	var tokens []*Token
+	for _, e := range p.order {
+		if e.invalid {
+			continue
+		}
+		value, err := p.resolve(e.token.Name, classDefault)
+		if err != nil {
+			continue
+		}
+		tablet, err := p.resolve(e.token.Name, classTablet)
+		if err != nil {
+			continue
+		}
+
+		if tablet.Unit != value.Unit {
+			p.errorf(yamlutil.NodeOrSelf(e.tabletNode, e.valueNode), "尺寸 %s 的平板值 %s 与默认值 %s 单位不同", e.token.Name, tablet, value)
+			continue
+		}
+
+		e.token.Value = value
+		if tablet.String() != value.String() {
+			e.token.Tablet = &tablet
+		} else if e.tabletNode != nil {
+			p.warnf(e.tabletNode, "尺寸 %s 的平板值与默认值相同，可以删除", e.token.Name)
+		}
+		tokens = append(tokens, e.token)
+	}
+
+	return tokens
+}
+
+// decode 解析令牌定义，支持 name: 16dp 的简写和包含value/tablet/description的映射
+func (p *parser) decode(e *entry, node *yaml.Node) {
+	name := e.token.Name
+	switch node.Kind {
+	case yaml.ScalarNode:
+		e.valueNode = node
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if !slices.Contains(tokenKeys, key.Value) {
+				p.errorf(key, "尺寸 %s 包含未知字段 %s，可用字段: %s", name, key.Value, strings.Join(tokenKeys, ", "))
+				continue
+			}
+			if value.Kind != yaml.ScalarNode {
+				p.errorf(value, "尺寸 %s 的%s必须是字符串", name, key.Value)
+				e.invalid = true
+				continue
+			}
+			switch key.Value {
+			case "value":
+				e.valueNode = value
+			case "tablet":
+				e.tabletNode = value
+			case "description":
+				e.token.Description = value.Value
+			}
+		}
+		if e.valueNode == nil && !e.invalid {
+			p.errorf(e.keyNode, "尺寸 %s 缺少value字段", name)
+			e.invalid = true
+		}
+	default:
+		p.errorf(node, "尺寸 %s 必须是尺寸值（如 16dp）或包含value字段的映射", name)
+		e.invalid = true
+	}
+}
+
+// resolve 计算令牌在指定尺寸类别下的值
+// 平板值未定义时按默认表达式在平板类别下重新计算，引用的令牌有平板值时结果随之变化
+func (p *parser) resolve(name string, class sizeClass) (Dimension, error) {
+	e := p.entries[name]
+	switch e.state[class] {
+	case stateDone:
+		return e.values[class], nil
+	case stateFailed:
+		return Dimension{}, fmt.Errorf("引用的尺寸 %s 存在错误", name)
+	case stateVisiting:
+		return Dimension{}, fmt.Errorf("尺寸 %s 存在循环引用", name)
+	}
+
+	node := e.valueNode
+	if class == classTablet && e.tabletNode != nil {
+		node = e.tabletNode
+	}
+
+	e.state[class] = stateVisiting
+	value, err := evaluate(node.Value, func(reference string) (Dimension, error) {
+		target, ok := p.entries[reference]
+		if !ok {
+			return Dimension{}, fmt.Errorf("引用的尺寸 %s 未定义", reference)
+		}
+		if target.invalid {
+			return Dimension{}, fmt.Errorf("引用的尺寸 %s 存在错误", reference)
+		}
+		return p.resolve(reference, class)
+	})
+	if err == nil && value.Unit == UnitNone {
+		err = fmt.Errorf("计算结果 %s 缺少单位，必须是dp或sp", FormatNumber(value.Value))
+	}
+	if err != nil {
+		e.state[class] = stateFailed
+		p.errorf(node, "尺寸 %s: %v", name, err)
+		return Dimension{}, fmt.Errorf("引用的尺寸 %s 存在错误", name)
+	}
+
+	e.values[class] = value
+	e.state[class] = stateDone
+	return value, nil
+}
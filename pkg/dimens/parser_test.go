@@ -0,0 +1,126 @@
+package dimens
+
+import (
+	"app-assets-generator/internal/diag"
+	"strings"
+	"testing"
+)
+
+// parseSource 解析YAML内容，返回令牌（名称 -> 令牌）和诊断
+func parseSource(source string) (map[string]*Token, diag.Diagnostics) {
+	p := &parser{file: "dimens.yaml", entries: make(map[string]*entry)}
+	tokens := make(map[string]*Token)
+	for _, token := range p.parse([]byte(source)) {
+		tokens[token.Name] = token
+	}
+	return tokens, p.diagnostics
+}
+
+func TestParseTablet(t *testing.T) {
+	tokens, diagnostics := parseSource(`
+spacing_unit:
+  value: 4dp
+  tablet: 6dp
+spacing_md: spacing_unit * 4
+page_margin:
+  value: 16dp
+  tablet: 16dp
+gutter: page_margin / 2
+`)
+	if len(diagnostics) != 1 || diagnostics[0].Severity != diag.SeverityWarning ||
+		!strings.Contains(diagnostics[0].Message, "page_margin 的平板值与默认值相同") || diagnostics[0].Line != 8 {
+		t.Errorf("诊断为 %v，应只有第8行平板值相同的警告", diagnostics)
+	}
+
+	tests := []struct {
+		name   string
+		value  string
+		tablet string // 为空时没有平板值
+	}{
+		{name: "spacing_unit", value: "4dp", tablet: "6dp"},
+		{name: "spacing_md", value: "16dp", tablet: "24dp"}, // 引用的令牌有平板值，结果随之变化
+		{name: "page_margin", value: "16dp"},
+		{name: "gutter", value: "8dp"},
+	}
+	for _, test := range tests {
+		token, ok := tokens[test.name]
+		if !ok {
+			t.Errorf("缺少 %s", test.name)
+			continue
+		}
+		if token.Value.String() != test.value {
+			t.Errorf("%s 的值为 %s，应为 %s", test.name, token.Value, test.value)
+		}
+		tablet := ""
+		if token.Tablet != nil {
+			tablet = token.Tablet.String()
+		}
+		if tablet != test.tablet {
+			t.Errorf("%s 的平板值为 %q，应为 %q", test.name, tablet, test.tablet)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		valid  []string // 仍然解析成功的令牌
+		errors []string // 每条错误应包含的内容，按出现顺序
+	}{
+		{
+			name:   "循环引用",
+			source: "a: b + 1dp\nb: a * 2\nc: 8dp\n",
+			valid:  []string{"c"},
+			errors: []string{"尺寸 b: 尺寸 a 存在循环引用", "尺寸 a: 引用的尺寸 b 存在错误"},
+		},
+		{
+			name:   "引用有错误的令牌",
+			source: "base:\n  description: 缺少值\nderived: base * 2\n",
+			errors: []string{"尺寸 base 缺少value字段", "引用的尺寸 base 存在错误"},
+		},
+		{
+			name:   "计算结果缺少单位",
+			source: "ratio: 16dp / 8dp\n",
+			errors: []string{"计算结果 2 缺少单位"},
+		},
+		{
+			name:   "平板值单位不同",
+			source: "title:\n  value: 20sp\n  tablet: 24dp\n",
+			errors: []string{"平板值 24dp 与默认值 20sp 单位不同"},
+		},
+		{
+			name:   "名称和字段",
+			source: "2x: 8dp\nok: 8dp\nok: 4dp\nbad:\n  value: 1dp\n  phone: 2dp\nlist: [1dp]\n",
+			valid:  []string{"ok", "bad"},
+			errors: []string{"尺寸名称 2x 无效", "尺寸 ok 重复定义", "包含未知字段 phone", "尺寸 list 必须是尺寸值"},
+		},
+		{
+			name:   "顶层不是映射",
+			source: "- 8dp\n",
+			errors: []string{"顶层必须是尺寸名称到尺寸定义的映射"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tokens, diagnostics := parseSource(test.source)
+			if len(tokens) != len(test.valid) {
+				t.Errorf("解析出 %d 个令牌，应为 %v", len(tokens), test.valid)
+			}
+			for _, name := range test.valid {
+				if _, ok := tokens[name]; !ok {
+					t.Errorf("缺少 %s", name)
+				}
+			}
+			if len(diagnostics) != len(test.errors) {
+				t.Fatalf("诊断为 %v，应有 %d 条错误", diagnostics, len(test.errors))
+			}
+			for i, want := range test.errors {
+				if diagnostics[i].Severity != diag.SeverityError || !strings.Contains(diagnostics[i].Message, want) {
+					t.Errorf("诊断 %d 为 %q，应为包含 %q 的错误", i, diagnostics[i].Message, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package dimens
+
+import (
+	"app-assets-generator/internal/naming"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultSwiftType 默认的Swift枚举名称
+const DefaultSwiftType = "Dimens"
+
+// SwiftGenerator Swift尺寸常量生成器
+type SwiftGenerator struct {
+	outputPath string // 输出的.swift文件路径
+	typeName   string // 枚举名称
+}
+
+// NewSwiftGenerator 创建Swift常量生成器，typeName为空时使用DefaultSwiftType
+func NewSwiftGenerator(outputPath, typeName string) *SwiftGenerator {
+	if typeName == "" {
+		typeName = DefaultSwiftType
+	}
+	return &SwiftGenerator{
+		outputPath: outputPath,
+		typeName:   typeName,
+	}
+}
+
+// Generate 生成CGFloat常量的枚举，dp和sp都按1pt输出
+// 有平板值的令牌生成计算属性，在iPad上返回平板值（对应Android的values-sw600dp）
+func (g *SwiftGenerator) Generate(tokens []*Token) error {
+	if err := os.MkdirAll(filepath.Dir(g.outputPath), 0755); err != nil {
+		return fmt.Errorf("创建输出目录失败: %w", err)
+	}
+
+	var builder strings.Builder
+	builder.WriteString("// 由 app-assets-generator 自动生成，请勿手动修改\n\n")
+	builder.WriteString("import CoreGraphics\n")
+	builder.WriteString("#if canImport(UIKit)\nimport UIKit\n#endif\n\n")
+	fmt.Fprintf(&builder, "public enum %s {\n", g.typeName)
+
+	section := ""
+	written := 0
+	for _, token := range tokens {
+		if token.Section != section {
+			section = token.Section
+			if written > 0 {
+				builder.WriteString("\n")
+			}
+			fmt.Fprintf(&builder, "    // MARK: - %s\n\n", naming.SingleLine(section))
+		}
+
+		for _, line := range naming.DocLines(token.Doc()) {
+			fmt.Fprintf(&builder, "    /// %s\n", line)
+		}
+		name := naming.Identifier(token.Name)
+		value := FormatNumber(token.Value.Value)
+		if token.Tablet == nil {
+			fmt.Fprintf(&builder, "    public static let %s: CGFloat = %s\n", name, value)
+		} else {
+			fmt.Fprintf(&builder, "    public static var %s: CGFloat {\n", name)
+			builder.WriteString("        #if canImport(UIKit)\n")
+			fmt.Fprintf(&builder, "        return UIDevice.current.userInterfaceIdiom == .pad ? %s : %s\n", FormatNumber(token.Tablet.Value), value)
+			builder.WriteString("        #else\n")
+			fmt.Fprintf(&builder, "        return %s\n", value)
+			builder.WriteString("        #endif\n")
+			builder.WriteString("    }\n")
+		}
+		written++
+	}
+	builder.WriteString("}\n")
+
+	if err := os.WriteFile(g.outputPath, []byte(builder.String()), 0644); err != nil {
+		return fmt.Errorf("写入 %s 失败: %w", g.outputPath, err)
+	}
+
+	return nil
+}
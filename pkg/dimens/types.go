@@ -0,0 +1,54 @@
+package dimens
+
+import (
+	"math"
+	"strconv"
+)
+
+// Unit 尺寸单位
+type Unit string
+
+const (
+	UnitNone Unit = ""   // 无单位，只能出现在表达式中间结果里，如倍数
+	UnitDP   Unit = "dp" // 密度无关像素，iOS中对应pt
+	UnitSP   Unit = "sp" // 可缩放像素，用于文字大小，iOS中对应pt
+)
+
+// Dimension 带单位的尺寸值
+type Dimension struct {
+	Value float64
+	Unit  Unit
+}
+
+// String 格式化为Android资源中的写法，如 8dp、1.5sp，最多保留2位小数
+func (d Dimension) String() string {
+	return FormatNumber(d.Value) + string(d.Unit)
+}
+
+// FormatNumber 格式化数值，最多保留2位小数并去除多余的0
+func FormatNumber(value float64) string {
+	value = math.Round(value*100) / 100
+	if value == 0 {
+		value = 0 // 避免输出 -0
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// Token 尺寸令牌
+type Token struct {
+	Name   string     // 名称，如 spacing_md
+	Value  Dimension  // 默认值
+	Tablet *Dimension // 平板（values-sw600dp）的值，与默认值相同时为nil
+
+	Description string // 说明文字
+	Comment     string // 令牌前的注释
+	Section     string // 所属分组（来自YAML中的分组注释块）
+}
+
+// Doc 获取说明文字，优先使用description字段，其次使用YAML注释
+func (t *Token) Doc() string {
+	if t.Description != "" {
+		return t.Description
+	}
+	return t.Comment
+}
@@ -0,0 +1,62 @@
+package figma
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL Figma REST API地址
+const DefaultBaseURL = "https://api.figma.com"
+
+// Client Figma Variables REST API客户端
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// NewClient 创建客户端，baseURL为空时使用DefaultBaseURL（测试时可指向本地mock）
+func NewClient(baseURL, token string) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		token:      token,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// LocalVariables 获取文件中的本地变量和变量集合
+func (c *Client) LocalVariables(fileKey string) (*LocalVariablesResponse, error) {
+	url := fmt.Sprintf("%s/v1/files/%s/variables/local", c.baseURL, fileKey)
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %w", err)
+	}
+	request.Header.Set("X-Figma-Token", c.token)
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("请求Figma API失败: %w", err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("读取Figma API响应失败: %w", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Figma API返回错误 %d: %s", response.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var result LocalVariablesResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("解析Figma API响应失败: %w", err)
+	}
+	return &result, nil
+}
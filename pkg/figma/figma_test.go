@@ -0,0 +1,235 @@
+package figma
+
+import (
+	"app-assets-generator/pkg/color"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// variablesJSON 模拟的 GET /v1/files/:file_key/variables/local 响应
+// Semantic集合中的Brand/Primary在浅色模式下是Primitives集合中blue/500的别名
+const variablesJSON = `{
+  "status": 200,
+  "error": false,
+  "meta": {
+    "variableCollections": {
+      "VC:1": {"id": "VC:1", "name": "Primitives", "defaultModeId": "1:0",
+        "modes": [{"modeId": "1:0", "name": "Value"}], "variableIds": ["V:1"]},
+      "VC:2": {"id": "VC:2", "name": "Semantic", "defaultModeId": "2:0",
+        "modes": [{"modeId": "2:0", "name": "Light"}, {"modeId": "2:1", "name": "Dark"}],
+        "variableIds": ["V:2", "V:3", "V:4", "V:5"]}
+    },
+    "variables": {
+      "V:1": {"id": "V:1", "name": "blue/500", "variableCollectionId": "VC:1", "resolvedType": "COLOR",
+        "valuesByMode": {"1:0": {"r": 0, "g": 0.4, "b": 1, "a": 1}}},
+      "V:2": {"id": "V:2", "name": "Brand/Primary", "description": "品牌主色", "variableCollectionId": "VC:2", "resolvedType": "COLOR",
+        "valuesByMode": {"2:0": {"type": "VARIABLE_ALIAS", "id": "V:1"}, "2:1": {"r": 1, "g": 1, "b": 1, "a": 1}}},
+      "V:3": {"id": "V:3", "name": "Text", "variableCollectionId": "VC:2", "resolvedType": "COLOR",
+        "valuesByMode": {"2:0": {"r": 0, "g": 0, "b": 0, "a": 1}, "2:1": {"r": 1, "g": 1, "b": 1, "a": 0.5}}},
+      "V:4": {"id": "V:4", "name": "Spacing", "variableCollectionId": "VC:2", "resolvedType": "FLOAT",
+        "valuesByMode": {"2:0": 8, "2:1": 8}},
+      "V:5": {"id": "V:5", "name": "Library Blue", "variableCollectionId": "VC:2", "resolvedType": "COLOR", "remote": true,
+        "valuesByMode": {"2:0": {"r": 0, "g": 0, "b": 1, "a": 1}, "2:1": {"r": 0, "g": 0, "b": 1, "a": 1}}}
+    }
+  }
+}`
+
+// newTestServer 创建模拟Figma API的服务器，只接受指定文件和令牌的请求
+func newTestServer(t *testing.T, body string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Figma-Token") != "token" {
+			http.Error(w, `{"status":403,"err":"Invalid token"}`, http.StatusForbidden)
+			return
+		}
+		if r.Method != http.MethodGet || r.URL.Path != "/v1/files/FILE/variables/local" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// fetch 从模拟服务器获取变量
+func fetch(t *testing.T, body string) *LocalVariablesResponse {
+	t.Helper()
+	server := newTestServer(t, body)
+	response, err := NewClient(server.URL+"/", "token").LocalVariables("FILE")
+	if err != nil {
+		t.Fatalf("LocalVariables() 失败: %v", err)
+	}
+	return response
+}
+
+// value 创建颜色值指针
+func value(hex string, alpha float64) *color.ColorValue {
+	return &color.ColorValue{Hex: hex, Alpha: alpha}
+}
+
+func TestLocalVariablesError(t *testing.T) {
+	server := newTestServer(t, variablesJSON)
+	_, err := NewClient(server.URL, "wrong").LocalVariables("FILE")
+	if err == nil || !strings.Contains(err.Error(), "403") || !strings.Contains(err.Error(), "Invalid token") {
+		t.Errorf("LocalVariables() 错误为 %v，应包含状态码和响应内容", err)
+	}
+}
+
+func TestToColors(t *testing.T) {
+	colors, err := ToColors(fetch(t, variablesJSON), Options{})
+	if err != nil {
+		t.Fatalf("ToColors() 失败: %v", err)
+	}
+
+	want := map[string]*color.ColorDefinition{
+		"blue_500": {Section: "Primitives", Order: 0, Default: value("#0066ff", 1)},
+		"brand_primary": {Description: "品牌主色", Section: "Semantic", Order: 1,
+			Default: value("#0066ff", 1), Light: value("#0066ff", 1), Dark: value("#ffffff", 1)},
+		"text": {Section: "Semantic", Order: 2,
+			Default: value("#000000", 1), Light: value("#000000", 1), Dark: value("#ffffff", 0.5)},
+	}
+	if len(colors) != len(want) {
+		t.Fatalf("ToColors() 得到 %d 个颜色，应为 %d 个（FLOAT和远程变量应被忽略）", len(colors), len(want))
+	}
+	for name, definition := range want {
+		if !reflect.DeepEqual(colors[name], definition) {
+			t.Errorf("颜色 %s = %+v，应为 %+v", name, colors[name], definition)
+		}
+	}
+}
+
+func TestToColorsOptions(t *testing.T) {
+	colors, err := ToColors(fetch(t, variablesJSON), Options{Collection: "Semantic", LightMode: "dark", DarkMode: "light"})
+	if err != nil {
+		t.Fatalf("ToColors() 失败: %v", err)
+	}
+	if _, ok := colors["blue_500"]; ok {
+		t.Errorf("指定集合后不应包含其它集合的变量")
+	}
+	// 别名目标不在过滤后的集合中时仍能解析
+	primary := colors["brand_primary"]
+	if primary == nil || primary.Light.Hex != "#ffffff" || primary.Dark.Hex != "#0066ff" {
+		t.Errorf("brand_primary = %+v，浅色/深色模式应按指定的名称映射", primary)
+	}
+}
+
+func TestToColorsAliasErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		replace [2]string
+		want    string
+	}{
+		{
+			name:    "循环引用",
+			replace: [2]string{`"1:0": {"r": 0, "g": 0.4, "b": 1, "a": 1}`, `"1:0": {"type": "VARIABLE_ALIAS", "id": "V:2"}`},
+			want:    "循环引用",
+		},
+		{
+			name:    "引用不存在的变量",
+			replace: [2]string{`{"type": "VARIABLE_ALIAS", "id": "V:1"}`, `{"type": "VARIABLE_ALIAS", "id": "V:404"}`},
+			want:    "V:404 不存在",
+		},
+		{
+			name:    "缺少模式的值",
+			replace: [2]string{`"2:1": {"r": 1, "g": 1, "b": 1, "a": 0.5}`, `"9:9": {"r": 1, "g": 1, "b": 1, "a": 0.5}`},
+			want:    "缺少模式 2:1 的值",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			body := strings.Replace(variablesJSON, test.replace[0], test.replace[1], 1)
+			if body == variablesJSON {
+				t.Fatalf("测试数据中没有 %s", test.replace[0])
+			}
+			_, err := ToColors(fetch(t, body), Options{})
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Errorf("ToColors() 错误为 %v，应包含 %q", err, test.want)
+			}
+		})
+	}
+}
+
+func TestPlanPush(t *testing.T) {
+	local := map[string]*color.ColorDefinition{
+		// 浅色模式的别名会被替换，深色模式不变
+		"brand_primary": {Order: 0, Light: value("#ff0000", 1), Dark: value("#ffffff", 1)},
+		// 与Figma一致，没有变更
+		"text": {Order: 1, Light: value("#000000", 1), Dark: value("#ffffff", 0.5)},
+		// 新建在第一个集合中
+		"accent": {Order: 2, Description: "强调色", Default: value("#00ff00", 0.8)},
+		// 渐变色不推送
+		"hero": {Order: 3, Type: "linear"},
+	}
+
+	plan, err := PlanPush(fetch(t, variablesJSON), local, Options{})
+	if err != nil {
+		t.Fatalf("PlanPush() 失败: %v", err)
+	}
+
+	wantChanges := []string{
+		"UPDATE Brand/Primary [Light]: #0066ff -> #ff0000 (将替换别名)",
+		"CREATE accent [Value]: #00ff00 (alpha 0.8)",
+	}
+	var changes []string
+	for _, change := range plan.Changes {
+		changes = append(changes, change.String())
+	}
+	if !reflect.DeepEqual(changes, wantChanges) {
+		t.Errorf("变更为\n%s\n应为\n%s", strings.Join(changes, "\n"), strings.Join(wantChanges, "\n"))
+	}
+
+	if plan.Collection != "Primitives" {
+		t.Errorf("新建变量的集合为 %s，应为 Primitives", plan.Collection)
+	}
+	if !reflect.DeepEqual(plan.FigmaOnly, []string{"blue/500"}) {
+		t.Errorf("只存在于Figma的变量为 %v，应为 [blue/500]", plan.FigmaOnly)
+	}
+
+	wantPayload := PostVariablesRequest{
+		Variables: []VariableChange{
+			{Action: "CREATE", ID: "tmp_1", Name: "accent", VariableCollectionID: "VC:1", ResolvedType: "COLOR", Description: "强调色"},
+		},
+		VariableModeValues: []VariableModeValue{
+			{VariableID: "V:2", ModeID: "2:0", Value: RGBA{R: 1, G: 0, B: 0, A: 1}},
+			{VariableID: "tmp_1", ModeID: "1:0", Value: RGBA{R: 0, G: 1, B: 0, A: 0.8}},
+		},
+	}
+	if !reflect.DeepEqual(plan.Payload, wantPayload) {
+		t.Errorf("请求体为 %+v，应为 %+v", plan.Payload, wantPayload)
+	}
+}
+
+func TestPlanPushMissingCollection(t *testing.T) {
+	// Text列在Semantic集合中，但所属的集合不在响应里
+	body := strings.Replace(variablesJSON, `"name": "Text", "variableCollectionId": "VC:2"`, `"name": "Text", "variableCollectionId": "VC:404"`, 1)
+	local := map[string]*color.ColorDefinition{
+		"text": {Light: value("#000000", 1), Dark: value("#ffffff", 0.5)},
+	}
+
+	_, err := PlanPush(fetch(t, body), local, Options{})
+	if err == nil || !strings.Contains(err.Error(), "VC:404 不存在") {
+		t.Errorf("PlanPush() 错误为 %v，应报告集合不存在", err)
+	}
+}
+
+func TestPlanPushNameCollision(t *testing.T) {
+	// blue/500 和 Blue 500 转换后都是 blue_500，与pull一样报错，不能任选一个更新
+	body := strings.Replace(variablesJSON, `"name": "Text"`, `"name": "Blue 500"`, 1)
+	local := map[string]*color.ColorDefinition{
+		"blue_500": {Hex: "#0066ff", Alpha: 1},
+	}
+
+	_, err := PlanPush(fetch(t, body), local, Options{})
+	if err == nil || !strings.Contains(err.Error(), "blue/500 和 Blue 500 转换后的颜色名称重复: blue_500") {
+		t.Errorf("PlanPush() 错误为 %v，应报告重名的变量", err)
+	}
+	if _, err := ToColors(fetch(t, body), Options{}); err == nil {
+		t.Errorf("ToColors() 也应报告重名的变量")
+	}
+}
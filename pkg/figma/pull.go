@@ -0,0 +1,209 @@
+package figma
+
+import (
+	"app-assets-generator/internal/naming"
+	"app-assets-generator/pkg/color"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// 主题角色
+const (
+	roleDefault = "default"
+	roleLight   = "light"
+	roleDark    = "dark"
+)
+
+// maxAliasDepth 别名解析的最大深度，用于检测循环引用
+const maxAliasDepth = 32
+
+// Options 模式映射和集合过滤配置
+type Options struct {
+	Collection string // 只处理指定名称的变量集合，为空时处理所有集合
+	LightMode  string // 浅色模式名称，为空时自动识别名称包含light的模式
+	DarkMode   string // 深色模式名称，为空时自动识别名称包含dark的模式
+}
+
+// resolver 变量解析器
+type resolver struct {
+	response *LocalVariablesResponse
+	options  Options
+}
+
+// collectionModes 变量集合中各主题对应的模式ID，没有对应模式时为空
+type collectionModes struct {
+	defaultMode string
+	lightMode   string
+	darkMode    string
+}
+
+// modeFor 获取主题对应的模式ID，浅色/深色模式不存在时回退到默认模式
+func (m collectionModes) modeFor(role string) string {
+	switch role {
+	case roleLight:
+		if m.lightMode != "" {
+			return m.lightMode
+		}
+	case roleDark:
+		if m.darkMode != "" {
+			return m.darkMode
+		}
+	}
+	return m.defaultMode
+}
+
+// ToColors 将Figma颜色变量转换为颜色定义
+// 默认模式映射为default，浅色/深色模式映射为light/dark，别名按相同主题递归解析
+func ToColors(response *LocalVariablesResponse, options Options) (map[string]*color.ColorDefinition, error) {
+	r := &resolver{response: response, options: options}
+	colors := make(map[string]*color.ColorDefinition)
+	sources := make(map[string]string) // 颜色名称 -> Figma变量名，用于检测重名
+
+	for _, collection := range r.collections() {
+		modes := r.modes(collection)
+
+		for _, variable := range r.colorVariables(collection) {
+			name := naming.NormalizeName(variable.Name)
+			if name == "" {
+				return nil, fmt.Errorf("变量 %s 无法转换为有效的颜色名称", variable.Name)
+			}
+			if source, exists := sources[name]; exists {
+				return nil, fmt.Errorf("变量 %s 和 %s 转换后的颜色名称重复: %s", source, variable.Name, name)
+			}
+			sources[name] = variable.Name
+
+			definition := &color.ColorDefinition{
+				Description: variable.Description,
+				Section:     collection.Name,
+				Order:       len(colors),
+			}
+
+			defaultValue, err := r.resolve(variable, roleDefault, 0)
+			if err != nil {
+				return nil, err
+			}
+			definition.Default = &defaultValue
+
+			if modes.lightMode != "" {
+				lightValue, err := r.resolve(variable, roleLight, 0)
+				if err != nil {
+					return nil, err
+				}
+				definition.Light = &lightValue
+			}
+			if modes.darkMode != "" {
+				darkValue, err := r.resolve(variable, roleDark, 0)
+				if err != nil {
+					return nil, err
+				}
+				definition.Dark = &darkValue
+			}
+
+			colors[name] = definition
+		}
+	}
+
+	return colors, nil
+}
+
+// collections 按名称排序并过滤变量集合
+func (r *resolver) collections() []*VariableCollection {
+	var collections []*VariableCollection
+	for _, collection := range r.response.Meta.VariableCollections {
+		if r.options.Collection == "" || collection.Name == r.options.Collection {
+			collections = append(collections, collection)
+		}
+	}
+	sort.Slice(collections, func(i, j int) bool {
+		return collections[i].Name < collections[j].Name
+	})
+	return collections
+}
+
+// modes 识别变量集合中的默认、浅色和深色模式
+func (r *resolver) modes(collection *VariableCollection) collectionModes {
+	modes := collectionModes{defaultMode: collection.DefaultModeID}
+	for _, mode := range collection.Modes {
+		name := strings.ToLower(mode.Name)
+		if modeMatches(name, r.options.LightMode, "light") && modes.lightMode == "" {
+			modes.lightMode = mode.ModeID
+		}
+		if modeMatches(name, r.options.DarkMode, "dark") && modes.darkMode == "" {
+			modes.darkMode = mode.ModeID
+		}
+	}
+	return modes
+}
+
+// modeMatches 判断模式名称是否匹配，指定了名称时精确匹配（不区分大小写），否则按关键字匹配
+func modeMatches(modeName, configured, keyword string) bool {
+	if configured != "" {
+		return modeName == strings.ToLower(configured)
+	}
+	return strings.Contains(modeName, keyword)
+}
+
+// colorVariables 获取集合中的本地颜色变量，按集合中的顺序排列
+func (r *resolver) colorVariables(collection *VariableCollection) []*Variable {
+	var variables []*Variable
+	seen := make(map[string]bool)
+	for _, id := range collection.VariableIDs {
+		if variable, ok := r.response.Meta.Variables[id]; ok {
+			variables = append(variables, variable)
+			seen[id] = true
+		}
+	}
+
+	// 集合中没有列出的变量按名称排序追加
+	var rest []*Variable
+	for id, variable := range r.response.Meta.Variables {
+		if !seen[id] && variable.VariableCollectionID == collection.ID {
+			rest = append(rest, variable)
+		}
+	}
+	sort.Slice(rest, func(i, j int) bool {
+		return rest[i].Name < rest[j].Name
+	})
+	variables = append(variables, rest...)
+
+	var result []*Variable
+	for _, variable := range variables {
+		if variable.ResolvedType == "COLOR" && !variable.Remote {
+			result = append(result, variable)
+		}
+	}
+	return result
+}
+
+// resolve 解析变量在指定主题下的颜色值，别名在目标变量所在集合中按相同主题解析
+func (r *resolver) resolve(variable *Variable, role string, depth int) (color.ColorValue, error) {
+	if depth > maxAliasDepth {
+		return color.ColorValue{}, fmt.Errorf("变量 %s 的别名存在循环引用", variable.Name)
+	}
+
+	collection, ok := r.response.Meta.VariableCollections[variable.VariableCollectionID]
+	if !ok {
+		return color.ColorValue{}, fmt.Errorf("变量 %s 所在的集合 %s 不存在", variable.Name, variable.VariableCollectionID)
+	}
+	modeID := r.modes(collection).modeFor(role)
+
+	raw, ok := variable.ValuesByMode[modeID]
+	if !ok {
+		return color.ColorValue{}, fmt.Errorf("变量 %s 缺少模式 %s 的值", variable.Name, modeID)
+	}
+
+	rgba, alias, err := parseValue(raw)
+	if err != nil {
+		return color.ColorValue{}, fmt.Errorf("变量 %s 的值无效: %w", variable.Name, err)
+	}
+	if alias != nil {
+		target, ok := r.response.Meta.Variables[alias.ID]
+		if !ok {
+			return color.ColorValue{}, fmt.Errorf("变量 %s 引用的变量 %s 不存在", variable.Name, alias.ID)
+		}
+		return r.resolve(target, role, depth+1)
+	}
+
+	return color.NewColorValue(rgba.R, rgba.G, rgba.B, rgba.A), nil
+}
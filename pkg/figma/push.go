@@ -0,0 +1,203 @@
+package figma
+
+import (
+	"app-assets-generator/internal/naming"
+	"app-assets-generator/pkg/color"
+	"fmt"
+	"sort"
+)
+
+// Change 一条将要在Figma端发生的变更
+type Change struct {
+	Action   string // CREATE/UPDATE
+	Variable string // Figma变量名称
+	Mode     string // 模式名称
+	From     string // 当前值，新建时为空
+	To       string // 新值
+	Alias    bool   // 当前值是否为别名（更新后别名会被替换为具体颜色）
+}
+
+// String 格式化变更
+func (c Change) String() string {
+	if c.Action == "CREATE" {
+		return fmt.Sprintf("CREATE %s [%s]: %s", c.Variable, c.Mode, c.To)
+	}
+	if c.Alias {
+		return fmt.Sprintf("UPDATE %s [%s]: %s -> %s (将替换别名)", c.Variable, c.Mode, c.From, c.To)
+	}
+	return fmt.Sprintf("UPDATE %s [%s]: %s -> %s", c.Variable, c.Mode, c.From, c.To)
+}
+
+// PushPlan push的执行计划
+type PushPlan struct {
+	Changes    []Change             // 所有变更
+	FigmaOnly  []string             // 只存在于Figma中的颜色变量（不会被删除）
+	Payload    PostVariablesRequest // POST /v1/files/:file_key/variables 的请求体
+	Collection string               // 新建变量所在的集合
+}
+
+// PostVariablesRequest POST /v1/files/:file_key/variables 的请求体
+type PostVariablesRequest struct {
+	Variables          []VariableChange    `json:"variables,omitempty"`
+	VariableModeValues []VariableModeValue `json:"variableModeValues,omitempty"`
+}
+
+// VariableChange 变量的新建/更新操作
+type VariableChange struct {
+	Action               string `json:"action"`
+	ID                   string `json:"id"`
+	Name                 string `json:"name,omitempty"`
+	VariableCollectionID string `json:"variableCollectionId,omitempty"`
+	ResolvedType         string `json:"resolvedType,omitempty"`
+	Description          string `json:"description,omitempty"`
+}
+
+// VariableModeValue 变量在某个模式下的值
+type VariableModeValue struct {
+	VariableID string `json:"variableId"`
+	ModeID     string `json:"modeId"`
+	Value      RGBA   `json:"value"`
+}
+
+// PlanPush 对比本地颜色与Figma变量，计算push会产生的变更（不会修改Figma）
+// 同一个模式同时是默认模式和浅色模式时，使用本地的light值
+func PlanPush(response *LocalVariablesResponse, colors map[string]*color.ColorDefinition, options Options) (*PushPlan, error) {
+	r := &resolver{response: response, options: options}
+	plan := &PushPlan{}
+
+	// 按颜色名称索引Figma变量
+	collections := r.collections()
+	if len(collections) == 0 {
+		return nil, fmt.Errorf("Figma文件中没有可用的变量集合")
+	}
+	// 与pull相同，转换后重名的变量无法确定应更新哪一个，直接报错
+	figmaVariables := make(map[string]*Variable)
+	for _, collection := range collections {
+		for _, variable := range r.colorVariables(collection) {
+			name := naming.NormalizeName(variable.Name)
+			if existing, exists := figmaVariables[name]; exists {
+				return nil, fmt.Errorf("变量 %s 和 %s 转换后的颜色名称重复: %s", existing.Name, variable.Name, name)
+			}
+			figmaVariables[name] = variable
+		}
+	}
+
+	names := make([]string, 0, len(colors))
+	for name := range colors {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return colors[names[i]].Order < colors[names[j]].Order
+	})
+
+	target := collections[0]
+	plan.Collection = target.Name
+	created := 0
+
+	for _, name := range names {
+		definition := colors[name]
+		if definition.IsGradient() {
+			continue
+		}
+
+		variable, exists := figmaVariables[name]
+		if !exists {
+			// 新建变量，放在第一个（或指定的）集合中
+			created++
+			id := fmt.Sprintf("tmp_%d", created)
+			plan.Payload.Variables = append(plan.Payload.Variables, VariableChange{
+				Action:               "CREATE",
+				ID:                   id,
+				Name:                 name,
+				VariableCollectionID: target.ID,
+				ResolvedType:         "COLOR",
+				Description:          definition.Description,
+			})
+			for _, modeValue := range r.modeValues(target, definition) {
+				plan.Changes = append(plan.Changes, Change{Action: "CREATE", Variable: name, Mode: modeValue.mode.Name, To: formatValue(modeValue.value)})
+				plan.Payload.VariableModeValues = append(plan.Payload.VariableModeValues, VariableModeValue{
+					VariableID: id,
+					ModeID:     modeValue.mode.ModeID,
+					Value:      toRGBA(modeValue.value),
+				})
+			}
+			continue
+		}
+		delete(figmaVariables, name)
+
+		// 变量可能列在一个集合的variableIds中，但所属的集合不在响应里
+		collection, ok := response.Meta.VariableCollections[variable.VariableCollectionID]
+		if !ok {
+			return nil, fmt.Errorf("变量 %s 所在的集合 %s 不存在", variable.Name, variable.VariableCollectionID)
+		}
+		for _, modeValue := range r.modeValues(collection, definition) {
+			current, err := r.resolve(variable, modeValue.role, 0)
+			if err != nil {
+				return nil, err
+			}
+			if current.Equal(modeValue.value) {
+				continue
+			}
+			_, alias, _ := parseValue(variable.ValuesByMode[modeValue.mode.ModeID])
+			plan.Changes = append(plan.Changes, Change{
+				Action:   "UPDATE",
+				Variable: variable.Name,
+				Mode:     modeValue.mode.Name,
+				From:     formatValue(current),
+				To:       formatValue(modeValue.value),
+				Alias:    alias != nil,
+			})
+			plan.Payload.VariableModeValues = append(plan.Payload.VariableModeValues, VariableModeValue{
+				VariableID: variable.ID,
+				ModeID:     modeValue.mode.ModeID,
+				Value:      toRGBA(modeValue.value),
+			})
+		}
+	}
+
+	for _, variable := range figmaVariables {
+		plan.FigmaOnly = append(plan.FigmaOnly, variable.Name)
+	}
+	sort.Strings(plan.FigmaOnly)
+
+	return plan, nil
+}
+
+// modeValue 某个模式对应的本地颜色值
+type modeValue struct {
+	mode  Mode
+	role  string
+	value color.ColorValue
+}
+
+// modeValues 获取集合中每个模式对应的本地颜色值
+// 浅色/深色模式使用light/dark值，其它模式（包括默认模式）使用default值
+func (r *resolver) modeValues(collection *VariableCollection, definition *color.ColorDefinition) []modeValue {
+	modes := r.modes(collection)
+	var values []modeValue
+	for _, mode := range collection.Modes {
+		switch mode.ModeID {
+		case modes.lightMode:
+			values = append(values, modeValue{mode: mode, role: roleLight, value: definition.GetLight()})
+		case modes.darkMode:
+			values = append(values, modeValue{mode: mode, role: roleDark, value: definition.GetDark()})
+		case modes.defaultMode:
+			values = append(values, modeValue{mode: mode, role: roleDefault, value: definition.GetDefault()})
+		}
+	}
+	return values
+}
+
+// toRGBA 转换为Figma颜色值
+func toRGBA(value color.ColorValue) RGBA {
+	r, g, b, _ := value.RGB()
+	return RGBA{R: r, G: g, B: b, A: value.Alpha}
+}
+
+// formatValue 格式化颜色值
+func formatValue(value color.ColorValue) string {
+	if value.Alpha < 1 {
+		return fmt.Sprintf("%s (alpha %g)", value.Hex, value.Alpha)
+	}
+	return value.Hex
+}
@@ -0,0 +1,69 @@
+package figma
+
+import (
+	"encoding/json"
+)
+
+// LocalVariablesResponse GET /v1/files/:file_key/variables/local 的响应
+type LocalVariablesResponse struct {
+	Status int  `json:"status"`
+	Error  bool `json:"error"`
+	Meta   struct {
+		Variables           map[string]*Variable           `json:"variables"`
+		VariableCollections map[string]*VariableCollection `json:"variableCollections"`
+	} `json:"meta"`
+}
+
+// Variable Figma变量
+type Variable struct {
+	ID                   string                     `json:"id"`
+	Name                 string                     `json:"name"`
+	Description          string                     `json:"description"`
+	VariableCollectionID string                     `json:"variableCollectionId"`
+	ResolvedType         string                     `json:"resolvedType"` // COLOR/FLOAT/STRING/BOOLEAN
+	Remote               bool                       `json:"remote"`
+	ValuesByMode         map[string]json.RawMessage `json:"valuesByMode"`
+}
+
+// VariableCollection Figma变量集合
+type VariableCollection struct {
+	ID            string   `json:"id"`
+	Name          string   `json:"name"`
+	DefaultModeID string   `json:"defaultModeId"`
+	Modes         []Mode   `json:"modes"`
+	VariableIDs   []string `json:"variableIds"`
+}
+
+// Mode 变量集合中的模式
+type Mode struct {
+	ModeID string `json:"modeId"`
+	Name   string `json:"name"`
+}
+
+// RGBA Figma颜色值，各分量0-1
+type RGBA struct {
+	R float64 `json:"r"`
+	G float64 `json:"g"`
+	B float64 `json:"b"`
+	A float64 `json:"a"`
+}
+
+// VariableAlias 变量别名
+type VariableAlias struct {
+	Type string `json:"type"` // VARIABLE_ALIAS
+	ID   string `json:"id"`
+}
+
+// parseValue 解析模式值，返回颜色或别名
+func parseValue(raw json.RawMessage) (*RGBA, *VariableAlias, error) {
+	var alias VariableAlias
+	if err := json.Unmarshal(raw, &alias); err == nil && alias.Type == "VARIABLE_ALIAS" {
+		return nil, &alias, nil
+	}
+
+	var color RGBA
+	if err := json.Unmarshal(raw, &color); err != nil {
+		return nil, nil, err
+	}
+	return &color, nil, nil
+}
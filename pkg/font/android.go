@@ -0,0 +1,73 @@
+package font
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// AndroidGenerator Android字体资源生成器
+type AndroidGenerator struct {
+	outputPath string // res目录路径
+}
+
+// NewAndroidGenerator 创建Android生成器
+func NewAndroidGenerator(outputPath string) *AndroidGenerator {
+	return &AndroidGenerator{
+		outputPath: outputPath,
+	}
+}
+
+// Generate 复制字体文件到font目录，并为每个家族生成 font/<家族>.xml
+func (g *AndroidGenerator) Generate(fonts []*FontInfo) error {
+	fontPath := filepath.Join(g.outputPath, "font")
+	if err := os.MkdirAll(fontPath, 0755); err != nil {
+		return fmt.Errorf("创建font目录失败: %w", err)
+	}
+
+	// fonts已按家族排序，同一家族的字体相邻
+	var family []*FontInfo
+	for i, font := range fonts {
+		dst := filepath.Join(fontPath, ResourceName(font)+strings.ToLower(filepath.Ext(font.Path)))
+		if err := copyFile(font.Path, dst); err != nil {
+			return fmt.Errorf("复制字体文件失败: %w", err)
+		}
+
+		family = append(family, font)
+		if i+1 == len(fonts) || fonts[i+1].Family != font.Family {
+			if err := g.generateFamilyXML(fontPath, family); err != nil {
+				return err
+			}
+			family = nil
+		}
+	}
+
+	return nil
+}
+
+// generateFamilyXML 生成font-family XML，同时写入android和app命名空间的属性以兼容API 26以下
+func (g *AndroidGenerator) generateFamilyXML(fontPath string, fonts []*FontInfo) error {
+	var builder strings.Builder
+	builder.WriteString("<?xml version=\"1.0\" encoding=\"utf-8\"?>\n")
+	fmt.Fprintf(&builder, "<!-- %s -->\n", fonts[0].Family)
+	builder.WriteString("<font-family xmlns:android=\"http://schemas.android.com/apk/res/android\"\n")
+	builder.WriteString("    xmlns:app=\"http://schemas.android.com/apk/res-auto\">\n")
+	for _, font := range fonts {
+		resource := "@font/" + ResourceName(font)
+		builder.WriteString("    <font\n")
+		fmt.Fprintf(&builder, "        android:font=\"%s\"\n", resource)
+		fmt.Fprintf(&builder, "        android:fontStyle=\"%s\"\n", font.Style())
+		fmt.Fprintf(&builder, "        android:fontWeight=\"%d\"\n", font.Weight)
+		fmt.Fprintf(&builder, "        app:font=\"%s\"\n", resource)
+		fmt.Fprintf(&builder, "        app:fontStyle=\"%s\"\n", font.Style())
+		fmt.Fprintf(&builder, "        app:fontWeight=\"%d\" />\n", font.Weight)
+	}
+	builder.WriteString("</font-family>\n")
+
+	filePath := filepath.Join(fontPath, FamilyResourceName(fonts[0].Family)+".xml")
+	if err := os.WriteFile(filePath, []byte(builder.String()), 0644); err != nil {
+		return fmt.Errorf("写入 %s 失败: %w", filePath, err)
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package font
+
+import (
+	"app-assets-generator/internal/naming"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Generator 字体资源生成器
+type Generator struct {
+	inputPath  string      // 字体目录
+	outputPath string      // 输出目录
+	fonts      []*FontInfo // 扫描到的字体，按家族、字重、样式排序
+	warnings   []string    // 扫描时发现的警告
+}
+
+// NewGenerator 创建新的生成器
+func NewGenerator(inputPath, outputPath string) *Generator {
+	return &Generator{
+		inputPath:  inputPath,
+		outputPath: outputPath,
+	}
+}
+
+// GenerateIOS 复制字体文件到输出目录，并生成UIAppFonts的Info.plist片段
+func (g *Generator) GenerateIOS() error {
+	if err := g.scanFonts(); err != nil {
+		return err
+	}
+
+	iosGen := NewIOSGenerator(g.outputPath)
+	return iosGen.Generate(g.fonts)
+}
+
+// GenerateAndroid 将字体文件以有效的资源名称复制到font目录，并为每个家族生成font-family XML
+func (g *Generator) GenerateAndroid() error {
+	if err := g.scanFonts(); err != nil {
+		return err
+	}
+
+	androidGen := NewAndroidGenerator(g.outputPath)
+	return androidGen.Generate(g.fonts)
+}
+
+// Fonts 获取扫描到的字体
+func (g *Generator) Fonts() ([]*FontInfo, error) {
+	if err := g.scanFonts(); err != nil {
+		return nil, err
+	}
+	return g.fonts, nil
+}
+
+// Warnings 获取扫描字体时发现的警告
+func (g *Generator) Warnings() []string {
+	return g.warnings
+}
+
+// scanFonts 扫描字体目录中的TTF/OTF文件
+// 同一家族中字重和样式相同的字体在两个平台上都无法区分，作为错误报告
+func (g *Generator) scanFonts() error {
+	if g.fonts != nil {
+		return nil // 已经扫描过了
+	}
+
+	var fonts []*FontInfo
+	err := filepath.Walk(g.inputPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !isSupportedFontFormat(filepath.Ext(path)) {
+			return nil
+		}
+
+		font, err := ReadFontInfo(path)
+		if err != nil {
+			return err
+		}
+		fonts = append(fonts, font)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("扫描字体失败: %w", err)
+	}
+	if len(fonts) == 0 {
+		return fmt.Errorf("字体目录 %s 中没有TTF/OTF文件", g.inputPath)
+	}
+
+	sort.SliceStable(fonts, func(i, j int) bool {
+		a, b := fonts[i], fonts[j]
+		if a.Family != b.Family {
+			return a.Family < b.Family
+		}
+		if a.Weight != b.Weight {
+			return a.Weight < b.Weight
+		}
+		return !a.Italic && b.Italic
+	})
+
+	// 检查重复的家族/字重/样式组合，以及资源名称冲突
+	var problems []string
+	seen := make(map[string]*FontInfo)
+	resources := make(map[string]*FontInfo)
+	for _, font := range fonts {
+		key := fmt.Sprintf("%s/%d/%s", font.Family, font.Weight, font.Style())
+		if existing, ok := seen[key]; ok {
+			problems = append(problems, fmt.Sprintf("字体家族 %s 的字重 %d (%s) 重复: %s 和 %s", font.Family, font.Weight, font.Style(), existing.Path, font.Path))
+			continue
+		}
+		seen[key] = font
+
+		resource := ResourceName(font)
+		if existing, ok := resources[resource]; ok {
+			problems = append(problems, fmt.Sprintf("字体 %s 和 %s 的Android资源名称 %s 冲突", existing.Path, font.Path, resource))
+			continue
+		}
+		resources[resource] = font
+
+		if font.Variable {
+			g.warnings = append(g.warnings, fmt.Sprintf("%s 是可变字体，只按默认字重 %d 注册", font.Path, font.Weight))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("发现 %d 个字体冲突:\n  %s", len(problems), strings.Join(problems, "\n  "))
+	}
+
+	g.fonts = fonts
+	return nil
+}
+
+// FamilyResourceName 获取字体家族的Android资源名称，如 Source Sans Pro -> source_sans_pro
+// 与typography命令中 @font/<家族> 的引用保持一致
+func FamilyResourceName(family string) string {
+	return naming.NormalizeName(family)
+}
+
+// ResourceName 获取字体文件的Android资源名称，如 inter_semibold_italic
+func ResourceName(font *FontInfo) string {
+	name := FamilyResourceName(font.Family) + "_" + weightName(font.Weight)
+	if font.Italic {
+		name += "_italic"
+	}
+	return name
+}
+
+// weightNames 标准字重对应的名称
+var weightNames = map[int]string{
+	100: "thin",
+	200: "extralight",
+	300: "light",
+	400: "regular",
+	500: "medium",
+	600: "semibold",
+	700: "bold",
+	800: "extrabold",
+	900: "black",
+}
+
+// weightName 获取字重名称，非标准字重使用 w450 形式
+func weightName(weight int) string {
+	if name, ok := weightNames[weight]; ok {
+		return name
+	}
+	return fmt.Sprintf("w%d", weight)
+}
+
+// isSupportedFontFormat 检查是否为支持的字体格式
+func isSupportedFontFormat(ext string) bool {
+	ext = strings.ToLower(ext)
+	return ext == ".ttf" || ext == ".otf"
+}
+
+// copyFile 复制文件
+func copyFile(src, dst string) error {
+	// 确保目标目录存在
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+
+	// 读取源文件
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+
+	// 写入目标文件
+	return os.WriteFile(dst, data, 0644)
+}
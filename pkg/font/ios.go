@@ -0,0 +1,66 @@
+package font
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// PlistFileName UIAppFonts片段的文件名
+const PlistFileName = "UIAppFonts.plist"
+
+// IOSGenerator iOS字体资源生成器
+type IOSGenerator struct {
+	outputPath string // 字体文件的输出目录
+}
+
+// NewIOSGenerator 创建iOS生成器
+func NewIOSGenerator(outputPath string) *IOSGenerator {
+	return &IOSGenerator{
+		outputPath: outputPath,
+	}
+}
+
+// Generate 按原文件名复制字体文件，并生成包含UIAppFonts数组的plist片段
+// 片段中的内容需要合并到应用的Info.plist中，字体文件需要加入应用target
+func (g *IOSGenerator) Generate(fonts []*FontInfo) error {
+	if err := os.MkdirAll(g.outputPath, 0755); err != nil {
+		return fmt.Errorf("创建输出目录失败: %w", err)
+	}
+
+	// 不同子目录中的同名文件在应用包中会互相覆盖
+	copied := make(map[string]string)
+	for _, font := range fonts {
+		fileName := filepath.Base(font.Path)
+		if existing, ok := copied[fileName]; ok {
+			return fmt.Errorf("字体文件 %s 和 %s 同名，iOS应用包中只能保留一个", existing, font.Path)
+		}
+		copied[fileName] = font.Path
+
+		if err := copyFile(font.Path, filepath.Join(g.outputPath, fileName)); err != nil {
+			return fmt.Errorf("复制字体文件失败: %w", err)
+		}
+	}
+
+	var builder strings.Builder
+	builder.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	builder.WriteString("<!DOCTYPE plist PUBLIC \"-//Apple//DTD PLIST 1.0//EN\" \"http://www.apple.com/DTDs/PropertyList-1.0.dtd\">\n")
+	builder.WriteString("<plist version=\"1.0\">\n")
+	builder.WriteString("<dict>\n")
+	builder.WriteString("\t<key>UIAppFonts</key>\n")
+	builder.WriteString("\t<array>\n")
+	for _, font := range fonts {
+		fmt.Fprintf(&builder, "\t\t<string>%s</string>\n", html.EscapeString(filepath.Base(font.Path)))
+	}
+	builder.WriteString("\t</array>\n")
+	builder.WriteString("</dict>\n")
+	builder.WriteString("</plist>\n")
+
+	filePath := filepath.Join(g.outputPath, PlistFileName)
+	if err := os.WriteFile(filePath, []byte(builder.String()), 0644); err != nil {
+		return fmt.Errorf("写入 %s 失败: %w", filePath, err)
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+package font
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf16"
+)
+
+// name表中使用的名称ID
+const (
+	nameFamily            = 1  // 字体家族名称（RIBBI风格分组，如 Inter SemiBold）
+	nameSubfamily         = 2  // 样式名称，如 Bold Italic
+	namePostScript        = 6  // PostScript名称，如 Inter-SemiBold
+	nameTypographicFamily = 16 // 排版家族名称，如 Inter
+)
+
+// 文件开头的sfnt版本标记
+const (
+	sfntTrueType   = 0x00010000 // TrueType轮廓
+	sfntOpenType   = 0x4F54544F // 'OTTO'，CFF轮廓
+	sfntAppleTrue  = 0x74727565 // 'true'，旧版Mac TrueType
+	sfntCollection = 0x74746366 // 'ttcf'，字体集合
+)
+
+// FontInfo 从字体文件中读取的信息
+type FontInfo struct {
+	Path           string // 字体文件路径
+	Family         string // 字体家族名称，优先使用排版家族名称
+	Subfamily      string // 样式名称
+	PostScriptName string // PostScript名称，iOS中UIFont(name:)使用
+	Weight         int    // 字重，来自OS/2表的usWeightClass
+	Italic         bool   // 是否为斜体
+	Variable       bool   // 是否为可变字体（包含fvar表）
+}
+
+// Style 获取字体样式 normal/italic
+func (f *FontInfo) Style() string {
+	if f.Italic {
+		return "italic"
+	}
+	return "normal"
+}
+
+// ReadFontInfo 读取TTF/OTF文件的name表和OS/2表
+func ReadFontInfo(path string) (*FontInfo, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取字体文件失败: %w", err)
+	}
+	info, err := parseFont(data)
+	if err != nil {
+		return nil, fmt.Errorf("解析字体文件 %s 失败: %w", path, err)
+	}
+	info.Path = path
+	return info, nil
+}
+
+// parseFont 解析sfnt表目录，读取字体信息
+func parseFont(data []byte) (*FontInfo, error) {
+	if len(data) < 12 {
+		return nil, fmt.Errorf("文件太小，不是有效的字体文件")
+	}
+
+	switch binary.BigEndian.Uint32(data) {
+	case sfntTrueType, sfntOpenType, sfntAppleTrue:
+	case sfntCollection:
+		return nil, fmt.Errorf("不支持字体集合（.ttc），请拆分为单独的TTF/OTF文件")
+	default:
+		return nil, fmt.Errorf("不是有效的TrueType/OpenType字体")
+	}
+
+	// 表目录：每项16字节，包含tag、checksum、offset、length
+	tables := make(map[string][]byte)
+	numTables := int(binary.BigEndian.Uint16(data[4:]))
+	for i := 0; i < numTables; i++ {
+		record := 12 + i*16
+		if record+16 > len(data) {
+			return nil, fmt.Errorf("表目录不完整")
+		}
+		tag := string(data[record : record+4])
+		offset := int(binary.BigEndian.Uint32(data[record+8:]))
+		length := int(binary.BigEndian.Uint32(data[record+12:]))
+		if offset < 0 || length < 0 || offset+length > len(data) {
+			return nil, fmt.Errorf("表 %s 超出文件范围", strings.TrimSpace(tag))
+		}
+		tables[tag] = data[offset : offset+length]
+	}
+
+	nameTable, ok := tables["name"]
+	if !ok {
+		return nil, fmt.Errorf("缺少name表")
+	}
+	names, err := parseNameTable(nameTable)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &FontInfo{
+		Family:         names[nameTypographicFamily],
+		Subfamily:      names[nameSubfamily],
+		PostScriptName: names[namePostScript],
+		Weight:         400,
+	}
+	if info.Family == "" {
+		info.Family = names[nameFamily]
+	}
+	if info.Family == "" {
+		return nil, fmt.Errorf("name表中缺少字体家族名称")
+	}
+	_, info.Variable = tables["fvar"]
+
+	// OS/2表：usWeightClass位于偏移4，fsSelection位于偏移62（bit 0为斜体，bit 9为倾斜体）
+	if os2, ok := tables["OS/2"]; ok && len(os2) >= 64 {
+		info.Weight = int(binary.BigEndian.Uint16(os2[4:]))
+		fsSelection := binary.BigEndian.Uint16(os2[62:])
+		info.Italic = fsSelection&0x0001 != 0 || fsSelection&0x0200 != 0
+	} else {
+		// 没有OS/2表的旧字体按样式名称推断
+		info.Weight = weightFromSubfamily(info.Subfamily)
+	}
+	subfamily := strings.ToLower(info.Subfamily)
+	if strings.Contains(subfamily, "italic") || strings.Contains(subfamily, "oblique") {
+		info.Italic = true
+	}
+
+	return info, nil
+}
+
+// parseNameTable 解析name表，优先使用Windows平台的Unicode名称，其次使用Mac Roman名称
+func parseNameTable(table []byte) (map[int]string, error) {
+	if len(table) < 6 {
+		return nil, fmt.Errorf("name表不完整")
+	}
+	count := int(binary.BigEndian.Uint16(table[2:]))
+	storage := int(binary.BigEndian.Uint16(table[4:]))
+
+	names := make(map[int]string)
+	priorities := make(map[int]int)
+	for i := 0; i < count; i++ {
+		record := 6 + i*12
+		if record+12 > len(table) {
+			return nil, fmt.Errorf("name表记录不完整")
+		}
+		platform := binary.BigEndian.Uint16(table[record:])
+		encoding := binary.BigEndian.Uint16(table[record+2:])
+		language := binary.BigEndian.Uint16(table[record+4:])
+		nameID := int(binary.BigEndian.Uint16(table[record+6:]))
+		length := int(binary.BigEndian.Uint16(table[record+8:]))
+		offset := storage + int(binary.BigEndian.Uint16(table[record+10:]))
+		if offset+length > len(table) {
+			continue
+		}
+		raw := table[offset : offset+length]
+
+		// 优先级：Windows英语(美国) > 其它Windows Unicode > Unicode平台 > Mac Roman英语
+		var value string
+		priority := 0
+		switch {
+		case platform == 3 && (encoding == 1 || encoding == 10):
+			value = decodeUTF16(raw)
+			priority = 3
+			if language == 0x0409 {
+				priority = 4
+			}
+		case platform == 0:
+			value = decodeUTF16(raw)
+			priority = 2
+		case platform == 1 && encoding == 0 && language == 0:
+			value = decodeMacRoman(raw)
+			priority = 1
+		default:
+			continue
+		}
+
+		value = strings.TrimSpace(value)
+		if value != "" && priority > priorities[nameID] {
+			names[nameID] = value
+			priorities[nameID] = priority
+		}
+	}
+
+	return names, nil
+}
+
+// decodeUTF16 解码UTF-16BE字符串
+func decodeUTF16(raw []byte) string {
+	units := make([]uint16, len(raw)/2)
+	for i := range units {
+		units[i] = binary.BigEndian.Uint16(raw[i*2:])
+	}
+	return string(utf16.Decode(units))
+}
+
+// decodeMacRoman 解码Mac Roman字符串，非ASCII字符按Latin-1近似处理
+func decodeMacRoman(raw []byte) string {
+	runes := make([]rune, len(raw))
+	for i, b := range raw {
+		runes[i] = rune(b)
+	}
+	return string(runes)
+}
+
+// weightFromSubfamily 根据样式名称推断字重
+func weightFromSubfamily(subfamily string) int {
+	name := strings.ToLower(strings.ReplaceAll(strings.ReplaceAll(subfamily, " ", ""), "-", ""))
+	// 先匹配较长的名称，避免 extrabold 被识别为 bold
+	for _, candidate := range []struct {
+		name   string
+		weight int
+	}{
+		{"extralight", 200}, {"ultralight", 200}, {"semibold", 600}, {"demibold", 600},
+		{"extrabold", 800}, {"ultrabold", 800}, {"thin", 100}, {"light", 300},
+		{"medium", 500}, {"bold", 700}, {"heavy", 800}, {"black", 900},
+	} {
+		if strings.Contains(name, candidate.name) {
+			return candidate.weight
+		}
+	}
+	return 400
+}
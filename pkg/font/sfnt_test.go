@@ -0,0 +1,273 @@
+package font
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf16"
+)
+
+// nameRecord name表中的一条名称
+type nameRecord struct {
+	platform, encoding, language, id uint16
+	value                            string
+}
+
+// windows 获取Windows平台英语(美国)的名称
+func windows(id uint16, value string) nameRecord {
+	return nameRecord{platform: 3, encoding: 1, language: 0x0409, id: id, value: value}
+}
+
+// nameTable 按OpenType格式0编码name表，Windows和Unicode平台的名称为UTF-16BE
+func nameTable(records ...nameRecord) []byte {
+	var storage []byte
+	table := binary.BigEndian.AppendUint16(nil, 0)
+	table = binary.BigEndian.AppendUint16(table, uint16(len(records)))
+	table = binary.BigEndian.AppendUint16(table, uint16(6+12*len(records)))
+	for _, r := range records {
+		var raw []byte
+		if r.platform == 1 {
+			raw = []byte(r.value)
+		} else {
+			for _, unit := range utf16.Encode([]rune(r.value)) {
+				raw = binary.BigEndian.AppendUint16(raw, unit)
+			}
+		}
+		for _, field := range []uint16{r.platform, r.encoding, r.language, r.id, uint16(len(raw)), uint16(len(storage))} {
+			table = binary.BigEndian.AppendUint16(table, field)
+		}
+		storage = append(storage, raw...)
+	}
+	return append(table, storage...)
+}
+
+// os2Table 获取只填写了usWeightClass和fsSelection的OS/2表
+func os2Table(weight, fsSelection uint16) []byte {
+	table := make([]byte, 96)
+	binary.BigEndian.PutUint16(table[4:], weight)
+	binary.BigEndian.PutUint16(table[62:], fsSelection)
+	return table
+}
+
+// sfnt 按表目录格式拼接字体文件，表按参数顺序紧接在目录之后
+func sfnt(version uint32, tables map[string][]byte, order ...string) []byte {
+	data := binary.BigEndian.AppendUint32(nil, version)
+	data = binary.BigEndian.AppendUint16(data, uint16(len(order)))
+	data = append(data, make([]byte, 6)...) // searchRange、entrySelector、rangeShift不参与解析
+
+	offset := len(data) + 16*len(order)
+	var body []byte
+	for _, tag := range order {
+		data = append(data, tag...)
+		data = binary.BigEndian.AppendUint32(data, 0) // checksum
+		data = binary.BigEndian.AppendUint32(data, uint32(offset+len(body)))
+		data = binary.BigEndian.AppendUint32(data, uint32(len(tables[tag])))
+		body = append(body, tables[tag]...)
+	}
+	return append(data, body...)
+}
+
+func TestParseFont(t *testing.T) {
+	interNames := nameTable(
+		windows(nameFamily, "Inter SemiBold"),
+		windows(nameSubfamily, "Italic"),
+		windows(namePostScript, "Inter-SemiBoldItalic"),
+		windows(nameTypographicFamily, "Inter"),
+	)
+
+	tests := []struct {
+		name string
+		data []byte
+		want FontInfo
+		err  string // 非空时应返回包含该内容的错误
+	}{
+		{
+			name: "TrueType，排版家族名称优先",
+			data: sfnt(sfntTrueType, map[string][]byte{"name": interNames, "OS/2": os2Table(600, 0x0001)}, "name", "OS/2"),
+			want: FontInfo{Family: "Inter", Subfamily: "Italic", PostScriptName: "Inter-SemiBoldItalic", Weight: 600, Italic: true},
+		},
+		{
+			name: "CFF可变字体，fsSelection的倾斜位",
+			data: sfnt(sfntOpenType, map[string][]byte{
+				"OS/2": os2Table(400, 0x0200),
+				"fvar": {0, 1},
+				"name": nameTable(windows(nameFamily, "Roboto Flex"), windows(nameSubfamily, "Regular")),
+			}, "OS/2", "fvar", "name"),
+			want: FontInfo{Family: "Roboto Flex", Subfamily: "Regular", Weight: 400, Italic: true, Variable: true},
+		},
+		{
+			name: "没有OS/2表时按样式名称推断",
+			data: sfnt(sfntAppleTrue, map[string][]byte{
+				"name": nameTable(
+					nameRecord{platform: 1, id: nameFamily, value: "Old Face"},
+					nameRecord{platform: 1, id: nameSubfamily, value: "ExtraBold Oblique"},
+				),
+			}, "name"),
+			want: FontInfo{Family: "Old Face", Subfamily: "ExtraBold Oblique", Weight: 800, Italic: true},
+		},
+		{
+			name: "OS/2表太短时忽略",
+			data: sfnt(sfntTrueType, map[string][]byte{
+				"name": nameTable(windows(nameFamily, "Short"), windows(nameSubfamily, "Light")),
+				"OS/2": make([]byte, 32),
+			}, "name", "OS/2"),
+			want: FontInfo{Family: "Short", Subfamily: "Light", Weight: 300},
+		},
+		{
+			name: "字体集合",
+			data: sfnt(sfntCollection, nil),
+			err:  "不支持字体集合",
+		},
+		{
+			name: "不是字体文件",
+			data: []byte("<!DOCTYPE html><html>"),
+			err:  "不是有效的TrueType/OpenType字体",
+		},
+		{
+			name: "文件太小",
+			data: []byte{0, 1, 0, 0},
+			err:  "文件太小",
+		},
+		{
+			name: "表目录不完整",
+			data: sfnt(sfntTrueType, map[string][]byte{"name": interNames}, "name")[:20],
+			err:  "表目录不完整",
+		},
+		{
+			name: "表超出文件范围",
+			data: func() []byte {
+				data := sfnt(sfntTrueType, map[string][]byte{"name": interNames}, "name")
+				return data[:len(data)-1]
+			}(),
+			err: "表 name 超出文件范围",
+		},
+		{
+			name: "缺少name表",
+			data: sfnt(sfntTrueType, map[string][]byte{"OS/2": os2Table(400, 0)}, "OS/2"),
+			err:  "缺少name表",
+		},
+		{
+			name: "缺少字体家族名称",
+			data: sfnt(sfntTrueType, map[string][]byte{"name": nameTable(windows(namePostScript, "Nameless"))}, "name"),
+			err:  "缺少字体家族名称",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info, err := parseFont(test.data)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("错误为 %v，应包含 %q", err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseFont() 失败: %v", err)
+			}
+			if *info != test.want {
+				t.Errorf("字体信息为 %+v，应为 %+v", *info, test.want)
+			}
+		})
+	}
+}
+
+func TestParseNameTable(t *testing.T) {
+	names, err := parseNameTable(nameTable(
+		nameRecord{platform: 1, id: nameFamily, value: "Mac Roman"},
+		nameRecord{platform: 0, encoding: 3, id: nameFamily, value: "Unicode"},
+		nameRecord{platform: 3, encoding: 1, language: 0x0804, id: nameFamily, value: "思源黑体"},
+		nameRecord{platform: 1, id: nameSubfamily, value: "Mac Only"},
+		nameRecord{platform: 0, encoding: 3, id: nameSubfamily, value: "Unicode Subfamily"},
+		nameRecord{platform: 1, encoding: 0, language: 11, id: namePostScript, value: "Japanese Mac"}, // 非英语的Mac名称不使用
+		windows(namePostScript, "  "), // 空白名称不覆盖
+		nameRecord{platform: 3, encoding: 1, language: 0x0411, id: nameTypographicFamily, value: "Other Language"},
+		windows(nameTypographicFamily, "US English"),
+		nameRecord{platform: 3, encoding: 10, language: 0x0407, id: nameTypographicFamily, value: "Deutsch"},
+	))
+	if err != nil {
+		t.Fatalf("parseNameTable() 失败: %v", err)
+	}
+
+	want := map[int]string{
+		nameFamily:            "思源黑体",
+		nameSubfamily:         "Unicode Subfamily",
+		nameTypographicFamily: "US English",
+	}
+	if len(names) != len(want) {
+		t.Errorf("名称为 %v，应为 %v", names, want)
+	}
+	for id, value := range want {
+		if names[id] != value {
+			t.Errorf("名称 %d 为 %q，应为 %q", id, names[id], value)
+		}
+	}
+}
+
+func TestParseNameTableTruncated(t *testing.T) {
+	table := nameTable(windows(nameFamily, "Inter"), windows(nameSubfamily, "Bold"))
+
+	if _, err := parseNameTable(table[:4]); err == nil {
+		t.Errorf("name表头不完整时应返回错误")
+	}
+	if _, err := parseNameTable(table[:20]); err == nil {
+		t.Errorf("name表记录不完整时应返回错误")
+	}
+	// 字符串存储区被截断的记录跳过
+	names, err := parseNameTable(table[:len(table)-2])
+	if err != nil {
+		t.Fatalf("parseNameTable() 失败: %v", err)
+	}
+	if names[nameFamily] != "Inter" || names[nameSubfamily] != "" {
+		t.Errorf("名称为 %v，应只有 Inter", names)
+	}
+}
+
+func TestWeightFromSubfamily(t *testing.T) {
+	tests := map[string]int{
+		"Thin":              100,
+		"Extra-Light":       200,
+		"UltraLight Italic": 200,
+		"Light":             300,
+		"Regular":           400,
+		"Italic":            400,
+		"Medium":            500,
+		"Semi Bold":         600,
+		"DemiBold":          600,
+		"Bold":              700,
+		"Bold Italic":       700,
+		"ExtraBold":         800,
+		"Heavy":             800,
+		"Black":             900,
+	}
+	for subfamily, want := range tests {
+		if got := weightFromSubfamily(subfamily); got != want {
+			t.Errorf("weightFromSubfamily(%q) = %d，应为 %d", subfamily, got, want)
+		}
+	}
+}
+
+func TestReadFontInfo(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "Inter-Bold.ttf")
+	data := sfnt(sfntTrueType, map[string][]byte{
+		"name": nameTable(windows(nameFamily, "Inter"), windows(nameSubfamily, "Bold")),
+		"OS/2": os2Table(700, 0x0020), // bit 5为粗体，不影响斜体
+	}, "name", "OS/2")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := ReadFontInfo(path)
+	if err != nil {
+		t.Fatalf("ReadFontInfo() 失败: %v", err)
+	}
+	if info.Path != path || info.Weight != 700 || info.Style() != "normal" {
+		t.Errorf("字体信息为 %+v", *info)
+	}
+
+	if _, err := ReadFontInfo(filepath.Join(t.TempDir(), "missing.ttf")); err == nil {
+		t.Errorf("文件不存在时应返回错误")
+	}
+}
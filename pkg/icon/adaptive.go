@@ -0,0 +1,219 @@
+package icon
+
+import (
+	"app-assets-generator/pkg/color"
+	"app-assets-generator/pkg/svg"
+	"fmt"
+	"image"
+	"image/draw"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// adaptiveSize 自适应图标每个图层的尺寸（dp）
+const adaptiveSize = 108
+
+// adaptiveViewport 图层中可见区域的尺寸（dp），图片缩放到该区域，四周18dp留给系统的视差和缩放动画
+const adaptiveViewport = 72
+
+// safeZone 安全区的直径（dp），任何形状的遮罩都不会裁掉安全区内的内容
+const safeZone = 66
+
+// safeZoneScale 检查安全区时渲染图层的缩放比例（与xxxhdpi相同）
+const safeZoneScale = 4
+
+// adaptiveDirectory 自适应图标XML所在的目录，Android 8以下的设备使用各密度的PNG图标
+const adaptiveDirectory = "mipmap-anydpi-v26"
+
+// adaptiveIcon 已解析并校验的自适应图标
+type adaptiveIcon struct {
+	background string     // 背景层引用的颜色资源
+	foreground layerInput // 前景层
+	monochrome layerInput // 单色层，没有单独的单色层时与前景层相同
+}
+
+// layerInput 前景层或单色层的输入图片
+type layerInput struct {
+	path   string
+	master *master
+}
+
+// loadAdaptive 解析背景色并读取前景层和单色层的图片，在写入任何文件之前完成所有校验
+func (g *Generator) loadAdaptive() (*adaptiveIcon, error) {
+	background, err := g.adaptiveBackground()
+	if err != nil {
+		return nil, err
+	}
+
+	foregroundPath := g.options.Foreground
+	if foregroundPath == "" {
+		foregroundPath = g.inputPath
+	}
+	foreground, err := g.loadLayer(foregroundPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// 没有单独的单色层时使用前景层，系统只使用图层的alpha通道
+	monochrome := foreground
+	if g.options.Monochrome != "" {
+		if monochrome, err = g.loadLayer(g.options.Monochrome); err != nil {
+			return nil, err
+		}
+	}
+	return &adaptiveIcon{background: background, foreground: foreground, monochrome: monochrome}, nil
+}
+
+// loadLayer 读取图层的图片并检查安全区
+func (g *Generator) loadLayer(path string) (layerInput, error) {
+	// xxxhdpi的72dp可见区域为288像素
+	m, err := g.loadMaster(path, launcherDensities[len(launcherDensities)-1].pixels(adaptiveViewport))
+	if err != nil {
+		return layerInput{}, err
+	}
+	g.checkSafeZone(path, m)
+	return layerInput{path: path, master: m}, nil
+}
+
+// generateAdaptive 生成自适应图标 mipmap-anydpi-v26/<名称>.xml 和 <名称>_round.xml
+// 背景层引用colors.yaml中的颜色资源，前景层和单色层由SVG生成VectorDrawable，由位图生成各密度的PNG
+func (g *Generator) generateAdaptive(icon *adaptiveIcon) error {
+	foreground, err := g.writeLayer(icon.foreground, "_foreground")
+	if err != nil {
+		return err
+	}
+	monochrome := foreground
+	if g.options.Monochrome != "" {
+		if monochrome, err = g.writeLayer(icon.monochrome, "_monochrome"); err != nil {
+			return err
+		}
+	}
+
+	data := adaptiveIconXML(icon.background, foreground, monochrome)
+	dir := filepath.Join(g.outputPath, adaptiveDirectory)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("创建目录 %s 失败: %w", dir, err)
+	}
+	for _, name := range []string{g.options.AndroidName, g.options.AndroidName + "_round"} {
+		path := filepath.Join(dir, name+".xml")
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return fmt.Errorf("写入 %s 失败: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// adaptiveBackground 获取背景层引用的颜色资源，颜色必须在颜色配置中定义
+// 颜色命令为同一配置生成values/colors.xml，深色值写入values-night，背景层随系统主题切换
+func (g *Generator) adaptiveBackground() (string, error) {
+	if g.options.ColorsPath == "" {
+		return "", fmt.Errorf("自适应图标的背景色需要指定颜色配置文件")
+	}
+	colors, err := color.ParseYAML(g.options.ColorsPath)
+	if err != nil {
+		return "", fmt.Errorf("解析颜色配置失败: %w", err)
+	}
+
+	name := g.options.AdaptiveBackground
+	definition, ok := colors[name]
+	if !ok {
+		return "", fmt.Errorf("颜色 %s 在 %s 中不存在", name, g.options.ColorsPath)
+	}
+	if definition.IsGradient() {
+		return "", fmt.Errorf("颜色 %s 是渐变色，不能作为自适应图标的背景", name)
+	}
+	if definition.GetLight().Alpha < 1 || definition.GetDark().Alpha < 1 {
+		g.warnings = append(g.warnings, fmt.Sprintf("颜色 %s 不是完全不透明的，自适应图标的背景层应当不透明", name))
+	}
+	return "@color/" + name, nil
+}
+
+// writeLayer 生成前景层或单色层，返回图层的资源引用
+// SVG写入 drawable/<名称><后缀>.xml，位图写入各密度的 mipmap-*dpi/<名称><后缀>.png
+func (g *Generator) writeLayer(layer layerInput, suffix string) (string, error) {
+	m := layer.master
+	name := g.options.AndroidName + suffix
+	if m.doc != nil {
+		data, warnings := paddedDocument(m.doc).VectorDrawable()
+		for _, warning := range warnings {
+			g.warnings = append(g.warnings, fmt.Sprintf("%s: %s", filepath.Base(layer.path), warning))
+		}
+		dst := filepath.Join(g.outputPath, "drawable", name+".xml")
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return "", fmt.Errorf("创建目录 %s 失败: %w", filepath.Dir(dst), err)
+		}
+		if err := os.WriteFile(dst, data, 0644); err != nil {
+			return "", fmt.Errorf("写入 %s 失败: %w", dst, err)
+		}
+		return "@drawable/" + name, nil
+	}
+
+	for _, density := range launcherDensities {
+		img := adaptiveLayer(m, density.pixels(adaptiveSize))
+		if err := writePNG(filepath.Join(g.outputPath, density.directory(), name+".png"), img); err != nil {
+			return "", err
+		}
+	}
+	return "@mipmap/" + name, nil
+}
+
+// checkSafeZone 图层内容超出直径66dp的安全区时记录警告，超出的部分可能被设备的遮罩形状裁掉
+func (g *Generator) checkSafeZone(path string, m *master) {
+	layer := adaptiveLayer(m, adaptiveSize*safeZoneScale)
+	center := float64(adaptiveSize*safeZoneScale) / 2
+	radius := float64(safeZone*safeZoneScale) / 2
+
+	// 抗锯齿和缩放会让边缘扩散一两个像素，留出半个dp的容差
+	farthest := 0.0
+	for y := 0; y < layer.Rect.Dy(); y++ {
+		for x := 0; x < layer.Rect.Dx(); x++ {
+			if layer.Pix[layer.PixOffset(x, y)+3] == 0 {
+				continue
+			}
+			farthest = math.Max(farthest, math.Hypot(float64(x)+0.5-center, float64(y)+0.5-center))
+		}
+	}
+	if farthest > radius+safeZoneScale/2 {
+		g.warnings = append(g.warnings, fmt.Sprintf("%s: 内容距图层中心最远%sdp，超出了自适应图标直径%ddp的安全区，部分设备的遮罩形状会裁掉超出的部分",
+			filepath.Base(path), formatSize(math.Round(farthest/safeZoneScale*10)/10), safeZone))
+	}
+}
+
+// adaptiveLayer 获取指定像素尺寸的图层：图片缩放到中央72dp的可见区域，四周保持透明
+func adaptiveLayer(m *master, size int) *image.NRGBA {
+	content := m.render(int(math.Round(float64(size) * adaptiveViewport / adaptiveSize)))
+	layer := image.NewNRGBA(image.Rect(0, 0, size, size))
+	offset := (size - content.Rect.Dx()) / 2
+	draw.Draw(layer, content.Rect.Add(image.Pt(offset, offset)), content, image.Point{}, draw.Src)
+	return layer
+}
+
+// paddedDocument 获取扩展了viewBox的文档副本，原有内容位于108dp图层中央的72dp可见区域
+func paddedDocument(doc *svg.Document) *svg.Document {
+	padded := *doc
+	inset := float64(adaptiveSize-adaptiveViewport) / 2 / adaptiveViewport
+	viewBox := doc.ViewBox
+	padded.ViewBox = svg.Rect{
+		X:      viewBox.X - viewBox.Width*inset,
+		Y:      viewBox.Y - viewBox.Height*inset,
+		Width:  viewBox.Width * (1 + 2*inset),
+		Height: viewBox.Height * (1 + 2*inset),
+	}
+	padded.Width, padded.Height = adaptiveSize, adaptiveSize
+	return &padded
+}
+
+// adaptiveIconXML 生成自适应图标的XML，单色层只在Android 13+的主题图标中使用，旧版本会忽略
+func adaptiveIconXML(background, foreground, monochrome string) []byte {
+	var out strings.Builder
+	out.WriteString(`<?xml version="1.0" encoding="utf-8"?>` + "\n")
+	out.WriteString("<!-- 由 app-assets-generator 自动生成，请勿手动修改 -->\n")
+	out.WriteString(`<adaptive-icon xmlns:android="http://schemas.android.com/apk/res/android">` + "\n")
+	fmt.Fprintf(&out, "    <background android:drawable=\"%s\" />\n", background)
+	fmt.Fprintf(&out, "    <foreground android:drawable=\"%s\" />\n", foreground)
+	fmt.Fprintf(&out, "    <monochrome android:drawable=\"%s\" />\n", monochrome)
+	out.WriteString("</adaptive-icon>\n")
+	return []byte(out.String())
+}
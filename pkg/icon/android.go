@@ -0,0 +1,103 @@
+package icon
+
+import (
+	"image"
+	"math"
+	"path/filepath"
+)
+
+// launcherSize 旧版启动图标的尺寸（dp）
+const launcherSize = 48
+
+// launcherDensity 启动图标的屏幕密度
+type launcherDensity struct {
+	Name  string  // 密度名称
+	Scale float64 // 相对于mdpi的缩放比例
+}
+
+// launcherDensities 启动图标需要的密度
+var launcherDensities = []launcherDensity{
+	{Name: "mdpi", Scale: 1},
+	{Name: "hdpi", Scale: 1.5},
+	{Name: "xhdpi", Scale: 2},
+	{Name: "xxhdpi", Scale: 3},
+	{Name: "xxxhdpi", Scale: 4},
+}
+
+// directory 获取密度对应的mipmap目录
+func (d launcherDensity) directory() string {
+	return "mipmap-" + d.Name
+}
+
+// pixels 获取dp尺寸在该密度下的像素尺寸
+func (d launcherDensity) pixels(dp float64) int {
+	return int(math.Round(dp * d.Scale))
+}
+
+// ValidateAndroid 读取图标母版，并解析自适应图标的背景色和图层，出错时不写入任何文件
+// 同时生成两个平台的图标时，在生成iOS图标之前调用，避免Android的配置错误留下只生成了一半的输出
+func (g *Generator) ValidateAndroid() error {
+	if _, err := g.launcherMaster(); err != nil {
+		return err
+	}
+	if g.options.AdaptiveBackground == "" || g.adaptive != nil {
+		return nil
+	}
+	adaptive, err := g.loadAdaptive()
+	if err != nil {
+		return err
+	}
+	g.adaptive = adaptive
+	return nil
+}
+
+// launcherMaster 读取旧版启动图标的母版，xxxhdpi的48dp图标为192像素
+func (g *Generator) launcherMaster() (*master, error) {
+	return g.loadMaster(g.inputPath, launcherDensities[len(launcherDensities)-1].pixels(launcherSize))
+}
+
+// GenerateAndroid 生成各密度的 mipmap-*dpi/ic_launcher.png 和圆形的 ic_launcher_round.png
+// 指定了自适应图标的背景色时，同时生成Android 8+的自适应图标
+func (g *Generator) GenerateAndroid() error {
+	if err := g.ValidateAndroid(); err != nil {
+		return err
+	}
+	m, err := g.launcherMaster()
+	if err != nil {
+		return err
+	}
+
+	for _, density := range launcherDensities {
+		img := g.render(m, density.pixels(launcherSize))
+		dir := filepath.Join(g.outputPath, density.directory())
+		if err := writePNG(filepath.Join(dir, g.options.AndroidName+".png"), img); err != nil {
+			return err
+		}
+		if err := writePNG(filepath.Join(dir, g.options.AndroidName+"_round.png"), circleMask(img)); err != nil {
+			return err
+		}
+	}
+
+	if g.adaptive != nil {
+		return g.generateAdaptive(g.adaptive)
+	}
+	return nil
+}
+
+// circleMask 获取裁剪为内切圆的图标，边缘按像素覆盖面积抗锯齿
+func circleMask(src *image.NRGBA) *image.NRGBA {
+	bounds := src.Bounds()
+	dst := image.NewNRGBA(bounds)
+	copy(dst.Pix, src.Pix)
+
+	radius := float64(bounds.Dx()) / 2
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			distance := math.Hypot(float64(x)+0.5-radius, float64(y)+0.5-radius)
+			cover := math.Max(0, math.Min(1, radius-distance+0.5))
+			i := dst.PixOffset(x, y) + 3
+			dst.Pix[i] = uint8(math.Round(float64(dst.Pix[i]) * cover))
+		}
+	}
+	return dst
+}
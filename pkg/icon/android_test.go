@@ -0,0 +1,153 @@
+package icon
+
+import (
+	"image"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// iconSVG 居中的红色方块，位于自适应图标的安全区内
+const iconSVG = `<svg xmlns="http://www.w3.org/2000/svg" width="100" height="100">
+	<rect x="30" y="30" width="40" height="40" fill="#ff0000"/>
+</svg>`
+
+// writeFiles 在临时目录中写入测试文件，返回目录路径
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestGenerateAndroidInvalidAdaptive(t *testing.T) {
+	tests := []struct {
+		name    string
+		colors  string
+		options Options
+		want    string
+	}{
+		{
+			name:    "颜色不存在",
+			colors:  "bg:\n  hex: \"#ffffff\"\n  alpha: 1\n",
+			options: Options{AdaptiveBackground: "missing"},
+			want:    "颜色 missing 在",
+		},
+		{
+			name:    "颜色配置有错误",
+			colors:  "bg:\n  hex: \"#zzzzzz\"\n  alpha: 1\n",
+			options: Options{AdaptiveBackground: "bg"},
+			want:    "解析颜色配置失败",
+		},
+		{
+			name: "渐变色",
+			colors: "bg:\n  type: linear\n  angle: 90\n  stops:\n" +
+				"    - color: \"#000000\"\n      position: 0\n    - color: \"#ffffff\"\n      position: 1\n",
+			options: Options{AdaptiveBackground: "bg"},
+			want:    "是渐变色",
+		},
+		{
+			name:    "单色层不存在",
+			colors:  "bg:\n  hex: \"#ffffff\"\n  alpha: 1\n",
+			options: Options{AdaptiveBackground: "bg", Monochrome: "missing.svg"},
+			want:    "missing.svg",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := writeFiles(t, map[string]string{"icon.svg": iconSVG, "colors.yaml": test.colors})
+			output := filepath.Join(dir, "res")
+			options := test.options
+			options.ColorsPath = filepath.Join(dir, "colors.yaml")
+			if options.Monochrome != "" {
+				options.Monochrome = filepath.Join(dir, options.Monochrome)
+			}
+
+			err := NewGenerator(filepath.Join(dir, "icon.svg"), output, options).GenerateAndroid()
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Fatalf("错误为 %v，应包含 %q", err, test.want)
+			}
+			if _, err := os.Stat(output); !os.IsNotExist(err) {
+				t.Errorf("配置错误时不应写入任何文件")
+			}
+		})
+	}
+}
+
+func TestGenerateAndroidAdaptive(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"icon.svg":    iconSVG,
+		"mono.svg":    iconSVG,
+		"colors.yaml": "bg:\n  light:\n    hex: \"#ffffff\"\n    alpha: 1\n  dark:\n    hex: \"#000000\"\n    alpha: 0.5\n",
+	})
+	output := filepath.Join(dir, "res")
+	generator := NewGenerator(filepath.Join(dir, "icon.svg"), output, Options{
+		AndroidName:        "ic_app",
+		AdaptiveBackground: "bg",
+		ColorsPath:         filepath.Join(dir, "colors.yaml"),
+		Monochrome:         filepath.Join(dir, "mono.svg"),
+	})
+	if err := generator.GenerateAndroid(); err != nil {
+		t.Fatalf("GenerateAndroid() 失败: %v", err)
+	}
+
+	for _, name := range []string{
+		"mipmap-mdpi/ic_app.png", "mipmap-xxxhdpi/ic_app_round.png",
+		"drawable/ic_app_foreground.xml", "drawable/ic_app_monochrome.xml", "mipmap-anydpi-v26/ic_app_round.xml",
+	} {
+		if _, err := os.Stat(filepath.Join(output, name)); err != nil {
+			t.Errorf("缺少 %s", name)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(output, "mipmap-anydpi-v26", "ic_app.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`<background android:drawable="@color/bg" />`,
+		`<foreground android:drawable="@drawable/ic_app_foreground" />`,
+		`<monochrome android:drawable="@drawable/ic_app_monochrome" />`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("自适应图标中没有 %s\n%s", want, data)
+		}
+	}
+
+	// 深色背景半透明
+	if warnings := generator.Warnings(); len(warnings) != 1 || !strings.Contains(warnings[0], "不是完全不透明的") {
+		t.Errorf("警告为 %q", warnings)
+	}
+}
+
+func TestCircleMask(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 10, 10))
+	for i := range src.Pix {
+		src.Pix[i] = 255
+	}
+	masked := circleMask(src)
+
+	tests := []struct {
+		x, y  int
+		alpha uint8
+	}{
+		{x: 5, y: 5, alpha: 255}, // 中心
+		{x: 1, y: 5, alpha: 255}, // 靠近边缘但完全在圆内
+		{x: 0, y: 0, alpha: 0},   // 角落在圆外
+		{x: 9, y: 9, alpha: 0},
+	}
+	for _, test := range tests {
+		if got := masked.NRGBAAt(test.x, test.y).A; got != test.alpha {
+			t.Errorf("(%d, %d) 的alpha为 %d，应为 %d", test.x, test.y, got, test.alpha)
+		}
+	}
+	if src.NRGBAAt(0, 0).A != 255 {
+		t.Errorf("circleMask不应修改原图")
+	}
+}
@@ -0,0 +1,214 @@
+package icon
+
+import (
+	"app-assets-generator/pkg/color"
+	assetimage "app-assets-generator/pkg/image"
+	"app-assets-generator/pkg/svg"
+	"fmt"
+	"image"
+	stdcolor "image/color"
+	"image/draw"
+	_ "image/jpeg" // 注册JPEG解码器
+	_ "image/png"  // 注册PNG解码器
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// MasterSize iOS图标母版需要的最小尺寸（App Store图标的像素尺寸）
+const MasterSize = 1024
+
+// DefaultIconSetName 默认的iOS图标集名称
+const DefaultIconSetName = "AppIcon"
+
+// DefaultAndroidName 默认的Android图标资源名称
+const DefaultAndroidName = "ic_launcher"
+
+// Generator 应用图标生成器
+type Generator struct {
+	inputPath  string             // 图标母版（1024×1024的PNG或SVG）
+	outputPath string             // 输出目录
+	options    Options            // 生成选项
+	masters    map[string]*master // 读取后的母版和图层图片，按路径缓存
+	adaptive   *adaptiveIcon      // 校验后的自适应图标输入
+	warnings   []string
+}
+
+// Options 生成选项
+type Options struct {
+	CatalogPath string // 已有的Assets.xcassets路径，设置后iOS图标集写入该目录
+	Name        string // iOS图标集名称，为空时使用 DefaultIconSetName
+	Legacy      bool   // 生成旧版Xcode需要的完整尺寸列表，默认只生成1024的单一尺寸
+
+	AndroidName string // Android图标资源名称，为空时使用 DefaultAndroidName
+
+	// Background 透明区域合成到的背景色，为nil时保持透明（iOS图标包含透明区域时报错）
+	Background *stdcolor.NRGBA
+
+	// 自适应图标（Android 8+），设置AdaptiveBackground时生成
+	AdaptiveBackground string // 背景层使用的颜色名称，必须在ColorsPath中定义
+	ColorsPath         string // 颜色配置文件（colors.yaml）
+	Foreground         string // 前景层图片（SVG或PNG），为空时使用图标母版
+	Monochrome         string // 主题图标（Android 13+）的单色层图片，为空时使用前景层
+}
+
+// NewGenerator 创建新的生成器
+func NewGenerator(inputPath, outputPath string, options Options) *Generator {
+	if options.Name == "" {
+		options.Name = DefaultIconSetName
+	}
+	if options.AndroidName == "" {
+		options.AndroidName = DefaultAndroidName
+	}
+	return &Generator{
+		inputPath:  inputPath,
+		outputPath: outputPath,
+		options:    options,
+		masters:    make(map[string]*master),
+	}
+}
+
+// Warnings 获取生成过程中的警告
+func (g *Generator) Warnings() []string {
+	return g.warnings
+}
+
+// ParseBackground 解析背景色，支持hex、rgb()、颜色名称等CSS颜色值，背景色必须不透明
+func ParseBackground(value string) (*stdcolor.NRGBA, error) {
+	parsed, err := color.ParseCSSColor(value)
+	if err != nil {
+		return nil, err
+	}
+	if parsed.Alpha < 1 {
+		return nil, fmt.Errorf("背景色 %s 必须是不透明的颜色", value)
+	}
+	rgb, err := strconv.ParseUint(strings.TrimPrefix(parsed.Hex, "#"), 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("无法识别的颜色值: %s", value)
+	}
+	return &stdcolor.NRGBA{R: uint8(rgb >> 16), G: uint8(rgb >> 8), B: uint8(rgb), A: 255}, nil
+}
+
+// master 图标母版：位图按目标尺寸缩小，SVG按目标尺寸渲染
+type master struct {
+	bitmap image.Image
+	doc    *svg.Document
+}
+
+// loadMaster 读取并校验图标母版或图层图片，minSize为需要的最小像素尺寸
+func (g *Generator) loadMaster(path string, minSize int) (*master, error) {
+	m, ok := g.masters[path]
+	if !ok {
+		var err error
+		if m, err = g.readMaster(path); err != nil {
+			return nil, err
+		}
+		g.masters[path] = m
+	}
+
+	name := filepath.Base(path)
+	width, height := m.size()
+	if math.Abs(width-height) > 1e-6 {
+		return nil, fmt.Errorf("图片 %s 必须是正方形，当前为 %sx%s", name, formatSize(width), formatSize(height))
+	}
+	if m.bitmap != nil && width < float64(minSize) {
+		return nil, fmt.Errorf("图片 %s 至少需要 %dx%d 像素，当前为 %sx%s", name, minSize, minSize, formatSize(width), formatSize(height))
+	}
+	return m, nil
+}
+
+// readMaster 解码图片文件
+func (g *Generator) readMaster(path string) (*master, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".svg":
+		doc, err := svg.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, warning := range doc.Warnings {
+			g.warnings = append(g.warnings, fmt.Sprintf("%s: %s", filepath.Base(path), warning))
+		}
+		return &master{doc: doc}, nil
+	case ".png", ".jpg", ".jpeg":
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("读取图片 %s 失败: %w", filepath.Base(path), err)
+		}
+		defer file.Close()
+		img, _, err := image.Decode(file)
+		if err != nil {
+			return nil, fmt.Errorf("解码图片 %s 失败: %w", filepath.Base(path), err)
+		}
+		return &master{bitmap: img}, nil
+	}
+	return nil, fmt.Errorf("不支持的图片格式: %s (必须是PNG、JPEG或SVG)", filepath.Ext(path))
+}
+
+// size 母版的尺寸，位图为像素尺寸，SVG为显示尺寸
+func (m *master) size() (float64, float64) {
+	if m.doc != nil {
+		return m.doc.Width, m.doc.Height
+	}
+	bounds := m.bitmap.Bounds()
+	return float64(bounds.Dx()), float64(bounds.Dy())
+}
+
+// render 获取指定像素尺寸的正方形图标
+func (m *master) render(size int) *image.NRGBA {
+	if m.doc != nil {
+		return assetimage.RenderSVG(m.doc, assetimage.Size{Width: float64(size), Height: float64(size)}, 1)
+	}
+	bounds := m.bitmap.Bounds()
+	if bounds.Dx() == size && bounds.Dy() == size {
+		img := image.NewNRGBA(image.Rect(0, 0, size, size))
+		draw.Draw(img, img.Bounds(), m.bitmap, bounds.Min, draw.Src)
+		return img
+	}
+	return assetimage.Resize(m.bitmap, size, size)
+}
+
+// render 获取指定像素尺寸的图标，设置了背景色时合成到背景上
+func (g *Generator) render(m *master, size int) *image.NRGBA {
+	img := m.render(size)
+	if g.options.Background != nil {
+		flatten(img, *g.options.Background)
+	}
+	return img
+}
+
+// flatten 将图片合成到不透明的背景色上
+func flatten(img *image.NRGBA, background stdcolor.NRGBA) {
+	channels := [3]float64{float64(background.R), float64(background.G), float64(background.B)}
+	for i := 0; i < len(img.Pix); i += 4 {
+		alpha := float64(img.Pix[i+3]) / 255
+		for c, channel := range channels {
+			img.Pix[i+c] = uint8(math.Round(float64(img.Pix[i+c])*alpha + channel*(1-alpha)))
+		}
+		img.Pix[i+3] = 255
+	}
+}
+
+// isOpaque 判断图片是否完全不透明
+func isOpaque(img *image.NRGBA) bool {
+	for i := 3; i < len(img.Pix); i += 4 {
+		if img.Pix[i] != 255 {
+			return false
+		}
+	}
+	return true
+}
+
+// writePNG 写入PNG文件，完全不透明的图片不包含alpha通道
+func writePNG(path string, img image.Image) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("创建目录 %s 失败: %w", filepath.Dir(path), err)
+	}
+	return assetimage.WriteImage(path, img)
+}
+
+// formatSize 格式化尺寸
+func formatSize(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,145 @@
+package icon
+
+import (
+	"app-assets-generator/pkg/xcassets"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// appIconSet 图标集Contents.json结构
+type appIconSet struct {
+	Images []appIcon `json:"images"`
+	Info   iconInfo  `json:"info"`
+}
+
+// appIcon 图标条目
+type appIcon struct {
+	Filename string `json:"filename"`
+	Idiom    string `json:"idiom"`
+	Platform string `json:"platform,omitempty"` // 单一尺寸格式为ios
+	Scale    string `json:"scale,omitempty"`    // 单一尺寸格式不指定倍数
+	Size     string `json:"size"`
+}
+
+// iconInfo Xcode生成的info字段
+type iconInfo struct {
+	Author  string `json:"author"`
+	Version int    `json:"version"`
+}
+
+// iconSlot 旧版图标集中的一个尺寸
+type iconSlot struct {
+	Idiom string
+	Size  float64 // 点尺寸
+	Scale int
+}
+
+// legacySlots 旧版Xcode（Xcode 14之前）需要的完整尺寸列表
+var legacySlots = []iconSlot{
+	{"iphone", 20, 2}, {"iphone", 20, 3},
+	{"iphone", 29, 2}, {"iphone", 29, 3},
+	{"iphone", 40, 2}, {"iphone", 40, 3},
+	{"iphone", 60, 2}, {"iphone", 60, 3},
+	{"ipad", 20, 1}, {"ipad", 20, 2},
+	{"ipad", 29, 1}, {"ipad", 29, 2},
+	{"ipad", 40, 1}, {"ipad", 40, 2},
+	{"ipad", 76, 1}, {"ipad", 76, 2},
+	{"ipad", 83.5, 2},
+	{"ios-marketing", 1024, 1},
+}
+
+// GenerateIOS 生成 <名称>.appiconset，默认使用Xcode 14起的单一1024尺寸格式
+// App Store不接受带alpha通道的图标，母版包含透明区域且没有指定背景色时报错
+func (g *Generator) GenerateIOS() error {
+	m, err := g.loadMaster(g.inputPath, MasterSize)
+	if err != nil {
+		return err
+	}
+	full := g.render(m, MasterSize)
+	if !isOpaque(full) {
+		return fmt.Errorf("iOS图标不能包含透明区域（App Store会拒绝带alpha通道的图标），请使用 --background 指定合成的背景色")
+	}
+
+	iconSetPath, err := g.iconSetPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(iconSetPath, 0755); err != nil {
+		return fmt.Errorf("创建图标集目录失败: %w", err)
+	}
+
+	iconSet := appIconSet{Images: []appIcon{}, Info: iconInfo{Author: "xcode", Version: 1}}
+	if !g.options.Legacy {
+		fileName := g.options.Name + ".png"
+		if err := writePNG(filepath.Join(iconSetPath, fileName), full); err != nil {
+			return err
+		}
+		iconSet.Images = append(iconSet.Images, appIcon{Filename: fileName, Idiom: "universal", Platform: "ios", Size: "1024x1024"})
+	} else {
+		// 相同点尺寸和倍数的iPhone、iPad条目共用一个文件
+		written := make(map[string]bool)
+		for _, slot := range legacySlots {
+			size := formatSize(slot.Size) + "x" + formatSize(slot.Size)
+			scale := strconv.Itoa(slot.Scale) + "x"
+			fileName := fmt.Sprintf("%s-%s@%s.png", g.options.Name, size, scale)
+			if !written[fileName] {
+				pixels := int(math.Round(slot.Size * float64(slot.Scale)))
+				img := full
+				if pixels != MasterSize {
+					img = g.render(m, pixels)
+				}
+				if err := writePNG(filepath.Join(iconSetPath, fileName), img); err != nil {
+					return err
+				}
+				written[fileName] = true
+			}
+			iconSet.Images = append(iconSet.Images, appIcon{Filename: fileName, Idiom: slot.Idiom, Scale: scale, Size: size})
+		}
+	}
+
+	if err := g.removeStaleIcons(iconSetPath, iconSet); err != nil {
+		return err
+	}
+
+	// 图标集完全由工具生成，不与已有的条目合并，切换格式时不会残留旧的条目
+	return xcassets.WriteContents(filepath.Join(iconSetPath, "Contents.json"), iconSet, false)
+}
+
+// iconSetPath 获取图标集目录，指定了资源目录时写入资源目录
+func (g *Generator) iconSetPath() (string, error) {
+	parent := g.outputPath
+	if g.options.CatalogPath != "" {
+		if err := xcassets.EnsureCatalog(g.options.CatalogPath); err != nil {
+			return "", err
+		}
+		parent = g.options.CatalogPath
+	}
+	return filepath.Join(parent, g.options.Name+".appiconset"), nil
+}
+
+// removeStaleIcons 删除之前生成、但不再被Contents.json引用的图标文件（如切换单一尺寸和完整尺寸格式后）
+func (g *Generator) removeStaleIcons(iconSetPath string, iconSet appIconSet) error {
+	referenced := make(map[string]bool)
+	for _, icon := range iconSet.Images {
+		referenced[icon.Filename] = true
+	}
+
+	entries, err := os.ReadDir(iconSetPath)
+	if err != nil {
+		return fmt.Errorf("读取图标集目录失败: %w", err)
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || referenced[name] || !strings.HasPrefix(name, g.options.Name) || filepath.Ext(name) != ".png" {
+			continue
+		}
+		if err := os.Remove(filepath.Join(iconSetPath, name)); err != nil {
+			return fmt.Errorf("删除旧的图标 %s 失败: %w", name, err)
+		}
+	}
+	return nil
+}
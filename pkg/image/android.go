@@ -1,6 +1,7 @@
 package image
 
 import (
+	"app-assets-generator/pkg/svg"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -11,6 +12,8 @@ import (
 type AndroidImageGenerator struct {
 	inputPath  string
 	outputPath string
+	warnings   []string // SVG转换时不支持或近似处理的特性
+	png        pngRendering // 需要渲染为PNG的SVG
 }
 
 // NewAndroidImageGenerator 创建Android图片生成器
@@ -36,10 +39,23 @@ var androidDensities = []AndroidDensity{
 	{Name: "xxxhdpi", Scale: 4.0, Directory: "drawable-xxxhdpi"}, // 4x
 }
 
+// DirectoryWith 获取带额外限定符的目录名称，如 drawable-sw600dp-xhdpi、drawable-night-xhdpi
+func (d AndroidDensity) DirectoryWith(qualifier string) string {
+	return d.ResourceDirectory("drawable", qualifier)
+}
+
+// ResourceDirectory 获取指定资源类型带额外限定符的目录名称，如 mipmap-xxhdpi、drawable-sw600dp-xhdpi
+func (d AndroidDensity) ResourceDirectory(resourceType, qualifier string) string {
+	return resourceType + "-" + joinQualifiers(qualifier, d.Name)
+}
+
 // Generate 生成Android图片资源
 func (g *AndroidImageGenerator) Generate(images map[string]*ImageInfo) error {
 	// 为每个图片生成Android资源
 	for _, imageInfo := range images {
+		if !imageInfo.Options.includes("android") {
+			continue
+		}
 		if err := g.generateAndroidImage(imageInfo); err != nil {
 			return fmt.Errorf("生成图片 %s 失败: %w", imageInfo.Name, err)
 		}
@@ -51,11 +67,276 @@ func (g *AndroidImageGenerator) Generate(images map[string]*ImageInfo) error {
 // generateAndroidImage 生成单个Android图片资源
 func (g *AndroidImageGenerator) generateAndroidImage(imageInfo *ImageInfo) error {
 	// Android使用下划线命名，将连字符转换为下划线
-	androidName := strings.ReplaceAll(imageInfo.Name, "-", "_")
+	androidName := strings.ReplaceAll(imageInfo.OutputName(), "-", "_")
 	androidName = strings.ToLower(androidName) // Android资源名称通常使用小写
 	
+	base := imageInfo.appearance("")
+	if phoneIdiom(base) == "ipad" {
+		g.warnings = append(g.warnings, fmt.Sprintf("图片 %s 只有iPad专属文件，Android手机和平板都使用这些文件", imageInfo.Name))
+	}
+	if err := g.generateAppearance(base, androidName, ""); err != nil {
+		return err
+	}
+	
+	// 深色图片使用同名资源，写入night限定符的目录
+	if dark := imageInfo.appearance(darkAppearance); dark != nil {
+		return g.generateAppearance(dark, androidName, nightQualifier)
+	}
+	return nil
+}
+
+// nightQualifier 深色模式的资源限定符
+const nightQualifier = "night"
+
+// joinQualifiers 按Android要求的顺序连接非空的资源限定符，如 sw600dp-night
+func joinQualifiers(qualifiers ...string) string {
+	var parts []string
+	for _, qualifier := range qualifiers {
+		if qualifier != "" {
+			parts = append(parts, qualifier)
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+// generateAppearance 生成一种外观的Android图片资源，night为深色模式的限定符（默认外观为空）
+func (g *AndroidImageGenerator) generateAppearance(imageInfo *ImageInfo, androidName, night string) error {
+	phone, tablet := joinQualifiers(night), joinQualifiers("sw600dp", night)
+	
+	// 指定渲染为PNG的SVG生成各密度的PNG，其它SVG转换为VectorDrawable
+	if g.png.applies(imageInfo) {
+		if err := g.renderDensities(imageInfo, androidName, phoneIdiom(imageInfo), phone); err != nil {
+			return err
+		}
+		if hasTabletImage(imageInfo) {
+			return g.renderDensities(imageInfo, androidName, "ipad", tablet)
+		}
+		return nil
+	}
+	if imageInfo.Extension == ".svg" {
+		return g.generateVectorDrawables(imageInfo, androidName, night)
+	}
+	
+	// PNG/JPEG按各密度的精确尺寸重新采样，其它格式按倍数复制
+	writeDensities := g.copyDensities
+	if isResampleable(imageInfo) {
+		writeDensities = g.resampleDensities
+	}
+	
+	// 手机使用通用图片（没有通用图片时使用iPhone专属图片），平板使用iPad专属图片
+	if err := writeDensities(imageInfo, androidName, phoneIdiom(imageInfo), phone); err != nil {
+		return err
+	}
+	if hasTabletImage(imageInfo) {
+		if err := writeDensities(imageInfo, androidName, "ipad", tablet); err != nil {
+			return err
+		}
+	}
+	
+	return nil
+}
+
+// phoneIdiom 获取Android手机使用的设备类型：优先通用图片，其次iPhone专属图片
+// 只有iPad专属图片时也使用iPad图片，保证不带限定符的目录中有资源，否则手机上找不到资源
+func phoneIdiom(imageInfo *ImageInfo) string {
+	switch {
+	case imageInfo.HasIdiom("universal"):
+		return "universal"
+	case imageInfo.HasIdiom("iphone"):
+		return "iphone"
+	case imageInfo.HasIdiom("ipad"):
+		return "ipad"
+	}
+	return "universal"
+}
+
+// hasTabletImage 判断是否需要单独生成sw600dp的平板资源：有iPad专属图片，且手机没有使用这些图片
+func hasTabletImage(imageInfo *ImageInfo) bool {
+	return imageInfo.HasIdiom("ipad") && phoneIdiom(imageInfo) != "ipad"
+}
+
+// outputDirectories 获取图片会写入的drawable目录，包括深色图片的night目录
+func (g *AndroidImageGenerator) outputDirectories(imageInfo *ImageInfo) map[string]bool {
+	directories := make(map[string]bool)
+	g.addOutputDirectories(directories, imageInfo.appearance(""), "")
+	if dark := imageInfo.appearance(darkAppearance); dark != nil {
+		g.addOutputDirectories(directories, dark, nightQualifier)
+	}
+	return directories
+}
+
+// addOutputDirectories 添加一种外观的图片会写入的drawable目录
+func (g *AndroidImageGenerator) addOutputDirectories(directories map[string]bool, imageInfo *ImageInfo, night string) {
+	phone, tablet := joinQualifiers(night), joinQualifiers("sw600dp", night)
+	resourceType := imageInfo.Options.resourceType()
+	if g.png.applies(imageInfo) {
+		for _, density := range androidDensities {
+			directories[density.ResourceDirectory(resourceType, phone)] = true
+			if hasTabletImage(imageInfo) {
+				directories[density.ResourceDirectory(resourceType, tablet)] = true
+			}
+		}
+		return
+	}
+	if imageInfo.Extension == ".svg" {
+		directories[vectorDirectory(resourceType, phone)] = true
+		if hasTabletImage(imageInfo) {
+			directories[vectorDirectory(resourceType, tablet)] = true
+		}
+		return
+	}
+	if isResampleable(imageInfo) {
+		for _, density := range g.resampledDensities(imageInfo, phoneIdiom(imageInfo)) {
+			directories[density.ResourceDirectory(resourceType, phone)] = true
+		}
+		if hasTabletImage(imageInfo) {
+			for _, density := range g.resampledDensities(imageInfo, "ipad") {
+				directories[density.ResourceDirectory(resourceType, tablet)] = true
+			}
+		}
+		return
+	}
+	for density, sourceFile := range g.getAndroidMapping(imageInfo, phoneIdiom(imageInfo)) {
+		if sourceFile != "" {
+			directories[density.ResourceDirectory(resourceType, phone)] = true
+		}
+	}
+	if hasTabletImage(imageInfo) {
+		for density, sourceFile := range g.getAndroidMapping(imageInfo, "ipad") {
+			if sourceFile != "" {
+				directories[density.ResourceDirectory(resourceType, tablet)] = true
+			}
+		}
+	}
+}
+
+// generateVectorDrawables 将SVG转换为 drawable/<名称>.xml，iPad专属的SVG写入 drawable-sw600dp
+// 深色图片写入 drawable-night、drawable-sw600dp-night
+func (g *AndroidImageGenerator) generateVectorDrawables(imageInfo *ImageInfo, androidName, night string) error {
+	if err := g.writeVectorDrawable(imageInfo, androidName, phoneIdiom(imageInfo), joinQualifiers(night)); err != nil {
+		return err
+	}
+	if hasTabletImage(imageInfo) {
+		return g.writeVectorDrawable(imageInfo, androidName, "ipad", joinQualifiers("sw600dp", night))
+	}
+	return nil
+}
+
+// writeVectorDrawable 转换指定设备类型的SVG，矢量图不区分倍数，使用该设备类型的第一个文件
+func (g *AndroidImageGenerator) writeVectorDrawable(imageInfo *ImageInfo, androidName, idiom, qualifier string) error {
+	fileName := imageInfo.LookupAny(idiom)
+	if fileName == "" {
+		return nil
+	}
+	
+	doc, err := svg.ReadFile(filepath.Join(sourceDir(imageInfo, g.inputPath), fileName))
+	if err != nil {
+		return err
+	}
+	resizeDocument(doc, imageInfo.Options.Size)
+	data, warnings := doc.VectorDrawable()
+	for _, warning := range append(doc.Warnings, warnings...) {
+		g.warnings = append(g.warnings, fmt.Sprintf("%s: %s", fileName, warning))
+	}
+	
+	dst := filepath.Join(g.outputPath, vectorDirectory(imageInfo.Options.resourceType(), qualifier), androidName+".xml")
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return fmt.Errorf("创建目录 %s 失败: %w", filepath.Dir(dst), err)
+	}
+	if err := os.WriteFile(dst, data, 0644); err != nil {
+		return fmt.Errorf("写入 %s 失败: %w", dst, err)
+	}
+	return nil
+}
+
+// renderDensities 将指定设备类型的SVG渲染为五个密度的PNG
+func (g *AndroidImageGenerator) renderDensities(imageInfo *ImageInfo, androidName, idiom, qualifier string) error {
+	fileName := imageInfo.LookupAny(idiom)
+	if fileName == "" {
+		return nil
+	}
+	doc, warnings, err := g.png.read(filepath.Join(sourceDir(imageInfo, g.inputPath), fileName), imageInfo)
+	if err != nil {
+		return err
+	}
+	g.warnings = append(g.warnings, warnings...)
+	
+	for _, density := range androidDensities {
+		dst := filepath.Join(g.outputPath, density.ResourceDirectory(imageInfo.Options.resourceType(), qualifier), androidName+".png")
+		if err := g.png.render(doc, dst, density.Scale); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// vectorDirectory 获取矢量图的目录名称，如 drawable、drawable-sw600dp
+func vectorDirectory(resourceType, qualifier string) string {
+	return joinQualifiers(resourceType, qualifier)
+}
+
+// resampleDensities 为各密度生成精确尺寸的图片：倍数正好对应的源图直接复制，其余从最大的源图缩小
+// 需要放大的密度不生成，运行时由系统从较低密度缩放
+func (g *AndroidImageGenerator) resampleDensities(imageInfo *ImageInfo, androidName, idiom, qualifier string) error {
+	source, ok := largestSource(imageInfo, g.inputPath, idiom)
+	if !ok {
+		return nil
+	}
+	
+	resourceType := imageInfo.Options.resourceType()
+	var skipped []string
+	for _, density := range androidDensities {
+		dst := filepath.Join(g.outputPath, density.ResourceDirectory(resourceType, qualifier), androidName+imageInfo.Extension)
+		if fileName := imageInfo.Lookup(idiom, densityScale(density)); fileName != "" {
+			if err := copyFile(filepath.Join(sourceDir(imageInfo, g.inputPath), fileName), dst); err != nil {
+				return fmt.Errorf("复制图片文件失败: %w", err)
+			}
+			continue
+		}
+		if density.Scale > source.scale {
+			skipped = append(skipped, density.ResourceDirectory(resourceType, qualifier))
+			continue
+		}
+		if err := source.resampleTo(dst, density.Scale); err != nil {
+			return err
+		}
+	}
+	
+	if len(skipped) > 0 {
+		g.warnings = append(g.warnings, fmt.Sprintf("图片 %s 最大的源图为 %s，%s 需要放大，已跳过（运行时由系统从较低密度缩放）",
+			filepath.Base(source.path), formatScale(source.scale), strings.Join(skipped, ", ")))
+	}
+	return nil
+}
+
+// resampledDensities 获取重新采样时会生成的密度
+func (g *AndroidImageGenerator) resampledDensities(imageInfo *ImageInfo, idiom string) []AndroidDensity {
+	source, ok := largestSource(imageInfo, g.inputPath, idiom)
+	if !ok {
+		return nil
+	}
+	var densities []AndroidDensity
+	for _, density := range androidDensities {
+		if density.Scale <= source.scale || imageInfo.Lookup(idiom, densityScale(density)) != "" {
+			densities = append(densities, density)
+		}
+	}
+	return densities
+}
+
+// densityScale 获取与密度倍数正好对应的iOS倍数（mdpi/xhdpi/xxhdpi对应1x/2x/3x），没有对应时返回空字符串
+func densityScale(density AndroidDensity) string {
+	switch density.Scale {
+	case 1, 2, 3:
+		return formatScale(density.Scale)
+	}
+	return ""
+}
+
+// copyDensities 将指定设备类型的图片复制到各密度目录，qualifier为额外的资源限定符（如sw600dp）
+func (g *AndroidImageGenerator) copyDensities(imageInfo *ImageInfo, androidName, idiom, qualifier string) error {
 	// 根据可用的iOS图片决定如何分配到Android密度
-	mapping := g.getAndroidMapping(imageInfo)
+	mapping := g.getAndroidMapping(imageInfo, idiom)
 	
 	// 复制图片到对应的drawable目录
 	for density, sourceFile := range mapping {
@@ -64,13 +345,13 @@ func (g *AndroidImageGenerator) generateAndroidImage(imageInfo *ImageInfo) error
 		}
 		
 		// 创建目标目录
-		targetDir := filepath.Join(g.outputPath, density.Directory)
+		targetDir := filepath.Join(g.outputPath, density.ResourceDirectory(imageInfo.Options.resourceType(), qualifier))
 		if err := os.MkdirAll(targetDir, 0755); err != nil {
 			return fmt.Errorf("创建目录 %s 失败: %w", targetDir, err)
 		}
 		
 		// 复制文件
-		src := filepath.Join(g.inputPath, sourceFile)
+		src := filepath.Join(sourceDir(imageInfo, g.inputPath), sourceFile)
 		dst := filepath.Join(targetDir, androidName+imageInfo.Extension)
 		
 		if err := copyFile(src, dst); err != nil {
@@ -82,23 +363,27 @@ func (g *AndroidImageGenerator) generateAndroidImage(imageInfo *ImageInfo) error
 }
 
 // getAndroidMapping 获取iOS图片到Android密度的映射
-func (g *AndroidImageGenerator) getAndroidMapping(imageInfo *ImageInfo) map[AndroidDensity]string {
+func (g *AndroidImageGenerator) getAndroidMapping(imageInfo *ImageInfo, idiom string) map[AndroidDensity]string {
 	mapping := make(map[AndroidDensity]string)
 	
+	fileName1x := imageInfo.Lookup(idiom, "1x")
+	fileName2x := imageInfo.Lookup(idiom, "2x")
+	fileName3x := imageInfo.Lookup(idiom, "3x")
+	has1x, has2x, has3x := fileName1x != "", fileName2x != "", fileName3x != ""
+	
 	// 理想映射：
 	// iOS 1x -> Android mdpi (1x)
 	// iOS 2x -> Android xhdpi (2x)
 	// iOS 3x -> Android xxhdpi (3x)
 	
 	// 如果有1x图片，用于mdpi
-	if imageInfo.Has1x {
-		mapping[androidDensities[0]] = imageInfo.Name + imageInfo.Extension // mdpi
+	if has1x {
+		mapping[androidDensities[0]] = fileName1x // mdpi
 	}
 	
 	// 如果有2x图片，用于hdpi和xhdpi
-	if imageInfo.Has2x {
-		fileName2x := imageInfo.Name + "@2x" + imageInfo.Extension
-		if !imageInfo.Has1x {
+	if has2x {
+		if !has1x {
 			// 如果没有1x，2x也用于mdpi
 			mapping[androidDensities[0]] = fileName2x // mdpi
 		}
@@ -107,16 +392,15 @@ func (g *AndroidImageGenerator) getAndroidMapping(imageInfo *ImageInfo) map[Andr
 	}
 	
 	// 如果有3x图片，用于xxhdpi和xxxhdpi
-	if imageInfo.Has3x {
-		fileName3x := imageInfo.Name + "@3x" + imageInfo.Extension
+	if has3x {
 		mapping[androidDensities[3]] = fileName3x // xxhdpi
 		mapping[androidDensities[4]] = fileName3x // xxxhdpi
 		
 		// 如果没有2x，3x也用于xhdpi
-		if !imageInfo.Has2x {
+		if !has2x {
 			mapping[androidDensities[2]] = fileName3x // xhdpi
 			// 如果连1x都没有，3x用于所有密度
-			if !imageInfo.Has1x {
+			if !has1x {
 				mapping[androidDensities[0]] = fileName3x // mdpi
 				mapping[androidDensities[1]] = fileName3x // hdpi
 			}
@@ -124,8 +408,7 @@ func (g *AndroidImageGenerator) getAndroidMapping(imageInfo *ImageInfo) map[Andr
 	}
 	
 	// 如果只有1x图片，用于所有密度
-	if imageInfo.Has1x && !imageInfo.Has2x && !imageInfo.Has3x {
-		fileName1x := imageInfo.Name + imageInfo.Extension
+	if has1x && !has2x && !has3x {
 		for i := range androidDensities {
 			mapping[androidDensities[i]] = fileName1x
 		}
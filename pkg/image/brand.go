@@ -0,0 +1,160 @@
+package image
+
+import (
+	"app-assets-generator/pkg/brand"
+	"app-assets-generator/pkg/xcassets"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Brand 品牌（白标）覆盖的图片
+type Brand struct {
+	Name   string                // 品牌名称，即品牌目录下的子目录名
+	Images map[string]*ImageInfo // 覆盖的图片，名称必须在基础图片中存在
+}
+
+// overriddenImages 获取任一品牌覆盖的图片名称
+func overriddenImages(brands []Brand) map[string]bool {
+	overridden := make(map[string]bool)
+	for _, b := range brands {
+		for name := range b.Images {
+			overridden[name] = true
+		}
+	}
+	return overridden
+}
+
+// scanBrands 扫描品牌目录，每个子目录为一个品牌
+func (g *Generator) scanBrands(base map[string]*ImageInfo) ([]Brand, error) {
+	if g.options.BrandsDir == "" {
+		return nil, nil
+	}
+
+	entries, err := os.ReadDir(g.options.BrandsDir)
+	if err != nil {
+		return nil, fmt.Errorf("读取品牌目录失败: %w", err)
+	}
+
+	var brands []Brand
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		if err := brand.ValidateName(name); err != nil {
+			return nil, err
+		}
+
+		images, err := scanImagesIn(filepath.Join(g.options.BrandsDir, name), "", g.options.DarkSuffix)
+		if err != nil {
+			return nil, fmt.Errorf("扫描品牌 %s 的图片失败: %w", name, err)
+		}
+		for imageName, info := range images {
+			baseInfo, ok := base[imageName]
+			if !ok {
+				return nil, fmt.Errorf("品牌 %s 覆盖的图片 %s 未在基础图片中定义", name, imageName)
+			}
+			info.Options = baseInfo.Options // 图片设置文件中的设置同样适用于品牌图片
+		}
+		brands = append(brands, Brand{Name: name, Images: images})
+	}
+
+	return brands, nil
+}
+
+// Brands 获取品牌目录中的品牌名称
+func (g *Generator) Brands() ([]string, error) {
+	images, err := g.scanImages()
+	if err != nil {
+		return nil, fmt.Errorf("扫描图片失败: %w", err)
+	}
+	brands, err := g.scanBrands(images)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(brands))
+	for _, b := range brands {
+		names = append(names, b.Name)
+	}
+	return names, nil
+}
+
+// generateBrandIOS 生成品牌的iOS资源目录，品牌未覆盖的图片使用基础图片
+func (g *Generator) generateBrandIOS(b Brand, base map[string]*ImageInfo, overridden map[string]bool) error {
+	catalogPath := g.BrandCatalogPath(b.Name)
+	if err := xcassets.EnsureCatalog(catalogPath); err != nil {
+		return err
+	}
+	outputPath, err := xcassets.EnsureFolder(catalogPath, g.options.Folder)
+	if err != nil {
+		return err
+	}
+
+	images := make(map[string]*ImageInfo, len(overridden))
+	for name := range overridden {
+		if info, ok := b.Images[name]; ok {
+			images[name] = info
+		} else {
+			images[name] = base[name]
+		}
+	}
+
+	iosGen := NewIOSImageGenerator(g.inputPath, outputPath)
+	iosGen.merge = true
+	g.configureIOS(iosGen)
+	err = iosGen.Generate(images)
+	for _, warning := range iosGen.warnings {
+		g.warnings = append(g.warnings, fmt.Sprintf("品牌 %s: %s", b.Name, warning))
+	}
+	return err
+}
+
+// BrandCatalogPath 获取品牌的iOS资源目录，指定了--catalog时与其位于同一目录，否则位于输出目录下
+func (g *Generator) BrandCatalogPath(name string) string {
+	return brand.CatalogPath(g.outputPath, g.options.CatalogPath, name)
+}
+
+// AndroidResPath 获取Android资源目录，配置了品牌目录时为 <输出目录>/src/<源码集>/res
+func (g *Generator) AndroidResPath(sourceSet string) string {
+	if g.options.BrandsDir == "" {
+		return g.outputPath
+	}
+	return brand.AndroidResPath(g.outputPath, sourceSet)
+}
+
+// checkBrandCoverage 检查品牌图片是否覆盖了基础图片的所有drawable目录
+// 缺少的密度或平板目录会回退到main源码集中的基础图片，通常不是期望的结果
+func (g *Generator) checkBrandCoverage(b Brand, base map[string]*ImageInfo, androidGen *AndroidImageGenerator) {
+	names := make([]string, 0, len(b.Images))
+	for name := range b.Images {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		covered := androidGen.outputDirectories(b.Images[name])
+		var missing []string
+		for directory := range androidGen.outputDirectories(base[name]) {
+			if !covered[directory] {
+				missing = append(missing, directory)
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+		sort.Strings(missing)
+		g.warnings = append(g.warnings, fmt.Sprintf("品牌 %s 的图片 %s 缺少 %s，这些目录将使用main源码集中的基础图片", b.Name, name, strings.Join(missing, ", ")))
+	}
+}
+
+// sourceDir 获取图片文件所在目录，未记录时使用输入目录
+func sourceDir(info *ImageInfo, inputPath string) string {
+	if info.SourceDir != "" {
+		return info.SourceDir
+	}
+	return inputPath
+}
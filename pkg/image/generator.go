@@ -1,6 +1,9 @@
 package image
 
 import (
+	"app-assets-generator/internal/diag"
+	"app-assets-generator/pkg/brand"
+	"app-assets-generator/pkg/xcassets"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -11,16 +14,54 @@ import (
 type Generator struct {
 	inputPath  string
 	outputPath string
+	options    Options
+	warnings   []string // 生成过程中的警告
+	
+	namesChecked bool // 是否已经检查过选项中指定的图片名称
+	
+	manifest       map[string]ImageOptions // 图片设置文件中的设置
+	manifestLoaded bool                    // 是否已经读取过图片设置文件
+}
+
+// Options 生成选项
+type Options struct {
+	CatalogPath string // 已有的Assets.xcassets路径，设置后iOS资源合并写入该目录
+	Folder      string // 资源目录内的子文件夹，如 Icons
+	
+	BrandsDir string // 品牌图片目录，每个子目录为一个品牌，包含覆盖的同名图片
+	
+	// RasterizeVectors 单倍数的SVG/PDF不保留矢量数据，由Xcode在编译时生成各倍数的位图
+	RasterizeVectors bool
+	
+	// PDF iOS中所有SVG转换为PDF；PDFImages 只转换指定名称的SVG
+	PDF       bool
+	PDFImages []string
+	
+	// PNG 所有SVG渲染为各倍数、各密度的PNG；PNGImages 只渲染指定名称的SVG
+	PNG       bool
+	PNGImages []string
+	PNGSize   Size // 渲染的点尺寸，未指定时使用SVG自身的尺寸
+	
+	DarkSuffix string // 深色变体的文件名后缀，如 _dark（icon_dark.png、icon@2x_dark.png），为空时不识别深色变体
+	
+	// ManifestPath 图片设置文件路径，为空时使用输入目录中的images.yaml（不存在时不使用）
+	ManifestPath string
 }
 
 // NewGenerator 创建新的生成器
-func NewGenerator(inputPath, outputPath string) *Generator {
+func NewGenerator(inputPath, outputPath string, options Options) *Generator {
 	return &Generator{
 		inputPath:  inputPath,
 		outputPath: outputPath,
+		options:    options,
 	}
 }
 
+// Warnings 获取生成过程中的警告
+func (g *Generator) Warnings() []string {
+	return g.warnings
+}
+
 // GenerateIOS 生成iOS图片资源
 func (g *Generator) GenerateIOS() error {
 	// 扫描输入目录的图片
@@ -28,10 +69,100 @@ func (g *Generator) GenerateIOS() error {
 	if err != nil {
 		return fmt.Errorf("扫描图片失败: %w", err)
 	}
+	brands, err := g.scanBrands(images)
+	if err != nil {
+		return err
+	}
+	
+	// 确定iOS输出目录
+	outputPath, err := g.iosOutputPath()
+	if err != nil {
+		return err
+	}
+	
+	// 被任一品牌覆盖的图片不写入共享资源目录，避免与品牌资源目录中的同名图片冲突
+	overridden := overriddenImages(brands)
+	shared := make(map[string]*ImageInfo, len(images))
+	for name, info := range images {
+		if !overridden[name] {
+			shared[name] = info
+		}
+	}
 	
 	// 生成iOS资源
-	iosGen := NewIOSImageGenerator(g.inputPath, g.outputPath)
-	return iosGen.Generate(images)
+	iosGen := NewIOSImageGenerator(g.inputPath, outputPath)
+	iosGen.merge = g.options.CatalogPath != ""
+	g.checkNamedImages(images)
+	g.configureIOS(iosGen)
+	err = iosGen.Generate(shared)
+	g.warnings = append(g.warnings, iosGen.warnings...)
+	if err != nil {
+		return err
+	}
+	
+	// 每个品牌一个资源目录，包含所有被覆盖的图片
+	for _, b := range brands {
+		if err := g.generateBrandIOS(b, images, overridden); err != nil {
+			return fmt.Errorf("生成品牌 %s 的iOS资源失败: %w", b.Name, err)
+		}
+	}
+	
+	return nil
+}
+
+// configureIOS 设置iOS生成器的矢量图选项
+func (g *Generator) configureIOS(iosGen *IOSImageGenerator) {
+	iosGen.preserveVectors = !g.options.RasterizeVectors
+	iosGen.pdfAll = g.options.PDF
+	iosGen.pdfImages = nameSet(g.options.PDFImages)
+	iosGen.png = g.pngRendering()
+}
+
+// pngRendering 获取SVG渲染为PNG的设置
+func (g *Generator) pngRendering() pngRendering {
+	return pngRendering{all: g.options.PNG, images: nameSet(g.options.PNGImages), size: g.options.PNGSize}
+}
+
+// nameSet 将名称列表转换为集合
+func nameSet(names []string) map[string]bool {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[name] = true
+	}
+	return set
+}
+
+// checkNamedImages 检查选项中指定的图片，不存在或不是SVG时给出警告，同时生成两个平台时只检查一次
+func (g *Generator) checkNamedImages(images map[string]*ImageInfo) {
+	if g.namesChecked {
+		return
+	}
+	g.namesChecked = true
+	
+	check := func(names []string, purpose string) {
+		for _, name := range names {
+			if info, ok := images[name]; !ok {
+				g.warnings = append(g.warnings, fmt.Sprintf("需要%s的图片 %s 不存在", purpose, name))
+			} else if info.Extension != ".svg" {
+				g.warnings = append(g.warnings, fmt.Sprintf("图片 %s 不是SVG，不会%s", name, purpose))
+			}
+		}
+	}
+	check(g.options.PDFImages, "转换为PDF")
+	check(g.options.PNGImages, "渲染为PNG")
+}
+
+// iosOutputPath 获取iOS输出目录
+// 指定了资源目录时，确保根Contents.json和子文件夹存在，并写入子文件夹
+func (g *Generator) iosOutputPath() (string, error) {
+	if g.options.CatalogPath == "" {
+		return g.outputPath, nil
+	}
+	
+	if err := xcassets.EnsureCatalog(g.options.CatalogPath); err != nil {
+		return "", err
+	}
+	return xcassets.EnsureFolder(g.options.CatalogPath, g.options.Folder)
 }
 
 // GenerateAndroid 生成Android图片资源
@@ -42,9 +173,36 @@ func (g *Generator) GenerateAndroid() error {
 		return fmt.Errorf("扫描图片失败: %w", err)
 	}
 	
-	// 生成Android资源
-	androidGen := NewAndroidImageGenerator(g.inputPath, g.outputPath)
-	return androidGen.Generate(images)
+	brands, err := g.scanBrands(images)
+	if err != nil {
+		return err
+	}
+	
+	// 生成Android资源，配置了品牌时共享资源写入main源码集
+	g.checkNamedImages(images)
+	androidGen := NewAndroidImageGenerator(g.inputPath, g.AndroidResPath(brand.MainSourceSet))
+	androidGen.png = g.pngRendering()
+	err = androidGen.Generate(images)
+	g.warnings = append(g.warnings, androidGen.warnings...)
+	if err != nil {
+		return err
+	}
+	
+	// 品牌源码集只包含品牌覆盖的图片
+	for _, b := range brands {
+		brandGen := NewAndroidImageGenerator(g.inputPath, g.AndroidResPath(b.Name))
+		brandGen.png = g.pngRendering()
+		g.checkBrandCoverage(b, images, brandGen)
+		err := brandGen.Generate(b.Images)
+		for _, warning := range brandGen.warnings {
+			g.warnings = append(g.warnings, fmt.Sprintf("品牌 %s: %s", b.Name, warning))
+		}
+		if err != nil {
+			return fmt.Errorf("生成品牌 %s 的Android资源失败: %w", b.Name, err)
+		}
+	}
+	
+	return nil
 }
 
 // ImageInfo 图片信息
@@ -52,23 +210,150 @@ type ImageInfo struct {
 	Name      string   // 图片名称（不含扩展名和@2x等后缀）
 	Files     []string // 相关文件列表
 	Extension string   // 文件扩展名
+	SourceDir string   // 图片文件所在目录
 	Has1x     bool     // 是否有1x图片
 	Has2x     bool     // 是否有@2x图片
 	Has3x     bool     // 是否有@3x图片
+	
+	Variants []ImageVariant // 所有变体（设备类型+倍数+外观）
+	Options  ImageOptions   // 图片设置文件中的设置
+	
+	darkSuffix string // 扫描时使用的深色后缀
+	suffix     string // 生成文件名时附加在名称后的后缀，深色变体为深色后缀
+}
+
+// ImageVariant 图片变体
+type ImageVariant struct {
+	FileName   string // 文件名
+	Scale      string // 倍数: 1x/2x/3x
+	Idiom      string // 设备类型: universal/iphone/ipad
+	Appearance string // 外观: 空为默认外观，dark为深色
+}
+
+// darkAppearance 深色外观
+const darkAppearance = "dark"
+
+// Lookup 查找默认外观中指定设备类型和倍数的文件名，不存在时返回空字符串
+func (info *ImageInfo) Lookup(idiom, scale string) string {
+	for _, variant := range info.Variants {
+		if variant.Appearance == "" && variant.Idiom == idiom && variant.Scale == scale {
+			return variant.FileName
+		}
+	}
+	return ""
+}
+
+// LookupAny 查找默认外观中指定设备类型的任意一个文件（矢量图不区分倍数），不存在时返回空字符串
+func (info *ImageInfo) LookupAny(idiom string) string {
+	for _, variant := range info.Variants {
+		if variant.Appearance == "" && variant.Idiom == idiom {
+			return variant.FileName
+		}
+	}
+	return ""
+}
+
+// HasIdiom 判断默认外观中是否有指定设备类型的图片
+func (info *ImageInfo) HasIdiom(idiom string) bool {
+	for _, variant := range info.Variants {
+		if variant.Appearance == "" && variant.Idiom == idiom {
+			return true
+		}
+	}
+	return false
+}
+
+// appearance 获取指定外观的变体组成的图片信息，变体按默认外观处理，深色图片生成的文件名带深色后缀
+// 没有该外观的变体时返回nil
+func (info *ImageInfo) appearance(appearance string) *ImageInfo {
+	view := *info
+	view.Files = nil
+	view.Variants = nil
+	view.Has1x, view.Has2x, view.Has3x = false, false, false
+	if appearance == darkAppearance {
+		view.suffix = info.darkSuffix
+	}
+	for _, variant := range info.Variants {
+		if variant.Appearance != appearance {
+			continue
+		}
+		variant.Appearance = ""
+		view.Files = append(view.Files, variant.FileName)
+		view.Variants = append(view.Variants, variant)
+		if variant.Idiom == "universal" {
+			view.Has1x = view.Has1x || variant.Scale == "1x"
+			view.Has2x = view.Has2x || variant.Scale == "2x"
+			view.Has3x = view.Has3x || variant.Scale == "3x"
+		}
+	}
+	if len(view.Variants) == 0 {
+		return nil
+	}
+	view.Extension = strings.ToLower(filepath.Ext(view.Variants[0].FileName))
+	return &view
 }
 
-// scanImages 扫描图片目录
+// scanImages 扫描图片目录，并应用图片设置文件中的设置
 func (g *Generator) scanImages() (map[string]*ImageInfo, error) {
+	images, err := scanImagesIn(g.inputPath, g.options.BrandsDir, g.options.DarkSuffix)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.loadManifest(images); err != nil {
+		return nil, err
+	}
+	for name, options := range g.manifest {
+		images[name].Options = options
+	}
+	return images, nil
+}
+
+// loadManifest 读取图片设置文件，同时生成两个平台时只读取一次
+func (g *Generator) loadManifest(images map[string]*ImageInfo) error {
+	if g.manifestLoaded {
+		return nil
+	}
+	
+	manifestPath := g.options.ManifestPath
+	if manifestPath == "" {
+		manifestPath = filepath.Join(g.inputPath, ManifestFileName)
+		if _, err := os.Stat(manifestPath); os.IsNotExist(err) {
+			g.manifestLoaded = true
+			return nil
+		}
+	}
+	
+	manifest, diagnostics, err := ParseManifest(manifestPath, images)
+	if err != nil {
+		return fmt.Errorf("解析图片设置文件失败: %w", err)
+	}
+	if diagnostics.HasErrors() {
+		return fmt.Errorf("解析图片设置文件失败: %w", &diag.ValidationError{Diagnostics: diagnostics})
+	}
+	for _, warning := range diagnostics.Warnings() {
+		g.warnings = append(g.warnings, fmt.Sprintf("%s:%d:%d: %s", warning.File, warning.Line, warning.Column, warning.Message))
+	}
+	
+	g.manifest = manifest
+	g.manifestLoaded = true
+	return nil
+}
+
+// scanImagesIn 扫描指定目录的图片，skipDir为需要跳过的子目录（如位于输入目录中的品牌目录），darkSuffix为深色变体的后缀
+func scanImagesIn(dirPath, skipDir, darkSuffix string) (map[string]*ImageInfo, error) {
 	images := make(map[string]*ImageInfo)
 	
 	// 遍历目录
-	err := filepath.Walk(g.inputPath, func(path string, info os.FileInfo, err error) error {
+	err := filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
 		
 		// 跳过目录
 		if info.IsDir() {
+			if skipDir != "" && path != dirPath && filepath.Clean(path) == filepath.Clean(skipDir) {
+				return filepath.SkipDir
+			}
 			return nil
 		}
 		
@@ -81,20 +366,39 @@ func (g *Generator) scanImages() (map[string]*ImageInfo, error) {
 			return nil
 		}
 		
-		// 解析图片名称和倍数
-		baseName, scale := parseImageName(fileName)
+		// 解析图片名称、倍数、设备类型和外观
+		baseName, scale, idiom, dark := parseImageName(fileName, darkSuffix)
 		
-		// 获取或创建ImageInfo
-		if _, exists := images[baseName]; !exists {
+		// 获取或创建ImageInfo，同一图片的所有变体必须在同一目录中（生成时按SourceDir读取文件）
+		if existing, exists := images[baseName]; exists && existing.SourceDir != filepath.Dir(path) {
+			return fmt.Errorf("图片 %s 的文件分布在多个目录中: %s 和 %s，同一图片的所有变体必须放在同一目录",
+				baseName, filepath.Join(existing.SourceDir, existing.Files[0]), path)
+		} else if !exists {
 			images[baseName] = &ImageInfo{
-				Name:      baseName,
-				Extension: ext,
-				Files:     []string{},
+				Name:       baseName,
+				Extension:  ext,
+				SourceDir:  filepath.Dir(path),
+				Files:      []string{},
+				darkSuffix: darkSuffix,
 			}
 		}
 		
 		imageInfo := images[baseName]
 		imageInfo.Files = append(imageInfo.Files, fileName)
+		variant := ImageVariant{
+			FileName: fileName,
+			Scale:    scale,
+			Idiom:    idiom,
+		}
+		if dark {
+			variant.Appearance = darkAppearance
+		}
+		imageInfo.Variants = append(imageInfo.Variants, variant)
+		
+		// 深色图片和设备专属图片不影响通用倍数标记
+		if dark || idiom != "universal" {
+			return nil
+		}
 		
 		// 标记倍数
 		switch scale {
@@ -113,24 +417,73 @@ func (g *Generator) scanImages() (map[string]*ImageInfo, error) {
 		return nil, err
 	}
 	
+	// 深色图片必须有对应的默认外观图片，扩展名以默认外观为准
+	for _, imageInfo := range images {
+		if err := checkDarkVariants(imageInfo); err != nil {
+			return nil, err
+		}
+	}
+	
 	return images, nil
 }
 
-// parseImageName 解析图片名称，返回基础名称和倍数
-func parseImageName(fileName string) (baseName string, scale string) {
+// checkDarkVariants 检查深色变体的每个设备类型都有默认外观的图片，并使用默认外观的扩展名
+func checkDarkVariants(imageInfo *ImageInfo) error {
+	for _, variant := range imageInfo.Variants {
+		if variant.Appearance == "" {
+			imageInfo.Extension = strings.ToLower(filepath.Ext(variant.FileName))
+			break
+		}
+	}
+	for _, variant := range imageInfo.Variants {
+		if variant.Appearance == darkAppearance && !imageInfo.HasIdiom(variant.Idiom) {
+			return fmt.Errorf("深色图片 %s 没有对应的默认外观图片", filepath.Join(imageInfo.SourceDir, variant.FileName))
+		}
+	}
+	return nil
+}
+
+// imageIdioms 文件名中支持的设备类型后缀
+var imageIdioms = []string{"iphone", "ipad"}
+
+// parseImageName 解析图片名称，返回基础名称、倍数、设备类型和是否为深色变体
+// 后缀的顺序不固定，支持 icon@2x~ipad.png、icon~ipad@2x.png、icon_dark@2x.png、icon@2x_dark.png 等写法
+func parseImageName(fileName, darkSuffix string) (baseName string, scale string, idiom string, dark bool) {
 	// 去除扩展名
-	nameWithoutExt := strings.TrimSuffix(fileName, filepath.Ext(fileName))
+	baseName = strings.TrimSuffix(fileName, filepath.Ext(fileName))
+	scale, idiom = "1x", "universal"
 	
-	// 检查@2x、@3x后缀
-	if strings.HasSuffix(nameWithoutExt, "@3x") {
-		return strings.TrimSuffix(nameWithoutExt, "@3x"), "3x"
-	}
-	if strings.HasSuffix(nameWithoutExt, "@2x") {
-		return strings.TrimSuffix(nameWithoutExt, "@2x"), "2x"
+	// 每种后缀最多去除一次，直到没有可识别的后缀
+	for stripped := true; stripped; {
+		stripped = false
+		
+		// 检查~iphone、~ipad后缀
+		if idiom == "universal" {
+			for _, candidate := range imageIdioms {
+				if strings.HasSuffix(baseName, "~"+candidate) {
+					baseName, idiom, stripped = strings.TrimSuffix(baseName, "~"+candidate), candidate, true
+					break
+				}
+			}
+		}
+		
+		// 检查@2x、@3x后缀
+		if scale == "1x" {
+			for _, candidate := range []string{"2x", "3x"} {
+				if strings.HasSuffix(baseName, "@"+candidate) {
+					baseName, scale, stripped = strings.TrimSuffix(baseName, "@"+candidate), candidate, true
+					break
+				}
+			}
+		}
+		
+		// 检查深色后缀
+		if !dark && darkSuffix != "" && strings.HasSuffix(baseName, darkSuffix) {
+			baseName, dark, stripped = strings.TrimSuffix(baseName, darkSuffix), true, true
+		}
 	}
 	
-	// 默认为1x
-	return nameWithoutExt, "1x"
+	return baseName, scale, idiom, dark
 }
 
 // isSupportedImageFormat 检查是否为支持的图片格式
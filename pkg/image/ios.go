@@ -1,37 +1,72 @@
 package image
 
 import (
-	"encoding/json"
+	"app-assets-generator/pkg/svg"
+	"app-assets-generator/pkg/xcassets"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 )
 
 // IOSImageGenerator iOS图片资源生成器
 type IOSImageGenerator struct {
 	inputPath  string
 	outputPath string
+	merge      bool // 是否与已有的Contents.json合并
+	
+	preserveVectors bool // 单倍数矢量图是否保留矢量数据（preserves-vector-representation）
+	
+	pdfAll    bool            // 是否将所有SVG转换为PDF
+	pdfImages map[string]bool // 需要转换为PDF的SVG图片名称
+	png       pngRendering    // 需要渲染为PNG的SVG
+	
+	warnings []string // 需要放大而跳过的倍数
 }
 
 // NewIOSImageGenerator 创建iOS图片生成器
 func NewIOSImageGenerator(inputPath, outputPath string) *IOSImageGenerator {
 	return &IOSImageGenerator{
-		inputPath:  inputPath,
-		outputPath: outputPath,
+		inputPath:       inputPath,
+		outputPath:      outputPath,
+		preserveVectors: true,
 	}
 }
 
 // iOSImageSet iOS图片集结构
 type iOSImageSet struct {
-	Images []iOSImage `json:"images"`
-	Info   iOSInfo    `json:"info"`
+	Images     []iOSImage     `json:"images"`
+	Info       iOSInfo        `json:"info"`
+	Properties *iOSProperties `json:"properties,omitempty"`
 }
 
 // iOSImage iOS图片定义
 type iOSImage struct {
+	Appearances []iOSAppearance `json:"appearances,omitempty"` // 为空时为默认外观
+	
 	Filename string `json:"filename,omitempty"`
 	Idiom    string `json:"idiom"`
-	Scale    string `json:"scale"`
+	Scale    string `json:"scale,omitempty"` // 单倍数矢量图不指定倍数
+}
+
+// iOSAppearance 图片条目的外观
+type iOSAppearance struct {
+	Appearance string `json:"appearance"`
+	Value      string `json:"value"`
+}
+
+// darkAppearances 深色图片条目的外观
+var darkAppearances = []iOSAppearance{{Appearance: "luminosity", Value: darkAppearance}}
+
+// iOSProperties 图片集属性
+type iOSProperties struct {
+	CompressionType string `json:"compression-type,omitempty"` // 压缩类型，如 lossless、gpu-optimized-best
+	
+	// PreservesVectorRepresentation 保留矢量数据，运行时按实际尺寸渲染；为false时Xcode在编译时生成各倍数的位图
+	PreservesVectorRepresentation *bool `json:"preserves-vector-representation,omitempty"`
+	
+	TemplateRenderingIntent string `json:"template-rendering-intent,omitempty"` // 渲染方式: template/original
 }
 
 // iOSInfo iOS信息
@@ -49,6 +84,9 @@ func (g *IOSImageGenerator) Generate(images map[string]*ImageInfo) error {
 	
 	// 为每个图片生成imageset
 	for _, imageInfo := range images {
+		if !imageInfo.Options.includes("ios") {
+			continue
+		}
 		if err := g.generateImageSet(g.outputPath, imageInfo); err != nil {
 			return fmt.Errorf("生成图片 %s 失败: %w", imageInfo.Name, err)
 		}
@@ -60,43 +98,97 @@ func (g *IOSImageGenerator) Generate(images map[string]*ImageInfo) error {
 // generateImageSet 生成单个图片集
 func (g *IOSImageGenerator) generateImageSet(outputPath string, imageInfo *ImageInfo) error {
 	// 创建imageset目录
-	imagesetPath := filepath.Join(outputPath, imageInfo.Name+".imageset")
+	imagesetPath := filepath.Join(outputPath, imageInfo.OutputName()+".imageset")
 	if err := os.MkdirAll(imagesetPath, 0755); err != nil {
 		return fmt.Errorf("创建imageset目录失败: %w", err)
 	}
 	
-	// 构建图片集数据
-	imageSet := g.buildImageSet(imageInfo)
+	// 直接使用的SVG由Xcode按自身的尺寸处理
+	if imageInfo.Extension == ".svg" && !imageInfo.Options.Size.IsZero() && !g.png.applies(imageInfo) && !g.convertsToPDF(imageInfo) {
+		g.warnings = append(g.warnings, fmt.Sprintf("图片 %s 设置的size不适用于直接使用的SVG（Xcode使用SVG自身的尺寸），可以使用 --pdf-image 或 --png-image", imageInfo.Name))
+	}
+	
+	// Xcode中没有槽位的文件（如@3x~ipad）不会被引用，忽略并给出提示
+	imageInfo, ignored := withoutUnslotted(imageInfo)
+	for _, variant := range ignored {
+		g.warnings = append(g.warnings, fmt.Sprintf("图片 %s 的文件 %s 没有对应的Xcode槽位（%s只有 %s），已忽略",
+			imageInfo.Name, variant.FileName, variant.Idiom, strings.Join(idiomScales[variant.Idiom], "/")))
+	}
+	
+	// 默认外观的图片
+	imageSet, err := g.writeAppearance(imagesetPath, imageInfo.appearance(""))
+	if err != nil {
+		return err
+	}
+	
+	// 深色图片写入同一个图片集，条目标记为深色外观
+	if dark := imageInfo.appearance(darkAppearance); dark != nil {
+		darkSet, err := g.writeAppearance(imagesetPath, dark)
+		if err != nil {
+			return err
+		}
+		for _, image := range darkSet.Images {
+			image.Appearances = darkAppearances
+			imageSet.Images = append(imageSet.Images, image)
+		}
+	}
+	
+	// 生成Contents.json
+	contentsPath := filepath.Join(imagesetPath, "Contents.json")
+	if err := xcassets.WriteContents(contentsPath, imageSet, g.merge); err != nil {
+		return err
+	}
+	
+	// 删除之前生成、但槽位本次为空的图片文件
+	return xcassets.RemoveUnreferenced(imagesetPath)
+}
+
+// writeAppearance 写入一种外观的图片文件，返回对应的图片集数据
+func (g *IOSImageGenerator) writeAppearance(imagesetPath string, imageInfo *ImageInfo) (iOSImageSet, error) {
+	// 渲染为PNG的SVG，图片集中只包含渲染结果
+	if g.png.applies(imageInfo) {
+		slots, err := g.renderScales(imagesetPath, imageInfo)
+		if err != nil {
+			return iOSImageSet{}, err
+		}
+		return g.buildImageSet(slots), nil
+	}
+	
+	// 构建图片集数据，转换为PDF的图片使用转换后的文件名
+	if g.convertsToPDF(imageInfo) {
+		for _, fileName := range imageInfo.Files {
+			src := filepath.Join(sourceDir(imageInfo, g.inputPath), fileName)
+			dst := filepath.Join(imagesetPath, g.getIOSFileName(imageInfo, fileName))
+			if err := convertToPDF(src, dst, imageInfo.Options.Size); err != nil {
+				return iOSImageSet{}, err
+			}
+		}
+		return g.buildImageSet(g.outputImageInfo(imageInfo)), nil
+	}
+	
+	// PNG/JPEG缺少的倍数从最大的源图缩小生成
+	slots := g.outputImageInfo(imageInfo)
+	if isResampleable(imageInfo) {
+		var err error
+		if slots, err = g.resampleMissingScales(imagesetPath, imageInfo); err != nil {
+			return iOSImageSet{}, err
+		}
+	}
 	
 	// 复制图片文件
 	for _, fileName := range imageInfo.Files {
-		src := filepath.Join(g.inputPath, fileName)
+		src := filepath.Join(sourceDir(imageInfo, g.inputPath), fileName)
 		
 		// 确定目标文件名
 		dstFileName := g.getIOSFileName(imageInfo, fileName)
 		dst := filepath.Join(imagesetPath, dstFileName)
 		
 		if err := copyFile(src, dst); err != nil {
-			return fmt.Errorf("复制图片文件失败: %w", err)
+			return iOSImageSet{}, fmt.Errorf("复制图片文件失败: %w", err)
 		}
 	}
 	
-	// 生成Contents.json
-	contentsPath := filepath.Join(imagesetPath, "Contents.json")
-	file, err := os.Create(contentsPath)
-	if err != nil {
-		return fmt.Errorf("创建Contents.json失败: %w", err)
-	}
-	defer file.Close()
-	
-	// 写入JSON（格式化输出）
-	encoder := json.NewEncoder(file)
-	encoder.SetIndent("", "  ")
-	if err := encoder.Encode(imageSet); err != nil {
-		return fmt.Errorf("写入JSON失败: %w", err)
-	}
-	
-	return nil
+	return g.buildImageSet(slots), nil
 }
 
 // buildImageSet 构建iOS图片集数据
@@ -109,59 +201,234 @@ func (g *IOSImageGenerator) buildImageSet(imageInfo *ImageInfo) iOSImageSet {
 		},
 	}
 	
-	// 添加1x图片
-	if imageInfo.Has1x {
-		fileName := imageInfo.Name + imageInfo.Extension
-		imageSet.Images = append(imageSet.Images, iOSImage{
-			Filename: fileName,
-			Idiom:    "universal",
-			Scale:    "1x",
-		})
-	} else {
-		// 如果没有1x图片，添加空占位
-		imageSet.Images = append(imageSet.Images, iOSImage{
-			Idiom: "universal",
-			Scale: "1x",
-		})
+	imageSet.Properties = g.properties(imageInfo)
+	
+	// 单倍数矢量图每个设备类型只有一个条目
+	if isSingleScaleVector(imageInfo) {
+		imageSet.Images = append(imageSet.Images, iOSImage{Filename: imageInfo.Lookup("universal", "1x"), Idiom: "universal"})
+		for _, idiom := range imageIdioms {
+			if imageInfo.HasIdiom(idiom) {
+				imageSet.Images = append(imageSet.Images, iOSImage{Filename: imageInfo.Lookup(idiom, "1x"), Idiom: idiom})
+			}
+		}
+		return imageSet
 	}
 	
-	// 添加2x图片
-	if imageInfo.Has2x {
-		fileName := imageInfo.Name + "@2x" + imageInfo.Extension
-		imageSet.Images = append(imageSet.Images, iOSImage{
-			Filename: fileName,
-			Idiom:    "universal",
-			Scale:    "2x",
-		})
-	} else {
-		// 如果没有2x图片，添加空占位
-		imageSet.Images = append(imageSet.Images, iOSImage{
-			Idiom: "universal",
-			Scale: "2x",
-		})
+	// 通用图片（始终保留1x/2x/3x槽位）
+	g.appendScaleSlots(&imageSet, imageInfo, "universal")
+	
+	// 设备专属图片
+	for _, idiom := range imageIdioms {
+		if imageInfo.HasIdiom(idiom) {
+			g.appendScaleSlots(&imageSet, imageInfo, idiom)
+		}
 	}
 	
-	// 添加3x图片
-	if imageInfo.Has3x {
-		fileName := imageInfo.Name + "@3x" + imageInfo.Extension
-		imageSet.Images = append(imageSet.Images, iOSImage{
-			Filename: fileName,
-			Idiom:    "universal",
-			Scale:    "3x",
-		})
-	} else {
-		// 如果没有3x图片，添加空占位
+	return imageSet
+}
+
+// properties 获取图片集属性，没有需要写入的属性时返回nil
+// 单倍数矢量图显式写入是否保留矢量数据，以覆盖已有Contents.json中的设置；图片设置文件中的设置优先
+func (g *IOSImageGenerator) properties(imageInfo *ImageInfo) *iOSProperties {
+	properties := iOSProperties{
+		CompressionType:         imageInfo.Options.Compression,
+		TemplateRenderingIntent: imageInfo.Options.RenderingIntent,
+	}
+	vector := imageInfo.Extension == ".svg" || imageInfo.Extension == ".pdf"
+	if preserve := imageInfo.Options.PreserveVector; preserve != nil && vector {
+		properties.PreservesVectorRepresentation = preserve
+	} else if isSingleScaleVector(imageInfo) {
+		preserve := g.preserveVectors
+		properties.PreservesVectorRepresentation = &preserve
+	}
+	
+	if properties == (iOSProperties{}) {
+		return nil
+	}
+	return &properties
+}
+
+// resampleMissingScales 为每个设备类型生成缺少的倍数，返回包含生成文件的图片信息
+// 比最大源图倍数更高的槽位需要放大，保持为空并给出警告
+func (g *IOSImageGenerator) resampleMissingScales(imagesetPath string, imageInfo *ImageInfo) (*ImageInfo, error) {
+	result := *g.outputImageInfo(imageInfo)
+	
+	for _, idiom := range append([]string{"universal"}, imageIdioms...) {
+		source, ok := largestSource(imageInfo, g.inputPath, idiom)
+		if !ok {
+			continue
+		}
+		
+		var skipped []string
+		for _, scale := range idiomScales[idiom] {
+			if imageInfo.Lookup(idiom, scale) != "" {
+				continue
+			}
+			if scaleFactor(scale) > source.scale {
+				skipped = append(skipped, scale)
+				continue
+			}
+			
+			fileName := imageInfo.OutputName() + imageInfo.suffix + scaleSuffix(scale) + idiomSuffix(idiom) + imageInfo.Extension
+			if err := source.resampleTo(filepath.Join(imagesetPath, fileName), scaleFactor(scale)); err != nil {
+				return nil, err
+			}
+			result.Variants = append(result.Variants, ImageVariant{FileName: fileName, Scale: scale, Idiom: idiom})
+		}
+		
+		if len(skipped) > 0 {
+			g.warnings = append(g.warnings, fmt.Sprintf("图片 %s 最大的源图为 %s，%s 需要放大，已留空（运行时由系统从较低倍数缩放）",
+				filepath.Base(source.path), formatScale(source.scale), strings.Join(skipped, ", ")))
+		}
+	}
+	return &result, nil
+}
+
+// renderScales 将每个设备类型的SVG渲染为该设备类型所有倍数的PNG，返回渲染结果的图片信息
+func (g *IOSImageGenerator) renderScales(imagesetPath string, imageInfo *ImageInfo) (*ImageInfo, error) {
+	result := *imageInfo
+	result.Extension = ".png"
+	result.Files = nil
+	result.Variants = nil
+	
+	for _, idiom := range append([]string{"universal"}, imageIdioms...) {
+		fileName := imageInfo.LookupAny(idiom)
+		if fileName == "" {
+			continue
+		}
+		doc, warnings, err := g.png.read(filepath.Join(sourceDir(imageInfo, g.inputPath), fileName), imageInfo)
+		if err != nil {
+			return nil, err
+		}
+		g.warnings = append(g.warnings, warnings...)
+		
+		for _, scale := range idiomScales[idiom] {
+			pngName := imageInfo.OutputName() + imageInfo.suffix + scaleSuffix(scale) + idiomSuffix(idiom) + ".png"
+			if err := g.png.render(doc, filepath.Join(imagesetPath, pngName), scaleFactor(scale)); err != nil {
+				return nil, err
+			}
+			result.Files = append(result.Files, pngName)
+			result.Variants = append(result.Variants, ImageVariant{FileName: pngName, Scale: scale, Idiom: idiom})
+		}
+	}
+	return &result, nil
+}
+
+// idiomSuffix 获取iOS文件名的设备类型后缀，通用图片没有后缀
+func idiomSuffix(idiom string) string {
+	if idiom == "universal" {
+		return ""
+	}
+	return "~" + idiom
+}
+
+// isSingleScaleVector 判断是否为单倍数矢量图：SVG或PDF，且没有@2x、@3x文件
+// 提供了多个倍数的矢量文件时仍按倍数槽位处理
+func isSingleScaleVector(imageInfo *ImageInfo) bool {
+	if imageInfo.Extension != ".svg" && imageInfo.Extension != ".pdf" {
+		return false
+	}
+	for _, variant := range imageInfo.Variants {
+		if variant.Scale != "1x" {
+			return false
+		}
+	}
+	return true
+}
+
+// idiomScales 各设备类型在Xcode中的倍数槽位
+var idiomScales = map[string][]string{
+	"universal": {"1x", "2x", "3x"},
+	"iphone":    {"1x", "2x", "3x"},
+	"ipad":      {"1x", "2x"},
+}
+
+// withoutUnslotted 去除在Xcode中没有对应倍数槽位的变体，返回去除后的图片信息和被去除的变体
+func withoutUnslotted(imageInfo *ImageInfo) (*ImageInfo, []ImageVariant) {
+	result := *imageInfo
+	result.Files, result.Variants = nil, nil
+	var ignored []ImageVariant
+	for _, variant := range imageInfo.Variants {
+		if !slices.Contains(idiomScales[variant.Idiom], variant.Scale) {
+			ignored = append(ignored, variant)
+			continue
+		}
+		result.Files = append(result.Files, variant.FileName)
+		result.Variants = append(result.Variants, variant)
+	}
+	return &result, ignored
+}
+
+// appendScaleSlots 为指定设备类型添加各倍数的图片条目
+func (g *IOSImageGenerator) appendScaleSlots(imageSet *iOSImageSet, imageInfo *ImageInfo, idiom string) {
+	for _, scale := range idiomScales[idiom] {
+		// 没有对应倍数的图片时添加空占位
 		imageSet.Images = append(imageSet.Images, iOSImage{
-			Idiom: "universal",
-			Scale: "3x",
+			Filename: imageInfo.Lookup(idiom, scale),
+			Idiom:    idiom,
+			Scale:    scale,
 		})
 	}
-	
-	return imageSet
 }
 
 // getIOSFileName 获取iOS的文件名
 func (g *IOSImageGenerator) getIOSFileName(imageInfo *ImageInfo, originalFileName string) string {
+	// 重命名的图片替换名称部分，保留@2x、~ipad、深色等后缀
+	fileName := originalFileName
+	if imageInfo.OutputName() != imageInfo.Name {
+		fileName = imageInfo.OutputName() + strings.TrimPrefix(fileName, imageInfo.Name)
+	}
+	
+	// 转换为PDF的SVG只替换扩展名
+	if g.convertsToPDF(imageInfo) {
+		return pdfFileName(fileName)
+	}
+	
 	// 保持原始文件名，包括@2x、@3x后缀
-	return originalFileName
+	return fileName
+}
+
+// convertsToPDF 判断SVG图片是否需要转换为PDF，同时指定渲染为PNG时以PNG为准
+func (g *IOSImageGenerator) convertsToPDF(imageInfo *ImageInfo) bool {
+	return imageInfo.Extension == ".svg" && (g.pdfAll || g.pdfImages[imageInfo.Name]) && !g.png.applies(imageInfo)
+}
+
+// pdfFileName 将文件名的扩展名替换为.pdf
+func pdfFileName(fileName string) string {
+	return strings.TrimSuffix(fileName, filepath.Ext(fileName)) + ".pdf"
+}
+
+// outputImageInfo 获取写入图片集后的图片信息，文件名与getIOSFileName一致，用于构建图片集
+func (g *IOSImageGenerator) outputImageInfo(imageInfo *ImageInfo) *ImageInfo {
+	output := *imageInfo
+	if g.convertsToPDF(imageInfo) {
+		output.Extension = ".pdf"
+	}
+	output.Files = make([]string, len(imageInfo.Files))
+	for i, fileName := range imageInfo.Files {
+		output.Files[i] = g.getIOSFileName(imageInfo, fileName)
+	}
+	output.Variants = make([]ImageVariant, len(imageInfo.Variants))
+	for i, variant := range imageInfo.Variants {
+		variant.FileName = g.getIOSFileName(imageInfo, variant.FileName)
+		output.Variants[i] = variant
+	}
+	return &output
+}
+
+// convertToPDF 将SVG文件转换为单页PDF，size不为零时作为页面尺寸，包含不支持的特性时返回错误而不是生成不完整的PDF
+func convertToPDF(src, dst string, size Size) error {
+	doc, err := svg.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	resizeDocument(doc, size)
+	data, err := doc.PDF()
+	if err != nil {
+		return fmt.Errorf("%s 无法转换为PDF: %w", filepath.Base(src), err)
+	}
+	if err := os.WriteFile(dst, data, 0644); err != nil {
+		return fmt.Errorf("写入 %s 失败: %w", dst, err)
+	}
+	return nil
 }
\ No newline at end of file
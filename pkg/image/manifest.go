@@ -0,0 +1,321 @@
+package image
+
+import (
+	"app-assets-generator/internal/diag"
+	"app-assets-generator/internal/yamlutil"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestFileName 输入目录中图片设置文件的默认名称
+const ManifestFileName = "images.yaml"
+
+// ImageOptions images.yaml中单个图片的设置，零值表示使用默认行为
+type ImageOptions struct {
+	RenderingIntent string   // iOS渲染方式: template/original，为空时由Xcode决定
+	PreserveVector  *bool    // iOS是否保留矢量数据，为nil时使用 --no-preserve-vector 的设置
+	Compression     string   // iOS压缩类型，如 lossless、gpu-optimized-best
+	Size            Size     // SVG的目标点尺寸，覆盖 --png-size 和SVG自身的尺寸
+	AndroidType     string   // Android资源类型: drawable/mipmap，为空时为drawable
+	Platforms       []string // 生成的平台，为空时生成所有平台
+	Rename          string   // 输出的资源名称，为空时使用图片名称
+}
+
+// includes 判断是否需要生成指定平台的资源
+func (o ImageOptions) includes(platform string) bool {
+	return len(o.Platforms) == 0 || slices.Contains(o.Platforms, platform)
+}
+
+// resourceType 获取Android资源类型
+func (o ImageOptions) resourceType() string {
+	if o.AndroidType == "" {
+		return "drawable"
+	}
+	return o.AndroidType
+}
+
+// OutputName 获取输出的资源名称：设置了rename时使用新名称
+func (info *ImageInfo) OutputName() string {
+	if info.Options.Rename != "" {
+		return info.Options.Rename
+	}
+	return info.Name
+}
+
+// manifestKeys 图片设置中允许的字段
+var manifestKeys = []string{"rendering_intent", "preserve_vector", "compression", "size", "android_type", "platforms", "rename"}
+
+// renderingIntents iOS图片集的渲染方式
+var renderingIntents = []string{"template", "original"}
+
+// compressionTypes iOS图片集的压缩类型
+var compressionTypes = []string{"automatic", "lossless", "lossy", "gpu-optimized-best", "gpu-optimized-smallest"}
+
+// androidTypes 图片可以使用的Android资源类型
+var androidTypes = []string{"drawable", "mipmap"}
+
+// platforms 可以生成的平台
+var platforms = []string{"ios", "android"}
+
+// renamePattern 重命名后的名称，需要同时可以作为imageset名称和Android资源名称
+var renamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+
+// ParseManifest 解析图片设置文件，images为扫描到的图片，每个条目必须对应一个已有的图片
+// 一次性收集所有带位置信息的错误和警告，只有读取文件失败时才返回error
+func ParseManifest(filePath string, images map[string]*ImageInfo) (map[string]ImageOptions, diag.Diagnostics, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("读取文件失败: %w", err)
+	}
+
+	p := &manifestParser{file: filePath, images: images}
+	options := p.parse(data)
+	return options, p.diagnostics, nil
+}
+
+// manifestParser 图片设置文件解析器
+type manifestParser struct {
+	file        string
+	images      map[string]*ImageInfo
+	diagnostics diag.Diagnostics
+}
+
+// errorf 记录节点位置的错误
+func (p *manifestParser) errorf(node *yaml.Node, format string, args ...interface{}) {
+	p.add(diag.SeverityError, node, format, args...)
+}
+
+// warnf 记录节点位置的警告
+func (p *manifestParser) warnf(node *yaml.Node, format string, args ...interface{}) {
+	p.add(diag.SeverityWarning, node, format, args...)
+}
+
+// add 记录问题
+func (p *manifestParser) add(severity diag.Severity, node *yaml.Node, format string, args ...interface{}) {
+	diagnostic := diag.Diagnostic{File: p.file, Severity: severity, Message: fmt.Sprintf(format, args...)}
+	if node != nil {
+		diagnostic.Line, diagnostic.Column = node.Line, node.Column
+	}
+	p.diagnostics = append(p.diagnostics, diagnostic)
+}
+
+// parse 解析所有图片的设置
+func (p *manifestParser) parse(data []byte) map[string]ImageOptions {
+	options := make(map[string]ImageOptions)
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		p.diagnostics = append(p.diagnostics, diag.Diagnostic{
+			File:     p.file,
+			Line:     yamlutil.ErrorLine(err),
+			Column:   1,
+			Severity: diag.SeverityError,
+			Message:  "解析YAML失败: " + strings.TrimPrefix(err.Error(), "yaml: "),
+		})
+		return options
+	}
+	if len(document.Content) == 0 {
+		return options // 空文件
+	}
+
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		p.errorf(root, "顶层必须是图片名称到图片设置的映射")
+		return options
+	}
+
+	renameNodes := make(map[string]*yaml.Node)
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		keyNode, valueNode := root.Content[i], root.Content[i+1]
+		name := keyNode.Value
+		imageInfo, ok := p.images[name]
+		if !ok {
+			p.errorf(keyNode, "图片 %s 不存在，条目必须对应输入目录中的图片（不含@2x、~ipad等后缀和扩展名）", name)
+			continue
+		}
+		if _, ok := options[name]; ok {
+			p.errorf(keyNode, "图片 %s 重复定义", name)
+			continue
+		}
+
+		imageOptions, renameNode, valid := p.decode(name, imageInfo, valueNode)
+		if !valid {
+			continue
+		}
+		options[name] = imageOptions
+		if renameNode != nil {
+			renameNodes[name] = renameNode
+		}
+	}
+
+	p.checkRenames(options, renameNodes)
+	return options
+}
+
+// decode 解析一个图片的设置，存在错误时valid为false
+func (p *manifestParser) decode(name string, imageInfo *ImageInfo, node *yaml.Node) (options ImageOptions, renameNode *yaml.Node, valid bool) {
+	if node.Kind != yaml.MappingNode {
+		p.errorf(node, "图片 %s 的设置必须是映射", name)
+		return options, nil, false
+	}
+
+	valid = true
+	vector := imageInfo.Extension == ".svg" || imageInfo.Extension == ".pdf"
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if !slices.Contains(manifestKeys, key.Value) {
+			p.errorf(key, "图片 %s 包含未知字段 %s，可用字段: %s", name, key.Value, strings.Join(manifestKeys, ", "))
+			valid = false
+			continue
+		}
+		if key.Value != "platforms" && value.Kind != yaml.ScalarNode {
+			p.errorf(value, "图片 %s 的%s必须是标量值", name, key.Value)
+			valid = false
+			continue
+		}
+
+		switch key.Value {
+		case "rendering_intent":
+			valid = p.oneOf(name, key.Value, value, renderingIntents) && valid
+			options.RenderingIntent = value.Value
+		case "compression":
+			valid = p.oneOf(name, key.Value, value, compressionTypes) && valid
+			options.Compression = value.Value
+		case "android_type":
+			valid = p.oneOf(name, key.Value, value, androidTypes) && valid
+			options.AndroidType = value.Value
+		case "preserve_vector":
+			var preserve bool
+			if err := value.Decode(&preserve); err != nil {
+				p.errorf(value, "图片 %s 的preserve_vector必须是 true 或 false", name)
+				valid = false
+				continue
+			}
+			if !vector {
+				p.errorf(value, "图片 %s 不是SVG或PDF，不能设置preserve_vector", name)
+				valid = false
+				continue
+			}
+			options.PreserveVector = &preserve
+		case "size":
+			size, err := ParseSize(value.Value)
+			if err != nil {
+				p.errorf(value, "图片 %s 的size无效: %s (格式为 24 或 24x32)", name, value.Value)
+				valid = false
+				continue
+			}
+			if imageInfo.Extension != ".svg" {
+				p.errorf(value, "图片 %s 不是SVG，不能设置size", name)
+				valid = false
+				continue
+			}
+			options.Size = size
+		case "platforms":
+			list, ok := p.platforms(name, value)
+			valid = ok && valid
+			options.Platforms = list
+		case "rename":
+			if !renamePattern.MatchString(value.Value) {
+				p.errorf(value, "图片 %s 的rename无效: %s，只能包含字母、数字、下划线和连字符，且必须以字母开头", name, value.Value)
+				valid = false
+				continue
+			}
+			if value.Value == name {
+				p.warnf(value, "图片 %s 的rename与原名称相同", name)
+			}
+			options.Rename = value.Value
+			renameNode = value
+		}
+	}
+
+	// 只生成iOS时Android的设置没有作用，反之亦然
+	if len(options.Platforms) == 1 {
+		if options.Platforms[0] == "ios" && options.AndroidType != "" {
+			p.warnf(node, "图片 %s 只生成iOS资源，android_type不会生效", name)
+		}
+		if options.Platforms[0] == "android" && (options.RenderingIntent != "" || options.Compression != "" || options.PreserveVector != nil) {
+			p.warnf(node, "图片 %s 只生成Android资源，rendering_intent、compression和preserve_vector不会生效", name)
+		}
+	}
+
+	return options, renameNode, valid
+}
+
+// oneOf 检查字段的值是否为可用值之一
+func (p *manifestParser) oneOf(name, key string, value *yaml.Node, allowed []string) bool {
+	if slices.Contains(allowed, value.Value) {
+		return true
+	}
+	p.errorf(value, "图片 %s 的%s无效: %s (必须是 %s)", name, key, value.Value, strings.Join(allowed, "/"))
+	return false
+}
+
+// platforms 解析平台列表，可以是单个平台或平台数组
+func (p *manifestParser) platforms(name string, node *yaml.Node) ([]string, bool) {
+	values := []*yaml.Node{node}
+	if node.Kind == yaml.SequenceNode {
+		values = node.Content
+	} else if node.Kind != yaml.ScalarNode {
+		p.errorf(node, "图片 %s 的platforms必须是平台名称或平台数组", name)
+		return nil, false
+	}
+	if len(values) == 0 {
+		p.errorf(node, "图片 %s 的platforms不能为空，不需要生成的图片请从输入目录中移除", name)
+		return nil, false
+	}
+
+	var list []string
+	valid := true
+	for _, value := range values {
+		if value.Kind != yaml.ScalarNode || !slices.Contains(platforms, value.Value) {
+			p.errorf(value, "图片 %s 的平台无效: %s (必须是 %s)", name, value.Value, strings.Join(platforms, "/"))
+			valid = false
+			continue
+		}
+		if !slices.Contains(list, value.Value) {
+			list = append(list, value.Value)
+		}
+	}
+	return list, valid
+}
+
+// checkRenames 检查重命名后的名称不与其它图片的输出名称冲突
+func (p *manifestParser) checkRenames(options map[string]ImageOptions, renameNodes map[string]*yaml.Node) {
+	outputs := make(map[string][]string)
+	for name := range p.images {
+		output := name
+		if rename := options[name].Rename; rename != "" {
+			output = rename
+		}
+		outputs[output] = append(outputs[output], name)
+	}
+
+	for output, names := range outputs {
+		if len(names) < 2 {
+			continue
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if node := renameNodes[name]; node != nil {
+				p.errorf(node, "图片 %s 重命名为 %s，与图片 %s 的输出名称冲突", name, output, strings.Join(without(names, name), ", "))
+			}
+		}
+	}
+}
+
+// without 获取去除指定值后的列表
+func without(list []string, value string) []string {
+	var result []string
+	for _, item := range list {
+		if item != value {
+			result = append(result, item)
+		}
+	}
+	return result
+}
@@ -0,0 +1,582 @@
+package image
+
+import (
+	"app-assets-generator/pkg/svg"
+	"fmt"
+	"image"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// subsamples 每个像素行的子扫描线数量，决定垂直方向的抗锯齿精度；水平方向按精确覆盖面积计算
+const subsamples = 16
+
+// flattenTolerance 曲线折线化的容差（像素）
+const flattenTolerance = 0.1
+
+// Size 渲染尺寸（点）
+type Size struct {
+	Width, Height float64
+}
+
+// IsZero 判断是否未指定尺寸
+func (s Size) IsZero() bool {
+	return s.Width == 0 && s.Height == 0
+}
+
+// ParseSize 解析尺寸，如 24（最长边为24点，按SVG宽高比计算另一边）或 24x32
+func ParseSize(value string) (Size, error) {
+	parts := strings.Split(strings.ToLower(strings.TrimSpace(value)), "x")
+	if len(parts) > 2 {
+		return Size{}, fmt.Errorf("无效的尺寸: %s (格式为 24 或 24x32)", value)
+	}
+	var numbers []float64
+	for _, part := range parts {
+		n, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil || n <= 0 {
+			return Size{}, fmt.Errorf("无效的尺寸: %s (格式为 24 或 24x32)", value)
+		}
+		numbers = append(numbers, n)
+	}
+	if len(numbers) == 1 {
+		return Size{Width: numbers[0]}, nil
+	}
+	return Size{Width: numbers[0], Height: numbers[1]}, nil
+}
+
+// resolve 获取SVG的渲染尺寸：未指定时使用SVG自身的尺寸，只指定一个数值时作为最长边
+func (s Size) resolve(doc *svg.Document) Size {
+	switch {
+	case s.IsZero():
+		return Size{Width: doc.Width, Height: doc.Height}
+	case s.Height == 0 && doc.Width >= doc.Height:
+		return Size{Width: s.Width, Height: s.Width * doc.Height / doc.Width}
+	case s.Height == 0:
+		return Size{Width: s.Width * doc.Width / doc.Height, Height: s.Width}
+	}
+	return s
+}
+
+// resizeDocument 将SVG的显示尺寸设置为指定的点尺寸，viewBox随之缩放；尺寸为零时保持不变
+func resizeDocument(doc *svg.Document, size Size) {
+	if size.IsZero() {
+		return
+	}
+	resolved := size.resolve(doc)
+	doc.Width, doc.Height = resolved.Width, resolved.Height
+}
+
+// pngRendering SVG渲染为PNG的设置
+type pngRendering struct {
+	all    bool            // 是否渲染所有SVG
+	images map[string]bool // 需要渲染的SVG图片名称
+	size   Size            // 渲染的点尺寸，未指定时使用SVG自身的尺寸
+}
+
+// applies 判断SVG图片是否需要渲染为PNG
+func (r pngRendering) applies(imageInfo *ImageInfo) bool {
+	return imageInfo.Extension == ".svg" && (r.all || r.images[imageInfo.Name])
+}
+
+// read 解析SVG并设置渲染尺寸（图片设置中的size优先于 --png-size），返回带文件名的警告
+func (r pngRendering) read(path string, imageInfo *ImageInfo) (*svg.Document, []string, error) {
+	doc, err := svg.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if size := imageInfo.Options.Size; !size.IsZero() {
+		resizeDocument(doc, size)
+	} else {
+		resizeDocument(doc, r.size)
+	}
+	warnings := make([]string, 0, len(doc.Warnings))
+	for _, warning := range doc.Warnings {
+		warnings = append(warnings, fmt.Sprintf("%s: %s", filepath.Base(path), warning))
+	}
+	return doc, warnings, nil
+}
+
+// render 按倍数渲染read设置好尺寸的SVG并写入PNG文件
+func (r pngRendering) render(doc *svg.Document, dst string, scale float64) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return fmt.Errorf("创建目录 %s 失败: %w", filepath.Dir(dst), err)
+	}
+	return WriteImage(dst, RenderSVG(doc, Size{}, scale))
+}
+
+// RenderSVG 按点尺寸和倍数将SVG渲染为位图，带抗锯齿
+// viewBox按preserveAspectRatio映射到渲染尺寸，不透明度、裁剪和渐变与浏览器的绘制顺序一致
+func RenderSVG(doc *svg.Document, size Size, scale float64) *image.NRGBA {
+	size = size.resolve(doc)
+	width := int(math.Max(1, math.Round(size.Width*scale)))
+	height := int(math.Max(1, math.Round(size.Height*scale)))
+
+	sized := *doc
+	sized.Width, sized.Height = size.Width, size.Height
+	base := svg.Scale(float64(width)/size.Width, float64(height)/size.Height).Multiply(sized.ViewBoxTransform())
+
+	c := &canvas{width: width, height: height, pixels: make([]float64, width*height*4), clips: make(map[*svg.Clip][]float64)}
+	for _, shape := range doc.Shapes {
+		c.drawShape(shape, base)
+	}
+	return c.toImage()
+}
+
+// canvas 预乘alpha的浮点画布
+type canvas struct {
+	width, height int
+	pixels        []float64
+	clips         map[*svg.Clip][]float64 // 裁剪路径的覆盖率缓存
+}
+
+// drawShape 绘制图形的填充和描边
+func (c *canvas) drawShape(shape *svg.Shape, base svg.Matrix) {
+	transform := base.Multiply(shape.Transform)
+	if transform.Determinant() == 0 {
+		return
+	}
+
+	var mask []float64
+	for _, clip := range shape.Clips {
+		mask = multiplyMask(mask, c.clipMask(clip, base))
+	}
+
+	if !shape.Fill.IsNone() {
+		polygons := shape.Path.Transform(transform).Flatten(flattenTolerance)
+		cover := coverage(polygons, shape.EvenOdd, c.width, c.height)
+		c.composite(multiplyMask(cover, mask), shape.Fill, shape.FillAlpha(), transform)
+	}
+	if !shape.Stroke.IsNone() && shape.StrokeWidth > 0 {
+		// 在用户坐标中生成描边轮廓后再变换，非等比缩放时线宽也正确
+		tolerance := flattenTolerance / transform.ScaleFactor()
+		polygons := strokeOutline(shape, tolerance)
+		for i := range polygons {
+			for j, point := range polygons[i].Points {
+				polygons[i].Points[j] = transform.Apply(point)
+			}
+		}
+		cover := coverage(polygons, false, c.width, c.height)
+		c.composite(multiplyMask(cover, mask), shape.Stroke, shape.StrokeAlpha(), transform)
+	}
+}
+
+// clipMask 获取裁剪路径的覆盖率
+func (c *canvas) clipMask(clip *svg.Clip, base svg.Matrix) []float64 {
+	if mask, ok := c.clips[clip]; ok {
+		return mask
+	}
+	polygons := clip.Path.Transform(base.Multiply(clip.Transform)).Flatten(flattenTolerance)
+	mask := coverage(polygons, clip.EvenOdd, c.width, c.height)
+	c.clips[clip] = mask
+	return mask
+}
+
+// multiplyMask 将两个覆盖率相乘，nil表示完全覆盖
+func multiplyMask(a, b []float64) []float64 {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	result := make([]float64, len(a))
+	for i := range a {
+		result[i] = a[i] * b[i]
+	}
+	return result
+}
+
+// composite 按覆盖率将颜色或渐变以source-over方式合成到画布
+func (c *canvas) composite(cover []float64, paint svg.Paint, alpha float64, transform svg.Matrix) {
+	var shade func(x, y int) [4]float64
+	if paint.Color != nil {
+		a := paint.Color.A
+		solid := [4]float64{paint.Color.R * a, paint.Color.G * a, paint.Color.B * a, a}
+		shade = func(int, int) [4]float64 { return solid }
+	} else {
+		inverse, ok := transform.Multiply(paint.Gradient.Transform).Invert()
+		if !ok {
+			return
+		}
+		gradient := paint.Gradient
+		shade = func(x, y int) [4]float64 {
+			p := inverse.Apply(svg.Point{X: float64(x) + 0.5, Y: float64(y) + 0.5})
+			return gradientColor(gradient, gradientOffset(gradient, p))
+		}
+	}
+
+	for y := 0; y < c.height; y++ {
+		for x := 0; x < c.width; x++ {
+			i := y*c.width + x
+			amount := cover[i] * alpha
+			if amount <= 0 {
+				continue
+			}
+			src := shade(x, y)
+			srcAlpha := src[3] * amount
+			if srcAlpha <= 0 {
+				continue
+			}
+			dst := c.pixels[i*4 : i*4+4]
+			for k := 0; k < 4; k++ {
+				dst[k] = src[k]*amount + dst[k]*(1-srcAlpha)
+			}
+		}
+	}
+}
+
+// gradientOffset 计算渐变空间中的点对应的位置（未处理spreadMethod）
+func gradientOffset(g *svg.Gradient, p svg.Point) float64 {
+	if g.Linear {
+		dx, dy := g.X2-g.X1, g.Y2-g.Y1
+		length := dx*dx + dy*dy
+		if length == 0 {
+			return 1
+		}
+		return ((p.X-g.X1)*dx + (p.Y-g.Y1)*dy) / length
+	}
+
+	if g.R <= 0 {
+		return 1
+	}
+	// 焦点在圆外时移到圆内，与浏览器的处理一致
+	fx, fy := g.FX, g.FY
+	if distance := math.Hypot(fx-g.CX, fy-g.CY); distance > g.R*0.999 {
+		ratio := g.R * 0.999 / distance
+		fx, fy = g.CX+(fx-g.CX)*ratio, g.CY+(fy-g.CY)*ratio
+	}
+	// 求 t 使点位于圆心为 f+t(c-f)、半径为 t*r 的圆上
+	dx, dy := g.CX-fx, g.CY-fy
+	qx, qy := p.X-fx, p.Y-fy
+	a := dx*dx + dy*dy - g.R*g.R
+	qd := qx*dx + qy*dy
+	qq := qx*qx + qy*qy
+	if math.Abs(a) < 1e-12 {
+		if qd == 0 {
+			return 0
+		}
+		return qq / (2 * qd)
+	}
+	return (qd - math.Sqrt(math.Max(0, qd*qd-a*qq))) / a
+}
+
+// gradientColor 获取渐变在指定位置的预乘alpha颜色
+func gradientColor(g *svg.Gradient, t float64) [4]float64 {
+	switch g.Spread {
+	case "repeat":
+		t -= math.Floor(t)
+	case "reflect":
+		t = math.Mod(math.Abs(t), 2)
+		if t > 1 {
+			t = 2 - t
+		}
+	}
+	t = math.Max(0, math.Min(1, t))
+
+	premultiplied := func(c svg.Color) [4]float64 {
+		return [4]float64{c.R * c.A, c.G * c.A, c.B * c.A, c.A}
+	}
+	stops := g.Stops
+	if t <= stops[0].Offset {
+		return premultiplied(stops[0].Color)
+	}
+	for i := 1; i < len(stops); i++ {
+		if t <= stops[i].Offset {
+			from, to := premultiplied(stops[i-1].Color), premultiplied(stops[i].Color)
+			span := stops[i].Offset - stops[i-1].Offset
+			if span <= 0 {
+				return to
+			}
+			k := (t - stops[i-1].Offset) / span
+			return [4]float64{
+				from[0] + (to[0]-from[0])*k,
+				from[1] + (to[1]-from[1])*k,
+				from[2] + (to[2]-from[2])*k,
+				from[3] + (to[3]-from[3])*k,
+			}
+		}
+	}
+	return premultiplied(stops[len(stops)-1].Color)
+}
+
+// toImage 转换为非预乘alpha的8位图片
+func (c *canvas) toImage() *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, c.width, c.height))
+	for i := 0; i < c.width*c.height; i++ {
+		var pixel [4]float64
+		copy(pixel[:], c.pixels[i*4:i*4+4])
+		img.SetNRGBA(i%c.width, i/c.width, unpremultiply(pixel))
+	}
+	return img
+}
+
+// edge 多边形的边，y0 < y1，dir为原方向（向下为1）
+type edge struct {
+	x0, y0, x1, y1 float64
+	dir            int
+}
+
+// coverage 计算多边形在每个像素的覆盖率（0-1），所有折线按闭合处理
+func coverage(polygons []svg.Polyline, evenOdd bool, width, height int) []float64 {
+	var edges []edge
+	for _, polygon := range polygons {
+		points := polygon.Points
+		for i := range points {
+			p0, p1 := points[i], points[(i+1)%len(points)]
+			switch {
+			case p0.Y < p1.Y:
+				edges = append(edges, edge{p0.X, p0.Y, p1.X, p1.Y, 1})
+			case p0.Y > p1.Y:
+				edges = append(edges, edge{p1.X, p1.Y, p0.X, p0.Y, -1})
+			}
+		}
+	}
+	sort.Slice(edges, func(i, j int) bool { return edges[i].y0 < edges[j].y0 })
+
+	cover := make([]float64, width*height)
+	row := make([]float64, width)
+	var active []edge
+	type crossing struct {
+		x   float64
+		dir int
+	}
+	var crossings []crossing
+	next := 0
+	for py := 0; py < height; py++ {
+		for i := range row {
+			row[i] = 0
+		}
+		touched := false
+		for s := 0; s < subsamples; s++ {
+			y := float64(py) + (float64(s)+0.5)/subsamples
+
+			// 更新活动边
+			for next < len(edges) && edges[next].y0 <= y {
+				active = append(active, edges[next])
+				next++
+			}
+			kept := active[:0]
+			crossings = crossings[:0]
+			for _, e := range active {
+				if e.y1 <= y {
+					continue
+				}
+				kept = append(kept, e)
+				if e.y0 <= y {
+					x := e.x0 + (y-e.y0)/(e.y1-e.y0)*(e.x1-e.x0)
+					crossings = append(crossings, crossing{x, e.dir})
+				}
+			}
+			active = kept
+			if len(crossings) < 2 {
+				continue
+			}
+			sort.Slice(crossings, func(i, j int) bool { return crossings[i].x < crossings[j].x })
+
+			winding := 0
+			for i := 0; i+1 < len(crossings); i++ {
+				winding += crossings[i].dir
+				inside := winding != 0
+				if evenOdd {
+					inside = winding%2 != 0
+				}
+				if inside {
+					addSpan(row, crossings[i].x, crossings[i+1].x)
+					touched = true
+				}
+			}
+		}
+		if touched {
+			for x, value := range row {
+				cover[py*width+x] = math.Min(1, value/subsamples)
+			}
+		}
+	}
+	return cover
+}
+
+// addSpan 在一条子扫描线上累加 [x0, x1) 区间的水平覆盖面积
+func addSpan(row []float64, x0, x1 float64) {
+	x0 = math.Max(0, x0)
+	x1 = math.Min(float64(len(row)), x1)
+	if x1 <= x0 {
+		return
+	}
+	first, last := int(x0), int(x1)
+	if first == last {
+		row[first] += x1 - x0
+		return
+	}
+	row[first] += float64(first+1) - x0
+	for x := first + 1; x < last; x++ {
+		row[x]++
+	}
+	if last < len(row) {
+		row[last] += x1 - float64(last)
+	}
+}
+
+// strokeOutline 生成描边轮廓（用户坐标）：每条线段一个矩形，加上线连接和线帽
+// 各部分统一为同一方向，按nonzero规则合并
+func strokeOutline(shape *svg.Shape, tolerance float64) []svg.Polyline {
+	half := shape.StrokeWidth / 2
+	var outline []svg.Polyline
+	add := func(points ...svg.Point) {
+		outline = append(outline, orient(points))
+	}
+
+	for _, polyline := range shape.Path.Flatten(tolerance) {
+		points := dedupe(polyline.Points)
+		if polyline.Closed && len(points) > 1 && points[0] == points[len(points)-1] {
+			points = points[:len(points)-1]
+		}
+
+		// 长度为0的子路径只绘制线帽
+		if len(points) == 1 {
+			switch shape.LineCap {
+			case "round":
+				add(circle(points[0], half, tolerance)...)
+			case "square":
+				p := points[0]
+				add(svg.Point{X: p.X - half, Y: p.Y - half}, svg.Point{X: p.X + half, Y: p.Y - half},
+					svg.Point{X: p.X + half, Y: p.Y + half}, svg.Point{X: p.X - half, Y: p.Y + half})
+			}
+			continue
+		}
+
+		closed := polyline.Closed && len(points) > 2
+		count := len(points) - 1
+		if closed {
+			count = len(points)
+		}
+		for i := 0; i < count; i++ {
+			p0, p1 := points[i], points[(i+1)%len(points)]
+			n := normal(p0, p1, half)
+			add(svg.Point{X: p0.X + n.X, Y: p0.Y + n.Y}, svg.Point{X: p1.X + n.X, Y: p1.Y + n.Y},
+				svg.Point{X: p1.X - n.X, Y: p1.Y - n.Y}, svg.Point{X: p0.X - n.X, Y: p0.Y - n.Y})
+		}
+
+		// 线连接
+		for i := 0; i < len(points); i++ {
+			if !closed && (i == 0 || i == len(points)-1) {
+				continue
+			}
+			prev := points[(i-1+len(points))%len(points)]
+			next := points[(i+1)%len(points)]
+			for _, join := range joinPolygons(prev, points[i], next, half, shape.LineJoin, shape.MiterLimit, tolerance) {
+				add(join...)
+			}
+		}
+
+		// 线帽
+		if !closed {
+			for _, end := range [][2]svg.Point{{points[1], points[0]}, {points[len(points)-2], points[len(points)-1]}} {
+				from, p := end[0], end[1]
+				switch shape.LineCap {
+				case "round":
+					add(circle(p, half, tolerance)...)
+				case "square":
+					n := normal(from, p, half)
+					d := svg.Point{X: -n.Y, Y: n.X} // 沿线段方向、长度为半线宽
+					if (p.X-from.X)*d.X+(p.Y-from.Y)*d.Y < 0 {
+						d = svg.Point{X: -d.X, Y: -d.Y}
+					}
+					add(svg.Point{X: p.X + n.X, Y: p.Y + n.Y}, svg.Point{X: p.X + n.X + d.X, Y: p.Y + n.Y + d.Y},
+						svg.Point{X: p.X - n.X + d.X, Y: p.Y - n.Y + d.Y}, svg.Point{X: p.X - n.X, Y: p.Y - n.Y})
+				}
+			}
+		}
+	}
+	return outline
+}
+
+// joinPolygons 生成顶点处的线连接
+func joinPolygons(prev, p, next svg.Point, half float64, join string, miterLimit, tolerance float64) [][]svg.Point {
+	if join == "round" {
+		return [][]svg.Point{circle(p, half, tolerance)}
+	}
+
+	n1, n2 := normal(prev, p, half), normal(p, next, half)
+	cross := (p.X-prev.X)*(next.Y-p.Y) - (p.Y-prev.Y)*(next.X-p.X)
+	if cross == 0 {
+		return nil // 共线时矩形已经相接
+	}
+	// 外侧为转向的另一侧
+	side := -1.0
+	if cross < 0 {
+		side = 1
+	}
+	a := svg.Point{X: p.X + side*n1.X, Y: p.Y + side*n1.Y}
+	b := svg.Point{X: p.X + side*n2.X, Y: p.Y + side*n2.Y}
+
+	if join != "bevel" {
+		// 斜接点沿两条法线的角平分线方向，长度为 half / cos(θ/2)
+		ux, uy := (n1.X+n2.X)/half, (n1.Y+n2.Y)/half
+		dot := (n1.X*n2.X + n1.Y*n2.Y) / (half * half)
+		if 1+dot > 1e-9 {
+			scale := half / (1 + dot)
+			miter := svg.Point{X: p.X + side*ux*scale, Y: p.Y + side*uy*scale}
+			// 斜接长度与线宽之比不超过miterlimit
+			if math.Hypot(miter.X-p.X, miter.Y-p.Y)/half <= miterLimit {
+				return [][]svg.Point{{p, a, miter, b}}
+			}
+		}
+	}
+	return [][]svg.Point{{p, a, b}}
+}
+
+// normal 获取线段左侧、长度为half的法线
+func normal(p0, p1 svg.Point, half float64) svg.Point {
+	dx, dy := p1.X-p0.X, p1.Y-p0.Y
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		return svg.Point{}
+	}
+	return svg.Point{X: -dy / length * half, Y: dx / length * half}
+}
+
+// circle 生成圆形多边形，段数保证与真实圆的误差不超过容差
+func circle(center svg.Point, radius, tolerance float64) []svg.Point {
+	segments := 8
+	if radius > tolerance {
+		segments = int(math.Max(8, math.Ceil(math.Pi/math.Acos(1-tolerance/radius))))
+	}
+	points := make([]svg.Point, segments)
+	for i := range points {
+		angle := 2 * math.Pi * float64(i) / float64(segments)
+		points[i] = svg.Point{X: center.X + radius*math.Cos(angle), Y: center.Y + radius*math.Sin(angle)}
+	}
+	return points
+}
+
+// orient 将多边形统一为正方向（有向面积为正），使重叠部分按nonzero规则合并而不是抵消
+func orient(points []svg.Point) svg.Polyline {
+	area := 0.0
+	for i := range points {
+		p0, p1 := points[i], points[(i+1)%len(points)]
+		area += p0.X*p1.Y - p1.X*p0.Y
+	}
+	if area < 0 {
+		reversed := make([]svg.Point, len(points))
+		for i, point := range points {
+			reversed[len(points)-1-i] = point
+		}
+		points = reversed
+	}
+	return svg.Polyline{Points: points, Closed: true}
+}
+
+// dedupe 去除连续重复的点
+func dedupe(points []svg.Point) []svg.Point {
+	result := make([]svg.Point, 0, len(points))
+	for _, point := range points {
+		if len(result) == 0 || result[len(result)-1] != point {
+			result = append(result, point)
+		}
+	}
+	return result
+}
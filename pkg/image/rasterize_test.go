@@ -0,0 +1,239 @@
+package image
+
+import (
+	"app-assets-generator/pkg/svg"
+	"image/color"
+	"math"
+	"testing"
+)
+
+// probe 渲染结果中一个像素的期望值，各分量允许相差tolerance
+type probe struct {
+	x, y      int
+	want      color.NRGBA
+	tolerance uint8
+}
+
+var (
+	opaqueRed   = color.NRGBA{R: 255, A: 255}
+	opaqueBlue  = color.NRGBA{B: 255, A: 255}
+	transparent = color.NRGBA{}
+)
+
+// near 判断两个颜色的各分量是否都在容差内
+func near(got, want color.NRGBA, tolerance uint8) bool {
+	diff := func(a, b uint8) uint8 {
+		if a > b {
+			return a - b
+		}
+		return b - a
+	}
+	// 完全透明的像素不比较颜色分量
+	if want.A == 0 {
+		return diff(got.A, 0) <= tolerance
+	}
+	return diff(got.R, want.R) <= tolerance && diff(got.G, want.G) <= tolerance &&
+		diff(got.B, want.B) <= tolerance && diff(got.A, want.A) <= tolerance
+}
+
+func TestRenderSVG(t *testing.T) {
+	tests := []struct {
+		name          string
+		svg           string
+		size          Size
+		scale         float64
+		width, height int
+		probes        []probe
+	}{
+		{
+			name:  "对齐像素的矩形边缘清晰",
+			svg:   `<svg xmlns="http://www.w3.org/2000/svg" width="10" height="10"><rect x="2" y="2" width="4" height="4" fill="red"/></svg>`,
+			scale: 1, width: 10, height: 10,
+			probes: []probe{{x: 2, y: 2, want: opaqueRed}, {x: 5, y: 5, want: opaqueRed}, {x: 6, y: 5, want: transparent}, {x: 1, y: 1, want: transparent}},
+		},
+		{
+			name:  "半像素边缘按覆盖面积抗锯齿",
+			svg:   `<svg xmlns="http://www.w3.org/2000/svg" width="4" height="4"><rect x="0" y="0" width="2.5" height="4" fill="#0000ff"/></svg>`,
+			scale: 1, width: 4, height: 4,
+			probes: []probe{{x: 1, y: 0, want: opaqueBlue}, {x: 2, y: 0, want: color.NRGBA{B: 255, A: 128}, tolerance: 1}, {x: 3, y: 0, want: transparent}},
+		},
+		{
+			name:  "倍数放大像素尺寸",
+			svg:   `<svg xmlns="http://www.w3.org/2000/svg" width="10" height="5"><rect x="5" y="0" width="5" height="5" fill="red"/></svg>`,
+			scale: 3, width: 30, height: 15,
+			probes: []probe{{x: 14, y: 7, want: transparent}, {x: 15, y: 7, want: opaqueRed}},
+		},
+		{
+			name: "只指定最长边时按宽高比计算",
+			svg:  `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 20 10"><rect width="10" height="10" fill="red"/></svg>`,
+			size: Size{Width: 8}, scale: 2, width: 16, height: 8,
+			probes: []probe{{x: 7, y: 4, want: opaqueRed}, {x: 8, y: 4, want: transparent}},
+		},
+		{
+			name:  "不透明度",
+			svg:   `<svg xmlns="http://www.w3.org/2000/svg" width="2" height="2"><rect width="2" height="2" fill="red" fill-opacity="0.5"/></svg>`,
+			scale: 1, width: 2, height: 2,
+			probes: []probe{{x: 0, y: 0, want: color.NRGBA{R: 255, A: 128}, tolerance: 1}},
+		},
+		{
+			name: "source-over合成",
+			svg: `<svg xmlns="http://www.w3.org/2000/svg" width="2" height="2">
+				<rect width="2" height="2" fill="#0000ff"/>
+				<rect width="2" height="2" fill="#ff0000" opacity="0.5"/>
+			</svg>`,
+			scale: 1, width: 2, height: 2,
+			probes: []probe{{x: 1, y: 1, want: color.NRGBA{R: 128, B: 128, A: 255}, tolerance: 1}},
+		},
+		{
+			name: "evenodd挖空内部",
+			svg: `<svg xmlns="http://www.w3.org/2000/svg" width="10" height="10">
+				<path fill-rule="evenodd" fill="red" d="M0 0H10V10H0Z M3 3H7V7H3Z"/>
+			</svg>`,
+			scale: 1, width: 10, height: 10,
+			probes: []probe{{x: 1, y: 1, want: opaqueRed}, {x: 5, y: 5, want: transparent}},
+		},
+		{
+			name: "nonzero同向的内部路径仍然填充",
+			svg: `<svg xmlns="http://www.w3.org/2000/svg" width="10" height="10">
+				<path fill="red" d="M0 0H10V10H0Z M3 3H7V7H3Z"/>
+			</svg>`,
+			scale: 1, width: 10, height: 10,
+			probes: []probe{{x: 5, y: 5, want: opaqueRed}},
+		},
+		{
+			name: "描边以路径为中心",
+			svg: `<svg xmlns="http://www.w3.org/2000/svg" width="10" height="10">
+				<line x1="0" y1="5" x2="10" y2="5" stroke="#0000ff" stroke-width="2"/>
+			</svg>`,
+			scale: 1, width: 10, height: 10,
+			probes: []probe{{x: 5, y: 4, want: opaqueBlue}, {x: 5, y: 5, want: opaqueBlue}, {x: 5, y: 3, want: transparent}, {x: 5, y: 6, want: transparent}},
+		},
+		{
+			name: "方形线帽延伸半个线宽",
+			svg: `<svg xmlns="http://www.w3.org/2000/svg" width="10" height="10">
+				<line x1="3" y1="5" x2="7" y2="5" stroke="#0000ff" stroke-width="2" stroke-linecap="square"/>
+			</svg>`,
+			scale: 1, width: 10, height: 10,
+			probes: []probe{{x: 2, y: 5, want: opaqueBlue}, {x: 7, y: 5, want: opaqueBlue}, {x: 1, y: 5, want: transparent}, {x: 8, y: 5, want: transparent}},
+		},
+		{
+			name: "裁剪路径",
+			svg: `<svg xmlns="http://www.w3.org/2000/svg" width="10" height="10">
+				<clipPath id="left"><rect width="5" height="10"/></clipPath>
+				<rect width="10" height="10" fill="red" clip-path="url(#left)"/>
+			</svg>`,
+			scale: 1, width: 10, height: 10,
+			probes: []probe{{x: 4, y: 5, want: opaqueRed}, {x: 5, y: 5, want: transparent}},
+		},
+		{
+			name: "线性渐变",
+			svg: `<svg xmlns="http://www.w3.org/2000/svg" width="100" height="1">
+				<linearGradient id="g"><stop offset="0" stop-color="#000000"/><stop offset="1" stop-color="#ffffff"/></linearGradient>
+				<rect width="100" height="1" fill="url(#g)"/>
+			</svg>`,
+			scale: 1, width: 100, height: 1,
+			probes: []probe{
+				{x: 0, y: 0, want: color.NRGBA{R: 1, G: 1, B: 1, A: 255}, tolerance: 2},
+				{x: 49, y: 0, want: color.NRGBA{R: 126, G: 126, B: 126, A: 255}, tolerance: 2},
+				{x: 99, y: 0, want: color.NRGBA{R: 254, G: 254, B: 254, A: 255}, tolerance: 2},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			doc, err := svg.Parse([]byte(test.svg))
+			if err != nil {
+				t.Fatalf("解析SVG失败: %v", err)
+			}
+			img := RenderSVG(doc, test.size, test.scale)
+			if img.Rect.Dx() != test.width || img.Rect.Dy() != test.height {
+				t.Fatalf("尺寸为 %dx%d，应为 %dx%d", img.Rect.Dx(), img.Rect.Dy(), test.width, test.height)
+			}
+			for _, p := range test.probes {
+				if got := img.NRGBAAt(p.x, p.y); !near(got, p.want, p.tolerance) {
+					t.Errorf("(%d, %d) 为 %v，应为 %v", p.x, p.y, got, p.want)
+				}
+			}
+		})
+	}
+}
+
+func TestAddSpan(t *testing.T) {
+	tests := []struct {
+		x0, x1 float64
+		want   []float64
+	}{
+		{x0: 1, x1: 3, want: []float64{0, 1, 1, 0}},
+		{x0: 0.25, x1: 0.75, want: []float64{0.5, 0, 0, 0}},
+		{x0: 0.5, x1: 2.25, want: []float64{0.5, 1, 0.25, 0}},
+		{x0: -2, x1: 1.5, want: []float64{1, 0.5, 0, 0}}, // 左侧超出画布
+		{x0: 3.5, x1: 9, want: []float64{0, 0, 0, 0.5}},  // 右侧超出画布
+		{x0: 2, x1: 2, want: []float64{0, 0, 0, 0}},      // 空区间
+		{x0: 5, x1: 6, want: []float64{0, 0, 0, 0}},      // 完全在画布外
+	}
+	for _, test := range tests {
+		row := make([]float64, 4)
+		addSpan(row, test.x0, test.x1)
+		for i := range row {
+			if math.Abs(row[i]-test.want[i]) > 1e-12 {
+				t.Errorf("addSpan(%g, %g) = %v，应为 %v", test.x0, test.x1, row, test.want)
+				break
+			}
+		}
+	}
+}
+
+func TestGradientColor(t *testing.T) {
+	stops := []svg.Stop{
+		{Offset: 0.2, Color: svg.Color{R: 1, A: 1}},
+		{Offset: 0.8, Color: svg.Color{B: 1, A: 0}},
+	}
+	tests := []struct {
+		spread string
+		t      float64
+		want   [4]float64 // 预乘alpha
+	}{
+		{spread: "pad", t: 0, want: [4]float64{1, 0, 0, 1}},
+		{spread: "pad", t: 0.5, want: [4]float64{0.5, 0, 0, 0.5}}, // 预乘后插值，不会混入透明端的蓝色
+		{spread: "pad", t: 1.5, want: [4]float64{0, 0, 0, 0}},
+		{spread: "repeat", t: 1.5, want: [4]float64{0.5, 0, 0, 0.5}},
+		{spread: "reflect", t: 1.5, want: [4]float64{0.5, 0, 0, 0.5}},
+		{spread: "reflect", t: -0.1, want: [4]float64{1, 0, 0, 1}},
+	}
+	for _, test := range tests {
+		got := gradientColor(&svg.Gradient{Stops: stops, Spread: test.spread}, test.t)
+		for k := range got {
+			if math.Abs(got[k]-test.want[k]) > 1e-9 {
+				t.Errorf("%s 在 %g 处为 %v，应为 %v", test.spread, test.t, got, test.want)
+				break
+			}
+		}
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		value string
+		want  Size
+		valid bool
+	}{
+		{value: "24", want: Size{Width: 24}, valid: true},
+		{value: "24x32", want: Size{Width: 24, Height: 32}, valid: true},
+		{value: " 1.5X2 ", want: Size{Width: 1.5, Height: 2}, valid: true},
+		{value: "0", valid: false},
+		{value: "24x", valid: false},
+		{value: "1x2x3", valid: false},
+		{value: "abc", valid: false},
+	}
+	for _, test := range tests {
+		got, err := ParseSize(test.value)
+		if (err == nil) != test.valid {
+			t.Errorf("ParseSize(%q) 的错误为 %v", test.value, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("ParseSize(%q) = %+v，应为 %+v", test.value, got, test.want)
+		}
+	}
+}
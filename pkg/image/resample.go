@@ -0,0 +1,247 @@
+package image
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// lanczosRadius Lanczos滤波器的半径（Lanczos3）
+const lanczosRadius = 3
+
+// jpegQuality 重新编码JPEG的质量
+const jpegQuality = 95
+
+// isResampleable 判断图片是否可以重新采样：PNG/JPEG位图，九宫格图片（.9.png）的边框像素不能缩放
+func isResampleable(imageInfo *ImageInfo) bool {
+	switch imageInfo.Extension {
+	case ".png", ".jpg", ".jpeg":
+		return !strings.HasSuffix(imageInfo.Name, ".9")
+	}
+	return false
+}
+
+// rasterSource 重新采样使用的源图，按需解码
+type rasterSource struct {
+	path  string
+	scale float64 // 源图的倍数
+	img   image.Image
+}
+
+// largestSource 获取指定设备类型倍数最大的源图，作为缩小的来源
+func largestSource(imageInfo *ImageInfo, inputPath, idiom string) (*rasterSource, bool) {
+	for _, scale := range []string{"3x", "2x", "1x"} {
+		if fileName := imageInfo.Lookup(idiom, scale); fileName != "" {
+			return &rasterSource{
+				path:  filepath.Join(sourceDir(imageInfo, inputPath), fileName),
+				scale: scaleFactor(scale),
+			}, true
+		}
+	}
+	return nil, false
+}
+
+// scaleFactor 将 1x/2x/3x 转换为数值
+func scaleFactor(scale string) float64 {
+	value, _ := strconv.ParseFloat(strings.TrimSuffix(scale, "x"), 64)
+	return value
+}
+
+// load 解码源图
+func (s *rasterSource) load() (image.Image, error) {
+	if s.img != nil {
+		return s.img, nil
+	}
+	file, err := os.Open(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("读取图片失败: %w", err)
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("解码图片 %s 失败: %w", filepath.Base(s.path), err)
+	}
+	s.img = img
+	return img, nil
+}
+
+// resampleTo 将源图缩放到指定倍数并写入目标文件，尺寸按源图的点尺寸换算并四舍五入
+func (s *rasterSource) resampleTo(dst string, scale float64) error {
+	img, err := s.load()
+	if err != nil {
+		return err
+	}
+	bounds := img.Bounds()
+	width := int(math.Max(1, math.Round(float64(bounds.Dx())/s.scale*scale)))
+	height := int(math.Max(1, math.Round(float64(bounds.Dy())/s.scale*scale)))
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return fmt.Errorf("创建目录 %s 失败: %w", filepath.Dir(dst), err)
+	}
+	return WriteImage(dst, Resize(img, width, height))
+}
+
+// WriteImage 按扩展名编码图片，PNG使用最高压缩级别，完全不透明的图片不包含alpha通道
+func WriteImage(path string, img image.Image) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("创建 %s 失败: %w", path, err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jpg", ".jpeg":
+		err = jpeg.Encode(file, img, &jpeg.Options{Quality: jpegQuality})
+	default:
+		encoder := png.Encoder{CompressionLevel: png.BestCompression}
+		err = encoder.Encode(file, img)
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("写入 %s 失败: %w", path, err)
+	}
+	return nil
+}
+
+// Resize 使用Lanczos3滤波器缩放图片
+// 在预乘alpha的空间中先水平后垂直分别卷积，避免透明边缘出现颜色光晕
+func Resize(src image.Image, width, height int) *image.NRGBA {
+	bounds := src.Bounds()
+	rgba := image.NewRGBA64(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), src, bounds.Min, draw.Src)
+
+	srcWidth, srcHeight := bounds.Dx(), bounds.Dy()
+	pixels := make([]float64, srcWidth*srcHeight*4)
+	for y := 0; y < srcHeight; y++ {
+		for x := 0; x < srcWidth; x++ {
+			c := rgba.RGBA64At(x, y)
+			i := (y*srcWidth + x) * 4
+			pixels[i] = float64(c.R) / 0xffff
+			pixels[i+1] = float64(c.G) / 0xffff
+			pixels[i+2] = float64(c.B) / 0xffff
+			pixels[i+3] = float64(c.A) / 0xffff
+		}
+	}
+
+	// 水平方向：srcWidth×srcHeight -> width×srcHeight
+	horizontal := make([]float64, width*srcHeight*4)
+	for x, taps := range filterWeights(srcWidth, width) {
+		for y := 0; y < srcHeight; y++ {
+			var sum [4]float64
+			for _, tap := range taps {
+				i := (y*srcWidth + tap.index) * 4
+				for c := 0; c < 4; c++ {
+					sum[c] += pixels[i+c] * tap.weight
+				}
+			}
+			copy(horizontal[(y*width+x)*4:], sum[:])
+		}
+	}
+
+	// 垂直方向：width×srcHeight -> width×height
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y, taps := range filterWeights(srcHeight, height) {
+		for x := 0; x < width; x++ {
+			var sum [4]float64
+			for _, tap := range taps {
+				i := (tap.index*width + x) * 4
+				for c := 0; c < 4; c++ {
+					sum[c] += horizontal[i+c] * tap.weight
+				}
+			}
+			dst.SetNRGBA(x, y, unpremultiply(sum))
+		}
+	}
+	return dst
+}
+
+// unpremultiply 将预乘alpha的分量转换为非预乘的8位颜色，截断滤波器振铃产生的越界值
+func unpremultiply(c [4]float64) color.NRGBA {
+	alpha := math.Max(0, math.Min(1, c[3]))
+	if alpha == 0 {
+		return color.NRGBA{}
+	}
+	channel := func(value float64) uint8 {
+		return uint8(math.Round(math.Max(0, math.Min(1, value/alpha)) * 255))
+	}
+	return color.NRGBA{R: channel(c[0]), G: channel(c[1]), B: channel(c[2]), A: uint8(math.Round(alpha * 255))}
+}
+
+// filterTap 卷积的一个采样点
+type filterTap struct {
+	index  int
+	weight float64
+}
+
+// filterWeights 计算每个目标像素的采样点和权重，缩小时按比例放宽滤波器以避免摩尔纹
+func filterWeights(srcSize, dstSize int) [][]filterTap {
+	ratio := float64(srcSize) / float64(dstSize)
+	stretch := math.Max(ratio, 1)
+	support := lanczosRadius * stretch
+
+	weights := make([][]filterTap, dstSize)
+	for i := range weights {
+		center := (float64(i)+0.5)*ratio - 0.5
+		start := int(math.Floor(center - support))
+		end := int(math.Ceil(center + support))
+
+		var taps []filterTap
+		total := 0.0
+		for j := start; j <= end; j++ {
+			weight := lanczos((float64(j) - center) / stretch)
+			if weight == 0 {
+				continue
+			}
+			// 边缘外的像素使用最近的边缘像素
+			index := j
+			if index < 0 {
+				index = 0
+			} else if index >= srcSize {
+				index = srcSize - 1
+			}
+			taps = append(taps, filterTap{index: index, weight: weight})
+			total += weight
+		}
+		for k := range taps {
+			taps[k].weight /= total
+		}
+		weights[i] = taps
+	}
+	return weights
+}
+
+// lanczos Lanczos3核函数
+func lanczos(x float64) float64 {
+	x = math.Abs(x)
+	if x == 0 {
+		return 1
+	}
+	if x >= lanczosRadius {
+		return 0
+	}
+	px := math.Pi * x
+	return lanczosRadius * math.Sin(px) * math.Sin(px/lanczosRadius) / (px * px)
+}
+
+// formatScale 格式化倍数，如 2x、1.5x
+func formatScale(scale float64) string {
+	return strconv.FormatFloat(scale, 'f', -1, 64) + "x"
+}
+
+// scaleSuffix 获取iOS文件名的倍数后缀，1x没有后缀
+func scaleSuffix(scale string) string {
+	if scale == "1x" {
+		return ""
+	}
+	return "@" + scale
+}
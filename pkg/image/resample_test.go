@@ -0,0 +1,207 @@
+package image
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fill 获取单一颜色的图片
+func fill(width, height int, c color.NRGBA) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetNRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestLanczos(t *testing.T) {
+	tests := []struct {
+		x    float64
+		want float64
+	}{
+		{x: 0, want: 1},
+		{x: 1, want: 0},
+		{x: -2, want: 0},
+		{x: 3, want: 0},
+		{x: 4.5, want: 0},
+		{x: 0.5, want: 3 * math.Sin(math.Pi/2) * math.Sin(math.Pi/6) / (math.Pi * math.Pi / 4)},
+		{x: -0.5, want: 3 * math.Sin(math.Pi/2) * math.Sin(math.Pi/6) / (math.Pi * math.Pi / 4)},
+	}
+	for _, test := range tests {
+		if got := lanczos(test.x); math.Abs(got-test.want) > 1e-12 {
+			t.Errorf("lanczos(%g) = %g，应为 %g", test.x, got, test.want)
+		}
+	}
+}
+
+func TestFilterWeights(t *testing.T) {
+	tests := []struct {
+		name     string
+		src, dst int
+		maxTaps  int // 每个目标像素最多的采样点数
+	}{
+		{name: "放大", src: 4, dst: 10, maxTaps: 7},
+		{name: "原尺寸", src: 8, dst: 8, maxTaps: 7},
+		{name: "缩小到三分之一，滤波器放宽3倍", src: 30, dst: 10, maxTaps: 19},
+		{name: "缩小到一个像素", src: 5, dst: 1, maxTaps: 31},
+	}
+
+	for _, test := range tests {
+		weights := filterWeights(test.src, test.dst)
+		if len(weights) != test.dst {
+			t.Fatalf("%s: 得到 %d 组权重，应为 %d 组", test.name, len(weights), test.dst)
+		}
+		for i, taps := range weights {
+			if len(taps) == 0 || len(taps) > test.maxTaps {
+				t.Errorf("%s: 像素 %d 有 %d 个采样点，应为 1-%d 个", test.name, i, len(taps), test.maxTaps)
+			}
+			total := 0.0
+			for _, tap := range taps {
+				if tap.index < 0 || tap.index >= test.src {
+					t.Errorf("%s: 像素 %d 的采样点 %d 超出源图范围", test.name, i, tap.index)
+				}
+				total += tap.weight
+			}
+			if math.Abs(total-1) > 1e-9 {
+				t.Errorf("%s: 像素 %d 的权重之和为 %g，应为 1", test.name, i, total)
+			}
+		}
+	}
+
+	// 尺寸不变时每个像素只采样自身
+	for i, taps := range filterWeights(8, 8) {
+		for _, tap := range taps {
+			if tap.index != i && math.Abs(tap.weight) > 1e-12 {
+				t.Errorf("原尺寸的像素 %d 采样了像素 %d（权重 %g）", i, tap.index, tap.weight)
+			}
+		}
+	}
+}
+
+func TestResize(t *testing.T) {
+	t.Run("纯色保持不变", func(t *testing.T) {
+		want := color.NRGBA{R: 51, G: 102, B: 153, A: 200}
+		for _, size := range [][2]int{{7, 5}, {40, 40}, {1, 1}} {
+			got := Resize(fill(20, 20, want), size[0], size[1])
+			if got.Rect.Dx() != size[0] || got.Rect.Dy() != size[1] {
+				t.Fatalf("尺寸为 %v，应为 %v", got.Rect.Size(), size)
+			}
+			for y := 0; y < size[1]; y++ {
+				for x := 0; x < size[0]; x++ {
+					if c := got.NRGBAAt(x, y); c != want {
+						t.Fatalf("缩放到 %v 后 (%d, %d) 为 %v，应为 %v", size, x, y, c, want)
+					}
+				}
+			}
+		}
+	})
+
+	t.Run("透明边缘没有光晕", func(t *testing.T) {
+		// 左半边不透明的红色，右半边完全透明的黑色
+		src := image.NewNRGBA(image.Rect(0, 0, 16, 4))
+		for y := 0; y < 4; y++ {
+			for x := 0; x < 8; x++ {
+				src.SetNRGBA(x, y, color.NRGBA{R: 255, A: 255})
+			}
+		}
+		got := Resize(src, 6, 2)
+		for x := 0; x < 6; x++ {
+			c := got.NRGBAAt(x, 0)
+			if c.A > 0 && (c.R != 255 || c.G != 0 || c.B != 0) {
+				t.Errorf("(%d, 0) 为 %v，半透明像素应保持红色", x, c)
+			}
+		}
+		// 滤波器的振铃会让两端偏离几个单位
+		if left, right := got.NRGBAAt(0, 0).A, got.NRGBAAt(5, 0).A; left < 250 || right > 5 {
+			t.Errorf("两端的alpha为 %d 和 %d，应接近 255 和 0", left, right)
+		}
+	})
+
+	t.Run("缩小细条纹不产生摩尔纹", func(t *testing.T) {
+		// 一像素宽的黑白竖条纹，缩小到三分之一后应接近均匀的灰色
+		src := fill(30, 3, color.NRGBA{A: 255})
+		for x := 0; x < 30; x += 2 {
+			for y := 0; y < 3; y++ {
+				src.SetNRGBA(x, y, color.NRGBA{R: 255, G: 255, B: 255, A: 255})
+			}
+		}
+		got := Resize(src, 10, 1)
+		lightest, darkest := uint8(0), uint8(255)
+		for x := 0; x < 10; x++ {
+			r := got.NRGBAAt(x, 0).R
+			lightest, darkest = max(lightest, r), min(darkest, r)
+		}
+		if lightest-darkest > 64 {
+			t.Errorf("缩小后的亮度在 %d-%d 之间变化，应接近均匀", darkest, lightest)
+		}
+	})
+
+	t.Run("源图边界不从原点开始", func(t *testing.T) {
+		src := fill(10, 10, color.NRGBA{G: 255, A: 255}).SubImage(image.Rect(5, 5, 10, 10))
+		if c := Resize(src, 2, 2).NRGBAAt(1, 1); c != (color.NRGBA{G: 255, A: 255}) {
+			t.Errorf("像素为 %v，应为不透明的绿色", c)
+		}
+	})
+}
+
+func TestResampleTo(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "logo@3x.png")
+	if err := WriteImage(path, fill(30, 45, color.NRGBA{B: 255, A: 255})); err != nil {
+		t.Fatal(err)
+	}
+	source := &rasterSource{path: path, scale: 3}
+
+	tests := []struct {
+		scale         float64
+		width, height int
+	}{
+		{scale: 2, width: 20, height: 30},
+		{scale: 1.5, width: 15, height: 23}, // 22.5 四舍五入
+		{scale: 0.75, width: 8, height: 11},
+		{scale: 0.01, width: 1, height: 1}, // 至少一个像素
+	}
+	for _, test := range tests {
+		dst := filepath.Join(dir, "out", formatScale(test.scale)+".png")
+		if err := source.resampleTo(dst, test.scale); err != nil {
+			t.Fatalf("resampleTo(%g) 失败: %v", test.scale, err)
+		}
+		file, err := os.Open(dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		config, err := png.DecodeConfig(file)
+		file.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if config.Width != test.width || config.Height != test.height {
+			t.Errorf("%s 的尺寸为 %dx%d，应为 %dx%d", formatScale(test.scale), config.Width, config.Height, test.width, test.height)
+		}
+	}
+}
+
+func TestIsResampleable(t *testing.T) {
+	tests := []struct {
+		name, extension string
+		want            bool
+	}{
+		{name: "logo", extension: ".png", want: true},
+		{name: "photo", extension: ".jpeg", want: true},
+		{name: "bubble.9", extension: ".png", want: false},
+		{name: "icon", extension: ".svg", want: false},
+		{name: "icon", extension: ".pdf", want: false},
+	}
+	for _, test := range tests {
+		if got := isResampleable(&ImageInfo{Name: test.name, Extension: test.extension}); got != test.want {
+			t.Errorf("isResampleable(%s%s) = %v，应为 %v", test.name, test.extension, got, test.want)
+		}
+	}
+}
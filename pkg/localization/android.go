@@ -0,0 +1,152 @@
+package localization
+
+import (
+	"app-assets-generator/internal/naming"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// AndroidGenerator Android字符串资源生成器
+type AndroidGenerator struct {
+	outputPath string // res目录路径
+}
+
+// NewAndroidGenerator 创建Android生成器
+func NewAndroidGenerator(outputPath string) *AndroidGenerator {
+	return &AndroidGenerator{
+		outputPath: outputPath,
+	}
+}
+
+// Generate 为每种语言生成strings.xml，基础语言写入 values/，其它语言写入 values-<语言>/
+// 占位符转换为 %1$s 形式的位置参数，复数字符串生成 <plurals>
+func (g *AndroidGenerator) Generate(catalog *Catalog) error {
+	for _, locale := range catalog.Locales {
+		dir := "values"
+		if locale != catalog.BaseLocale {
+			dir = AndroidValuesDir(locale)
+		}
+		if err := g.generateLocale(catalog, locale, filepath.Join(g.outputPath, dir)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// generateLocale 生成一种语言的strings.xml，缺少翻译的键不写入，运行时回退到基础语言
+func (g *AndroidGenerator) generateLocale(catalog *Catalog, locale, valuesPath string) error {
+	if err := os.MkdirAll(valuesPath, 0755); err != nil {
+		return fmt.Errorf("创建 %s 目录失败: %w", valuesPath, err)
+	}
+
+	var builder strings.Builder
+	builder.WriteString("<?xml version=\"1.0\" encoding=\"utf-8\"?>\n")
+	builder.WriteString("<!-- 由 app-assets-generator 自动生成，请勿手动修改 -->\n")
+	builder.WriteString("<resources>\n")
+
+	section := ""
+	written := 0
+	for _, entry := range catalog.Entries {
+		translation, ok := entry.Translations[locale]
+		if !ok {
+			continue
+		}
+		if entry.Section != section {
+			section = entry.Section
+			if written > 0 {
+				builder.WriteString("\n")
+			}
+			fmt.Fprintf(&builder, "    <!-- ===== %s ===== -->\n", naming.XMLComment(section))
+		}
+		if doc := entry.Doc(); doc != "" && locale == catalog.BaseLocale {
+			fmt.Fprintf(&builder, "    <!-- %s -->\n", naming.XMLComment(doc))
+		}
+
+		if !entry.Plural {
+			fmt.Fprintf(&builder, "    <string name=\"%s\">%s</string>\n", entry.Key, androidText(entry, translation.Text))
+		} else {
+			fmt.Fprintf(&builder, "    <plurals name=\"%s\">\n", entry.Key)
+			for _, category := range sortedCategories(translation.Plural) {
+				fmt.Fprintf(&builder, "        <item quantity=\"%s\">%s</item>\n", category, androidText(entry, translation.Plural[category]))
+			}
+			builder.WriteString("    </plurals>\n")
+		}
+		written++
+	}
+
+	builder.WriteString("</resources>\n")
+
+	filePath := filepath.Join(valuesPath, "strings.xml")
+	if err := os.WriteFile(filePath, []byte(builder.String()), 0644); err != nil {
+		return fmt.Errorf("写入 %s 失败: %w", filePath, err)
+	}
+	return nil
+}
+
+// androidText 转换为strings.xml中的文本
+func androidText(entry *Entry, text string) string {
+	formatted := entry.Formatted()
+	result := renderText(text, func(s string) string {
+		return escapeAndroid(s, formatted)
+	}, func(name string) string {
+		index := entry.placeholderIndex(name)
+		return fmt.Sprintf("%%%d$%s", index, androidSpecifier(entry.Placeholders[index-1].Type))
+	})
+
+	// 开头的@和?会被解析为资源引用，首尾空白会被aapt去除
+	if strings.HasPrefix(result, "@") || strings.HasPrefix(result, "?") {
+		result = "\\" + result
+	}
+	if strings.TrimSpace(text) != text {
+		result = "\"" + result + "\""
+	}
+	return result
+}
+
+// androidSpecifier 获取参数类型对应的Java格式说明符
+func androidSpecifier(typ PlaceholderType) string {
+	switch typ {
+	case TypeInt:
+		return "d"
+	case TypeDouble:
+		return "f"
+	default:
+		return "s"
+	}
+}
+
+// escapeAndroid 转义strings.xml中的特殊字符，格式字符串中的%需要写作%%
+func escapeAndroid(text string, formatted bool) string {
+	var builder strings.Builder
+	for _, r := range text {
+		switch r {
+		case '&':
+			builder.WriteString("&amp;")
+		case '<':
+			builder.WriteString("&lt;")
+		case '>':
+			builder.WriteString("&gt;")
+		case '\\':
+			builder.WriteString("\\\\")
+		case '\'':
+			builder.WriteString("\\'")
+		case '"':
+			builder.WriteString("\\\"")
+		case '\n':
+			builder.WriteString("\\n")
+		case '\t':
+			builder.WriteString("\\t")
+		case '%':
+			if formatted {
+				builder.WriteString("%%")
+			} else {
+				builder.WriteRune(r)
+			}
+		default:
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
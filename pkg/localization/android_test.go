@@ -0,0 +1,172 @@
+package localization
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEscapeAndroid(t *testing.T) {
+	tests := []struct {
+		text      string
+		formatted bool
+		want      string
+	}{
+		{text: "Tom & Jerry", want: "Tom &amp; Jerry"},
+		{text: "<b>bold</b>", want: "&lt;b&gt;bold&lt;/b&gt;"},
+		{text: `It's "quoted"`, want: `It\'s \"quoted\"`},
+		{text: `C:\path`, want: `C:\\path`},
+		{text: "line1\nline2\tend", want: `line1\nline2\tend`},
+		{text: "100%", want: "100%"},
+		{text: "100%", formatted: true, want: "100%%"},
+		{text: "中文，不转义", want: "中文，不转义"},
+	}
+	for _, test := range tests {
+		if got := escapeAndroid(test.text, test.formatted); got != test.want {
+			t.Errorf("escapeAndroid(%q, %v) = %q，应为 %q", test.text, test.formatted, got, test.want)
+		}
+	}
+}
+
+func TestAndroidText(t *testing.T) {
+	greeting := &Entry{Key: "greeting", Placeholders: []Placeholder{
+		{Name: "name", Type: TypeString},
+		{Name: "count", Type: TypeInt},
+		{Name: "price", Type: TypeDouble},
+	}}
+	plain := &Entry{Key: "plain"}
+
+	tests := []struct {
+		entry *Entry
+		text  string
+		want  string
+	}{
+		{entry: greeting, text: "{name} has {count} items", want: "%1$s has %2$d items"},
+		{entry: greeting, text: "{price} ({count}) {name}", want: "%3$f (%2$d) %1$s"},
+		{entry: greeting, text: "{name}: 50% off", want: "%1$s: 50%% off"},
+		{entry: greeting, text: "{{literal}} {name}", want: "{literal} %1$s"},
+		{entry: plain, text: "50% off", want: "50% off"},
+		{entry: plain, text: "@home", want: `\@home`},
+		{entry: plain, text: "?help", want: `\?help`},
+		{entry: plain, text: "  padded ", want: `"  padded "`},
+		{entry: plain, text: "'quoted' ", want: `"\'quoted\' "`},
+	}
+	for _, test := range tests {
+		if got := androidText(test.entry, test.text); got != test.want {
+			t.Errorf("androidText(%q) = %q，应为 %q", test.text, got, test.want)
+		}
+	}
+}
+
+func TestAndroidValuesDir(t *testing.T) {
+	tests := map[string]string{
+		"en":         "values-en",
+		"pt-BR":      "values-pt-rBR",
+		"zh-Hans":    "values-b+zh+Hans",
+		"zh-Hant-TW": "values-b+zh+Hant+TW",
+		"es-419":     "values-b+es+419",
+		"fil":        "values-fil",
+	}
+	for locale, want := range tests {
+		if got := AndroidValuesDir(locale); got != want {
+			t.Errorf("AndroidValuesDir(%q) = %q，应为 %q", locale, got, want)
+		}
+	}
+}
+
+func TestAndroidGenerate(t *testing.T) {
+	catalog := &Catalog{
+		BaseLocale: "en",
+		Locales:    []string{"en", "zh-Hans", "pt-BR"},
+		Entries: []*Entry{
+			{
+				Key:          "welcome",
+				Placeholders: []Placeholder{{Name: "name", Type: TypeString}},
+				Description:  "首页 -- 欢迎语",
+				Section:      "首页",
+				Translations: map[string]*Translation{
+					"en":      {Text: "Hello, {name}!"},
+					"zh-Hans": {Text: "你好，{name}！"},
+				},
+			},
+			{
+				Key:          "cart_items",
+				Plural:       true,
+				Placeholders: []Placeholder{{Name: "count", Type: TypeInt}},
+				Section:      "购物车",
+				Translations: map[string]*Translation{
+					"en": {Plural: map[PluralCategory]string{
+						"other": "{count} items & more",
+						"one":   "{count} item",
+						"zero":  "No items",
+					}},
+					"zh-Hans": {Plural: map[PluralCategory]string{"other": "{count} 件商品"}},
+				},
+			},
+		},
+	}
+
+	output := t.TempDir()
+	if err := NewAndroidGenerator(output).Generate(catalog); err != nil {
+		t.Fatalf("Generate() 失败: %v", err)
+	}
+
+	tests := []struct {
+		dir  string
+		want string
+	}{
+		{
+			dir: "values",
+			want: `<?xml version="1.0" encoding="utf-8"?>
+<!-- 由 app-assets-generator 自动生成，请勿手动修改 -->
+<resources>
+    <!-- ===== 首页 ===== -->
+    <!-- 首页 - - 欢迎语 -->
+    <string name="welcome">Hello, %1$s!</string>
+
+    <!-- ===== 购物车 ===== -->
+    <plurals name="cart_items">
+        <item quantity="zero">No items</item>
+        <item quantity="one">%1$d item</item>
+        <item quantity="other">%1$d items &amp; more</item>
+    </plurals>
+</resources>
+`,
+		},
+		{
+			// 说明文字只写入基础语言
+			dir: "values-b+zh+Hans",
+			want: `<?xml version="1.0" encoding="utf-8"?>
+<!-- 由 app-assets-generator 自动生成，请勿手动修改 -->
+<resources>
+    <!-- ===== 首页 ===== -->
+    <string name="welcome">你好，%1$s！</string>
+
+    <!-- ===== 购物车 ===== -->
+    <plurals name="cart_items">
+        <item quantity="other">%1$d 件商品</item>
+    </plurals>
+</resources>
+`,
+		},
+		{
+			// 没有任何翻译的语言也生成空的资源文件
+			dir: "values-pt-rBR",
+			want: `<?xml version="1.0" encoding="utf-8"?>
+<!-- 由 app-assets-generator 自动生成，请勿手动修改 -->
+<resources>
+</resources>
+`,
+		},
+	}
+	for _, test := range tests {
+		data, err := os.ReadFile(filepath.Join(output, test.dir, "strings.xml"))
+		if err != nil {
+			t.Errorf("读取 %s/strings.xml 失败: %v", test.dir, err)
+			continue
+		}
+		if string(data) != test.want {
+			t.Errorf("%s/strings.xml 为:\n%s\n应为:\n%s", test.dir, data, test.want)
+		}
+	}
+}
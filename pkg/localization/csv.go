@@ -0,0 +1,123 @@
+package localization
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+)
+
+// CSV中的固定列，其余列都是语言代码
+const (
+	csvKeyColumn         = "key"
+	csvDescriptionColumn = "description"
+)
+
+// parseCSV 解析CSV配置，第一行为表头: key,description,en,zh-Hans,...
+// 复数字符串每个类别一行，键写作 <键>#<类别>，如 items_count#one
+func (p *parser) parseCSV(data []byte) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")) // Excel导出的UTF-8 BOM
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	header, err := reader.Read()
+	if err == io.EOF {
+		return // 空文件
+	}
+	if err != nil {
+		p.csvError(err)
+		return
+	}
+	if len(header) == 0 || strings.TrimSpace(header[0]) != csvKeyColumn {
+		p.errorAt(1, 1, "CSV表头的第一列必须是 %s", csvKeyColumn)
+		return
+	}
+
+	// 表头中每一列对应的语言代码，固定列和无效的语言为空
+	descriptionColumn := -1
+	columns := make([]string, len(header))
+	for i := 1; i < len(header); i++ {
+		name := strings.TrimSpace(header[i])
+		line, column := reader.FieldPos(i)
+		switch {
+		case name == csvDescriptionColumn:
+			descriptionColumn = i
+		case p.addLocale(name, line, column):
+			columns[i] = name
+		}
+	}
+
+	pluralKeys := make(map[string]bool) // 按复数类别分行定义的键
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			p.csvError(err)
+			if errors.Is(err, csv.ErrFieldCount) {
+				continue
+			}
+			return
+		}
+
+		keyLine, keyColumn := reader.FieldPos(0)
+		key, category, isPlural := strings.Cut(strings.TrimSpace(record[0]), "#")
+		if key == "" {
+			continue // 空行
+		}
+
+		entry := p.keys[key]
+		if entry == nil {
+			if entry = p.addEntry(key, keyLine, keyColumn); entry == nil {
+				continue
+			}
+			pluralKeys[key] = isPlural
+		} else if !isPlural || !pluralKeys[key] {
+			// 只有复数字符串可以由多行组成
+			p.errorAt(keyLine, keyColumn, "键 %s 重复定义", key)
+			continue
+		}
+		if isPlural && !isPluralCategory(PluralCategory(category)) {
+			p.errorAt(keyLine, keyColumn, "键 %s 包含未知的复数类别 %s，可用类别: zero, one, two, few, many, other", key, category)
+			continue
+		}
+
+		if descriptionColumn >= 0 && record[descriptionColumn] != "" {
+			entry.Description = record[descriptionColumn]
+		}
+		for i, locale := range columns {
+			if locale == "" || record[i] == "" {
+				continue // 空单元格视为缺少翻译
+			}
+			line, column := reader.FieldPos(i)
+			translation := entry.Translations[locale]
+			if translation == nil {
+				translation = &Translation{Line: line, Column: column}
+				entry.Translations[locale] = translation
+			}
+			if !isPlural {
+				translation.Text = record[i]
+				continue
+			}
+			if translation.Plural == nil {
+				translation.Plural = make(map[PluralCategory]string)
+			}
+			if _, exists := translation.Plural[PluralCategory(category)]; exists {
+				p.errorAt(line, column, "键 %s 的 %s 翻译中复数类别 %s 重复定义", key, locale, category)
+				continue
+			}
+			translation.Plural[PluralCategory(category)] = record[i]
+		}
+	}
+}
+
+// csvError 记录CSV格式错误
+func (p *parser) csvError(err error) {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		p.errorAt(parseErr.Line, parseErr.Column, "解析CSV失败: %v", parseErr.Err)
+		return
+	}
+	p.errorAt(1, 1, "解析CSV失败: %v", err)
+}
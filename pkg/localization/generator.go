@@ -0,0 +1,116 @@
+package localization
+
+import (
+	"app-assets-generator/internal/diag"
+	"fmt"
+)
+
+// Generator 本地化字符串生成器
+type Generator struct {
+	inputPath  string           // 输入文件路径（YAML或CSV）
+	outputPath string           // Android res目录路径
+	options    Options          // 生成选项
+	catalog    *Catalog         // 解析后的字符串表
+	warnings   diag.Diagnostics // 解析时发现的警告
+}
+
+// Options 生成选项
+type Options struct {
+	BaseLocale string // 基础语言，为空时使用 DefaultBaseLocale
+
+	IOSOutput   string // iOS字符串资源的输出目录
+	IOSFormat   string // iOS字符串资源格式 xcstrings/strings，为空时使用xcstrings
+	TableName   string // iOS字符串表名称，为空时使用 DefaultTableName
+	SwiftOutput string // Swift访问器输出文件，为空时不生成
+	SwiftType   string // Swift枚举名称，为空时使用 DefaultSwiftType
+
+	KotlinOutput     string // Kotlin(Compose)访问器输出文件，为空时不生成
+	KotlinPackage    string // Kotlin访问器的包名
+	AndroidNamespace string // R类所在的包名，为空时与KotlinPackage相同
+}
+
+// NewGenerator 创建新的生成器
+func NewGenerator(inputPath, outputPath string, options Options) *Generator {
+	return &Generator{
+		inputPath:  inputPath,
+		outputPath: outputPath,
+		options:    options,
+	}
+}
+
+// GenerateIOS 生成iOS字符串资源和Swift访问器
+func (g *Generator) GenerateIOS() error {
+	if err := g.parseCatalog(); err != nil {
+		return err
+	}
+	if g.options.IOSOutput == "" {
+		return fmt.Errorf("生成iOS字符串需要指定输出目录")
+	}
+
+	iosGen := NewIOSGenerator(g.options.IOSOutput, g.options.TableName, g.options.IOSFormat)
+	if err := iosGen.Generate(g.catalog); err != nil {
+		return err
+	}
+
+	if g.options.SwiftOutput != "" {
+		swiftGen := NewSwiftGenerator(g.options.SwiftOutput, g.options.SwiftType, g.options.TableName)
+		if err := swiftGen.Generate(g.catalog); err != nil {
+			return fmt.Errorf("生成Swift字符串访问器失败: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GenerateAndroid 生成Android字符串资源和Compose访问器
+func (g *Generator) GenerateAndroid() error {
+	if err := g.parseCatalog(); err != nil {
+		return err
+	}
+
+	androidGen := NewAndroidGenerator(g.outputPath)
+	if err := androidGen.Generate(g.catalog); err != nil {
+		return err
+	}
+
+	if g.options.KotlinOutput != "" {
+		kotlinGen := NewKotlinGenerator(g.options.KotlinOutput, g.options.KotlinPackage, g.options.AndroidNamespace)
+		if err := kotlinGen.Generate(g.catalog); err != nil {
+			return fmt.Errorf("生成Kotlin字符串访问器失败: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Catalog 获取解析后的字符串表
+func (g *Generator) Catalog() (*Catalog, error) {
+	if err := g.parseCatalog(); err != nil {
+		return nil, err
+	}
+	return g.catalog, nil
+}
+
+// Warnings 获取解析字符串配置时发现的警告，如缺少的翻译
+func (g *Generator) Warnings() diag.Diagnostics {
+	return g.warnings
+}
+
+// parseCatalog 解析字符串配置
+func (g *Generator) parseCatalog() error {
+	if g.catalog != nil {
+		return nil // 已经解析过了
+	}
+
+	catalog, diagnostics, err := Parse(g.inputPath, g.options.BaseLocale)
+	if err != nil {
+		return fmt.Errorf("解析字符串配置失败: %w", err)
+	}
+	if diagnostics.HasErrors() {
+		return fmt.Errorf("解析字符串配置失败: %w", &diag.ValidationError{Diagnostics: diagnostics})
+	}
+
+	g.catalog = catalog
+	g.warnings = diagnostics.Warnings()
+	return nil
+}
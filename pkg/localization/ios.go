@@ -0,0 +1,299 @@
+package localization
+
+import (
+	"app-assets-generator/internal/naming"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// iOS字符串资源格式
+const (
+	FormatXCStrings = "xcstrings" // Xcode 15的String Catalog（Localizable.xcstrings）
+	FormatStrings   = "strings"   // 传统的 <语言>.lproj/Localizable.strings 和 .stringsdict
+)
+
+// DefaultTableName 默认的字符串表名称
+const DefaultTableName = "Localizable"
+
+// IOSGenerator iOS字符串资源生成器
+type IOSGenerator struct {
+	outputPath string // 输出目录
+	tableName  string // 字符串表名称
+	format     string // 资源格式
+}
+
+// NewIOSGenerator 创建iOS生成器，tableName为空时使用DefaultTableName，format为空时使用xcstrings
+func NewIOSGenerator(outputPath, tableName, format string) *IOSGenerator {
+	if tableName == "" {
+		tableName = DefaultTableName
+	}
+	if format == "" {
+		format = FormatXCStrings
+	}
+	return &IOSGenerator{
+		outputPath: outputPath,
+		tableName:  tableName,
+		format:     format,
+	}
+}
+
+// Generate 生成字符串资源
+func (g *IOSGenerator) Generate(catalog *Catalog) error {
+	if err := os.MkdirAll(g.outputPath, 0755); err != nil {
+		return fmt.Errorf("创建输出目录失败: %w", err)
+	}
+
+	switch g.format {
+	case FormatXCStrings:
+		return g.generateCatalog(catalog)
+	case FormatStrings:
+		for _, locale := range catalog.Locales {
+			if err := g.generateLegacy(catalog, locale); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("无效的iOS字符串格式: %s (必须是 %s/%s)", g.format, FormatXCStrings, FormatStrings)
+	}
+}
+
+// String Catalog的JSON结构，字段按Xcode输出的字母顺序排列
+type xcStrings struct {
+	SourceLanguage string              `json:"sourceLanguage"`
+	Strings        map[string]xcString `json:"strings"`
+	Version        string              `json:"version"`
+}
+
+type xcString struct {
+	Comment         string                    `json:"comment,omitempty"`
+	ExtractionState string                    `json:"extractionState"`
+	Localizations   map[string]xcLocalization `json:"localizations"`
+}
+
+type xcLocalization struct {
+	StringUnit    *xcStringUnit             `json:"stringUnit,omitempty"`
+	Substitutions map[string]xcSubstitution `json:"substitutions,omitempty"`
+	Variations    *xcVariations             `json:"variations,omitempty"`
+}
+
+type xcStringUnit struct {
+	State string `json:"state"`
+	Value string `json:"value"`
+}
+
+type xcVariations struct {
+	Plural map[PluralCategory]xcVariation `json:"plural"`
+}
+
+type xcVariation struct {
+	StringUnit xcStringUnit `json:"stringUnit"`
+}
+
+type xcSubstitution struct {
+	ArgNum          int          `json:"argNum"`
+	FormatSpecifier string       `json:"formatSpecifier"`
+	Variations      xcVariations `json:"variations"`
+}
+
+// generateCatalog 生成 <表名>.xcstrings
+// 只有数量参数的复数字符串直接按复数变化，包含其它参数时通过substitutions只对数量参数变化
+func (g *IOSGenerator) generateCatalog(catalog *Catalog) error {
+	document := xcStrings{
+		SourceLanguage: catalog.BaseLocale,
+		Strings:        make(map[string]xcString),
+		Version:        "1.0",
+	}
+
+	for _, entry := range catalog.Entries {
+		item := xcString{
+			Comment:         naming.SingleLine(entry.Doc()),
+			ExtractionState: "manual",
+			Localizations:   make(map[string]xcLocalization),
+		}
+		for _, locale := range catalog.Locales {
+			translation, ok := entry.Translations[locale]
+			if !ok {
+				continue
+			}
+			if !entry.Plural {
+				item.Localizations[locale] = xcLocalization{StringUnit: translated(iosText(entry, translation.Text, false))}
+				continue
+			}
+
+			variations := xcVariations{Plural: make(map[PluralCategory]xcVariation)}
+			substitute := len(entry.Placeholders) > 1
+			for category, text := range translation.Plural {
+				variations.Plural[category] = xcVariation{StringUnit: *translated(iosText(entry, text, substitute))}
+			}
+			if !substitute {
+				item.Localizations[locale] = xcLocalization{Variations: &variations}
+				continue
+			}
+			item.Localizations[locale] = xcLocalization{
+				StringUnit: translated(pluralFormatKey(entry)),
+				Substitutions: map[string]xcSubstitution{
+					CountPlaceholder: {ArgNum: 1, FormatSpecifier: "lld", Variations: variations},
+				},
+			}
+		}
+		document.Strings[entry.Key] = item
+	}
+
+	// Xcode不转义HTML字符，使用相同的格式以减少版本控制中的差异
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return fmt.Errorf("编码String Catalog失败: %w", err)
+	}
+
+	filePath := filepath.Join(g.outputPath, g.tableName+".xcstrings")
+	if err := os.WriteFile(filePath, buffer.Bytes(), 0644); err != nil {
+		return fmt.Errorf("写入 %s 失败: %w", filePath, err)
+	}
+	return nil
+}
+
+// translated 创建已翻译状态的stringUnit
+func translated(value string) *xcStringUnit {
+	return &xcStringUnit{State: "translated", Value: value}
+}
+
+// generateLegacy 生成 <语言>.lproj/<表名>.strings，有复数字符串时另外生成 .stringsdict
+func (g *IOSGenerator) generateLegacy(catalog *Catalog, locale string) error {
+	lprojPath := filepath.Join(g.outputPath, locale+".lproj")
+	if err := os.MkdirAll(lprojPath, 0755); err != nil {
+		return fmt.Errorf("创建 %s 目录失败: %w", lprojPath, err)
+	}
+
+	var content strings.Builder
+	content.WriteString("/* 由 app-assets-generator 自动生成，请勿手动修改 */\n")
+
+	var plurals []*Entry
+	section := ""
+	for _, entry := range catalog.Entries {
+		translation, ok := entry.Translations[locale]
+		if !ok {
+			continue
+		}
+		if entry.Plural {
+			plurals = append(plurals, entry)
+			continue
+		}
+		if entry.Section != section {
+			section = entry.Section
+			fmt.Fprintf(&content, "\n/* ===== %s ===== */\n", stringsComment(section))
+		}
+		content.WriteString("\n")
+		if doc := entry.Doc(); doc != "" {
+			fmt.Fprintf(&content, "/* %s */\n", stringsComment(doc))
+		}
+		fmt.Fprintf(&content, "\"%s\" = \"%s\";\n", entry.Key, escapeStrings(iosText(entry, translation.Text, false)))
+	}
+
+	filePath := filepath.Join(lprojPath, g.tableName+".strings")
+	if err := os.WriteFile(filePath, []byte(content.String()), 0644); err != nil {
+		return fmt.Errorf("写入 %s 失败: %w", filePath, err)
+	}
+
+	if len(plurals) == 0 {
+		return nil
+	}
+	return g.generateStringsDict(plurals, locale, filepath.Join(lprojPath, g.tableName+".stringsdict"))
+}
+
+// generateStringsDict 生成复数字符串的 .stringsdict
+func (g *IOSGenerator) generateStringsDict(entries []*Entry, locale, filePath string) error {
+	var builder strings.Builder
+	builder.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	builder.WriteString("<!DOCTYPE plist PUBLIC \"-//Apple//DTD PLIST 1.0//EN\" \"http://www.apple.com/DTDs/PropertyList-1.0.dtd\">\n")
+	builder.WriteString("<!-- 由 app-assets-generator 自动生成，请勿手动修改 -->\n")
+	builder.WriteString("<plist version=\"1.0\">\n")
+	builder.WriteString("<dict>\n")
+	for _, entry := range entries {
+		translation := entry.Translations[locale]
+		fmt.Fprintf(&builder, "\t<key>%s</key>\n", entry.Key)
+		builder.WriteString("\t<dict>\n")
+		builder.WriteString("\t\t<key>NSStringLocalizedFormatKey</key>\n")
+		fmt.Fprintf(&builder, "\t\t<string>%s</string>\n", pluralFormatKey(entry))
+		fmt.Fprintf(&builder, "\t\t<key>%s</key>\n", CountPlaceholder)
+		builder.WriteString("\t\t<dict>\n")
+		builder.WriteString("\t\t\t<key>NSStringFormatSpecTypeKey</key>\n")
+		builder.WriteString("\t\t\t<string>NSStringPluralRuleType</string>\n")
+		builder.WriteString("\t\t\t<key>NSStringFormatValueTypeKey</key>\n")
+		builder.WriteString("\t\t\t<string>lld</string>\n")
+		for _, category := range sortedCategories(translation.Plural) {
+			fmt.Fprintf(&builder, "\t\t\t<key>%s</key>\n", category)
+			fmt.Fprintf(&builder, "\t\t\t<string>%s</string>\n", html.EscapeString(iosText(entry, translation.Plural[category], false)))
+		}
+		builder.WriteString("\t\t</dict>\n")
+		builder.WriteString("\t</dict>\n")
+	}
+	builder.WriteString("</dict>\n")
+	builder.WriteString("</plist>\n")
+
+	if err := os.WriteFile(filePath, []byte(builder.String()), 0644); err != nil {
+		return fmt.Errorf("写入 %s 失败: %w", filePath, err)
+	}
+	return nil
+}
+
+// pluralFormatKey 获取复数字符串的格式，数量参数始终是第一个参数
+func pluralFormatKey(entry *Entry) string {
+	if len(entry.Placeholders) > 1 {
+		return "%1$#@" + CountPlaceholder + "@"
+	}
+	return "%#@" + CountPlaceholder + "@"
+}
+
+// iosText 将占位符转换为格式说明符，有多个参数时使用 %1$@ 形式的位置参数
+// substitution为true时数量参数写作 %arg（String Catalog中substitutions的写法）
+func iosText(entry *Entry, text string, substitution bool) string {
+	formatted := entry.Formatted()
+	return renderText(text, func(s string) string {
+		if formatted {
+			return strings.ReplaceAll(s, "%", "%%")
+		}
+		return s
+	}, func(name string) string {
+		if substitution && name == CountPlaceholder {
+			return "%arg"
+		}
+		index := entry.placeholderIndex(name)
+		specifier := iosSpecifier(entry.Placeholders[index-1].Type)
+		if len(entry.Placeholders) > 1 {
+			return fmt.Sprintf("%%%d$%s", index, specifier)
+		}
+		return "%" + specifier
+	})
+}
+
+// iosSpecifier 获取参数类型对应的格式说明符
+func iosSpecifier(typ PlaceholderType) string {
+	switch typ {
+	case TypeInt:
+		return "lld"
+	case TypeDouble:
+		return "f"
+	default:
+		return "@"
+	}
+}
+
+// escapeStrings 转义 .strings 文件中的字符串
+func escapeStrings(text string) string {
+	replacer := strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\n", "\\n", "\t", "\\t")
+	return replacer.Replace(text)
+}
+
+// stringsComment 转换为可以放在 /* */ 注释中的单行文本
+func stringsComment(text string) string {
+	return strings.ReplaceAll(naming.SingleLine(text), "*/", "* /")
+}
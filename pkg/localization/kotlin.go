@@ -0,0 +1,143 @@
+package localization
+
+import (
+	"app-assets-generator/internal/naming"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// kotlinKeywords 作为标识符时需要用反引号转义的Kotlin关键字
+var kotlinKeywords = map[string]bool{
+	"as": true, "break": true, "class": true, "continue": true, "do": true, "else": true,
+	"false": true, "for": true, "fun": true, "if": true, "in": true, "interface": true,
+	"is": true, "null": true, "object": true, "package": true, "return": true, "super": true,
+	"this": true, "throw": true, "true": true, "try": true, "typealias": true, "typeof": true,
+	"val": true, "var": true, "when": true, "while": true,
+}
+
+// KotlinGenerator Kotlin(Jetpack Compose)字符串访问器生成器
+type KotlinGenerator struct {
+	outputPath  string // 输出的.kt文件路径
+	packageName string // 生成代码的包名
+	namespace   string // R类所在的包名（Android namespace）
+}
+
+// NewKotlinGenerator 创建Kotlin访问器生成器，namespace为空时使用packageName
+func NewKotlinGenerator(outputPath, packageName, namespace string) *KotlinGenerator {
+	if namespace == "" {
+		namespace = packageName
+	}
+	return &KotlinGenerator{
+		outputPath:  outputPath,
+		packageName: packageName,
+		namespace:   namespace,
+	}
+}
+
+// Generate 生成AppStrings对象，参数的类型与占位符声明一致
+// 复数字符串通过pluralStringResource读取，数量参数同时用于选择复数类别和格式化
+func (g *KotlinGenerator) Generate(catalog *Catalog) error {
+	if g.packageName == "" {
+		return fmt.Errorf("生成Kotlin代码需要指定包名")
+	}
+	if err := os.MkdirAll(filepath.Dir(g.outputPath), 0755); err != nil {
+		return fmt.Errorf("创建输出目录失败: %w", err)
+	}
+
+	hasPlural := false
+	for _, entry := range catalog.Entries {
+		if entry.Plural {
+			hasPlural = true
+			break
+		}
+	}
+
+	var builder strings.Builder
+	builder.WriteString("// 由 app-assets-generator 自动生成，请勿手动修改\n\n")
+	fmt.Fprintf(&builder, "package %s\n\n", g.packageName)
+	builder.WriteString("import androidx.compose.runtime.Composable\n")
+	builder.WriteString("import androidx.compose.runtime.ReadOnlyComposable\n")
+	if hasPlural {
+		builder.WriteString("import androidx.compose.ui.res.pluralStringResource\n")
+	}
+	builder.WriteString("import androidx.compose.ui.res.stringResource\n")
+	if g.namespace != g.packageName {
+		fmt.Fprintf(&builder, "import %s.R\n", g.namespace)
+	}
+	builder.WriteString("\nobject AppStrings {\n")
+
+	section := ""
+	for i, entry := range catalog.Entries {
+		if entry.Section != section {
+			if section != "" {
+				builder.WriteString("    // endregion\n")
+			}
+			section = entry.Section
+			if i > 0 {
+				builder.WriteString("\n")
+			}
+			fmt.Fprintf(&builder, "    // region %s\n\n", naming.SingleLine(section))
+		}
+
+		if lines := accessorDoc(entry, catalog.BaseLocale); len(lines) > 0 {
+			builder.WriteString("    /**\n")
+			for _, line := range lines {
+				fmt.Fprintf(&builder, "     * %s\n", strings.ReplaceAll(line, "*/", "* /"))
+			}
+			builder.WriteString("     */\n")
+		}
+		name := kotlinIdentifier(naming.Identifier(entry.Key))
+		if len(entry.Placeholders) == 0 {
+			fmt.Fprintf(&builder, "    val %s: String\n", name)
+			builder.WriteString("        @Composable @ReadOnlyComposable\n")
+			fmt.Fprintf(&builder, "        get() = stringResource(R.string.%s)\n", entry.Key)
+			continue
+		}
+
+		var parameters, arguments []string
+		for _, placeholder := range entry.Placeholders {
+			label := kotlinIdentifier(naming.Identifier(placeholder.Name))
+			parameters = append(parameters, fmt.Sprintf("%s: %s", label, kotlinType(placeholder.Type)))
+			arguments = append(arguments, label)
+		}
+		builder.WriteString("    @Composable @ReadOnlyComposable\n")
+		fmt.Fprintf(&builder, "    fun %s(%s): String =\n", name, strings.Join(parameters, ", "))
+		if entry.Plural {
+			fmt.Fprintf(&builder, "        pluralStringResource(R.plurals.%s, %s, %s)\n", entry.Key, arguments[0], strings.Join(arguments, ", "))
+		} else {
+			fmt.Fprintf(&builder, "        stringResource(R.string.%s, %s)\n", entry.Key, strings.Join(arguments, ", "))
+		}
+	}
+	if section != "" {
+		builder.WriteString("    // endregion\n")
+	}
+	builder.WriteString("}\n")
+
+	if err := os.WriteFile(g.outputPath, []byte(builder.String()), 0644); err != nil {
+		return fmt.Errorf("写入 %s 失败: %w", g.outputPath, err)
+	}
+
+	return nil
+}
+
+// kotlinType 获取参数类型对应的Kotlin类型
+func kotlinType(typ PlaceholderType) string {
+	switch typ {
+	case TypeInt:
+		return "Int"
+	case TypeDouble:
+		return "Double"
+	default:
+		return "String"
+	}
+}
+
+// kotlinIdentifier 转义与Kotlin关键字相同的标识符
+func kotlinIdentifier(name string) string {
+	if kotlinKeywords[name] {
+		return "`" + name + "`"
+	}
+	return name
+}
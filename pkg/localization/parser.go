@@ -0,0 +1,389 @@
+package localization
+
+import (
+	"app-assets-generator/internal/diag"
+	"app-assets-generator/internal/yamlutil"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// descriptionKey YAML中键的说明字段，其余字段都是语言代码
+const descriptionKey = "description"
+
+// keyPattern 键同时作为Android资源名称，只能使用小写字母、数字和下划线
+var keyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// javaKeywords 不能作为Android资源名称的Java关键字（R.string.<键>无法编译）
+var javaKeywords = map[string]bool{
+	"abstract": true, "assert": true, "boolean": true, "break": true, "byte": true, "case": true,
+	"catch": true, "char": true, "class": true, "const": true, "continue": true, "default": true,
+	"do": true, "double": true, "else": true, "enum": true, "extends": true, "false": true,
+	"final": true, "finally": true, "float": true, "for": true, "goto": true, "if": true,
+	"implements": true, "import": true, "instanceof": true, "int": true, "interface": true, "long": true,
+	"native": true, "new": true, "null": true, "package": true, "private": true, "protected": true,
+	"public": true, "return": true, "short": true, "static": true, "strictfp": true, "super": true,
+	"switch": true, "synchronized": true, "this": true, "throw": true, "throws": true, "transient": true,
+	"true": true, "try": true, "void": true, "volatile": true, "while": true,
+}
+
+// Parse 解析字符串配置文件，.csv按CSV解析，其它按YAML解析
+// 一次性收集所有带位置信息的错误和警告，只有读取文件失败时才返回error
+func Parse(filePath, baseLocale string) (*Catalog, diag.Diagnostics, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("读取文件失败: %w", err)
+	}
+	if baseLocale == "" {
+		baseLocale = DefaultBaseLocale
+	}
+
+	p := &parser{file: filePath, baseLocale: baseLocale, keys: make(map[string]*Entry)}
+	if strings.EqualFold(filepath.Ext(filePath), ".csv") {
+		p.parseCSV(data)
+	} else {
+		p.parseYAML(data)
+	}
+	catalog := p.check()
+	return catalog, p.diagnostics, nil
+}
+
+// parser 字符串配置解析器，YAML和CSV解析为相同的结构后统一检查
+type parser struct {
+	file        string
+	baseLocale  string
+	entries     []*Entry
+	keys        map[string]*Entry
+	locales     []string
+	diagnostics diag.Diagnostics
+}
+
+// errorAt 记录指定位置的错误
+func (p *parser) errorAt(line, column int, format string, args ...interface{}) {
+	p.add(diag.SeverityError, line, column, format, args...)
+}
+
+// warnAt 记录指定位置的警告
+func (p *parser) warnAt(line, column int, format string, args ...interface{}) {
+	p.add(diag.SeverityWarning, line, column, format, args...)
+}
+
+// add 记录问题
+func (p *parser) add(severity diag.Severity, line, column int, format string, args ...interface{}) {
+	p.diagnostics = append(p.diagnostics, diag.Diagnostic{
+		File:     p.file,
+		Line:     line,
+		Column:   column,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// addEntry 校验键名称并登记新的字符串，键无效或重复时返回nil
+func (p *parser) addEntry(key string, line, column int) *Entry {
+	if !keyPattern.MatchString(key) {
+		p.errorAt(line, column, "键 %s 无效，只能包含小写字母、数字和下划线，且以字母开头", key)
+		return nil
+	}
+	if javaKeywords[key] {
+		p.errorAt(line, column, "键 %s 是Java关键字，不能作为Android资源名称", key)
+		return nil
+	}
+	if _, exists := p.keys[key]; exists {
+		p.errorAt(line, column, "键 %s 重复定义", key)
+		return nil
+	}
+
+	entry := &Entry{Key: key, Translations: make(map[string]*Translation), Line: line, Column: column}
+	p.keys[key] = entry
+	p.entries = append(p.entries, entry)
+	return entry
+}
+
+// addLocale 校验语言代码并记录首次出现的顺序
+func (p *parser) addLocale(locale string, line, column int) bool {
+	for _, existing := range p.locales {
+		if existing == locale {
+			return true
+		}
+	}
+	if !localePattern.MatchString(locale) {
+		hint := ""
+		if strings.Contains(locale, "_") {
+			hint = fmt.Sprintf("，请使用 %s", strings.ReplaceAll(locale, "_", "-"))
+		}
+		p.errorAt(line, column, "语言代码 %s 无效，应为 en、pt-BR、zh-Hans 形式的BCP 47代码%s", locale, hint)
+		return false
+	}
+	p.locales = append(p.locales, locale)
+	return true
+}
+
+// parseYAML 解析YAML配置
+// 每个键是语言代码到翻译的映射，复数字符串的翻译是复数类别到文本的映射
+func (p *parser) parseYAML(data []byte) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		p.errorAt(yamlutil.ErrorLine(err), 1, "解析YAML失败: %s", strings.TrimPrefix(err.Error(), "yaml: "))
+		return
+	}
+	if len(document.Content) == 0 {
+		return // 空文件
+	}
+
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		p.errorAt(root.Line, root.Column, "顶层必须是键到翻译的映射")
+		return
+	}
+
+	// 文档开头的注释属于第一个键，分组注释块对后续所有键生效
+	section := ""
+	headComment := document.HeadComment
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		keyNode, valueNode := root.Content[i], root.Content[i+1]
+		comments := []string{headComment, keyNode.HeadComment}
+		headComment = ""
+		comment := ""
+		for _, block := range comments {
+			blockSection, blockComment := yamlutil.ParseHeadComment(block)
+			if blockSection != "" {
+				section = blockSection
+			}
+			if blockComment != "" {
+				comment = blockComment
+			}
+		}
+
+		entry := p.addEntry(keyNode.Value, keyNode.Line, keyNode.Column)
+		if entry == nil {
+			continue
+		}
+		entry.Comment = comment
+		entry.Section = section
+
+		if valueNode.Kind != yaml.MappingNode {
+			p.errorAt(valueNode.Line, valueNode.Column, "键 %s 必须是语言代码到翻译的映射，如 en: Hello", entry.Key)
+			continue
+		}
+		for j := 0; j+1 < len(valueNode.Content); j += 2 {
+			field, value := valueNode.Content[j], valueNode.Content[j+1]
+			if field.Value == descriptionKey {
+				if value.Kind != yaml.ScalarNode {
+					p.errorAt(value.Line, value.Column, "键 %s 的description必须是字符串", entry.Key)
+					continue
+				}
+				entry.Description = value.Value
+				continue
+			}
+			if !p.addLocale(field.Value, field.Line, field.Column) {
+				continue
+			}
+			if translation := p.decodeTranslation(entry, field.Value, value); translation != nil {
+				entry.Translations[field.Value] = translation
+			}
+		}
+	}
+}
+
+// decodeTranslation 解析一种语言的翻译，空值视为缺少翻译
+func (p *parser) decodeTranslation(entry *Entry, locale string, node *yaml.Node) *Translation {
+	translation := &Translation{Line: node.Line, Column: node.Column}
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if node.Tag == "!!null" || node.Value == "" {
+			return nil
+		}
+		translation.Text = node.Value
+	case yaml.MappingNode:
+		translation.Plural = make(map[PluralCategory]string)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			category := PluralCategory(key.Value)
+			if !isPluralCategory(category) {
+				p.errorAt(key.Line, key.Column, "键 %s 的 %s 翻译包含未知的复数类别 %s，可用类别: zero, one, two, few, many, other", entry.Key, locale, key.Value)
+				continue
+			}
+			if value.Kind != yaml.ScalarNode {
+				p.errorAt(value.Line, value.Column, "键 %s 的 %s 翻译中 %s 必须是字符串", entry.Key, locale, key.Value)
+				continue
+			}
+			translation.Plural[category] = value.Value
+		}
+	default:
+		p.errorAt(node.Line, node.Column, "键 %s 的 %s 翻译必须是字符串或复数类别到字符串的映射", entry.Key, locale)
+		return nil
+	}
+	return translation
+}
+
+// check 检查复数形式、占位符和缺失的翻译，生成字符串表
+func (p *parser) check() *Catalog {
+	base := p.baseLocale
+	if len(p.entries) > 0 && !slices.Contains(p.locales, base) {
+		p.errorAt(1, 1, "没有基础语言 %s 的翻译，可以通过 --base-locale 指定其它基础语言", base)
+		return nil
+	}
+
+	catalog := &Catalog{BaseLocale: base, Locales: []string{base}}
+	for _, locale := range p.locales {
+		if locale != base {
+			catalog.Locales = append(catalog.Locales, locale)
+		}
+	}
+
+	for _, entry := range p.entries {
+		if p.checkEntry(entry, catalog.Locales) {
+			catalog.Entries = append(catalog.Entries, entry)
+		}
+	}
+	return catalog
+}
+
+// checkEntry 检查一个字符串，确定复数形式和参数列表，存在错误时返回false
+func (p *parser) checkEntry(entry *Entry, locales []string) bool {
+	base := locales[0]
+	baseTranslation, ok := entry.Translations[base]
+	if !ok {
+		p.errorAt(entry.Line, entry.Column, "键 %s 缺少基础语言 %s 的翻译", entry.Key, base)
+		return false
+	}
+	entry.Plural = baseTranslation.Plural != nil
+
+	valid := true
+	types := make(map[string]PlaceholderType)
+	names := make(map[string][]string) // 语言 -> 出现的占位符（按首次出现的顺序）
+	for _, locale := range locales {
+		translation, ok := entry.Translations[locale]
+		if !ok {
+			p.warnAt(entry.Line, entry.Column, "键 %s 缺少 %s 翻译，将显示基础语言的文本", entry.Key, locale)
+			continue
+		}
+
+		if (translation.Plural != nil) != entry.Plural {
+			if entry.Plural {
+				p.errorAt(translation.Line, translation.Column, "键 %s 在基础语言中是复数字符串，%s 翻译也必须按复数类别定义", entry.Key, locale)
+			} else {
+				p.errorAt(translation.Line, translation.Column, "键 %s 在基础语言中不是复数字符串，%s 翻译不能按复数类别定义", entry.Key, locale)
+			}
+			valid = false
+			continue
+		}
+		if entry.Plural {
+			if _, ok := translation.Plural["other"]; !ok {
+				p.errorAt(translation.Line, translation.Column, "键 %s 的 %s 翻译缺少复数类别 other", entry.Key, locale)
+				valid = false
+			}
+		}
+
+		for _, text := range translation.Forms() {
+			segments, err := parseText(text)
+			if err != nil {
+				p.errorAt(translation.Line, translation.Column, "键 %s 的 %s 翻译: %v", entry.Key, locale, err)
+				valid = false
+				continue
+			}
+			for _, s := range segments {
+				if s.placeholder == "" {
+					continue
+				}
+				if !slices.Contains(names[locale], s.placeholder) {
+					names[locale] = append(names[locale], s.placeholder)
+				}
+				if s.typ == "" {
+					continue
+				}
+				if existing, ok := types[s.placeholder]; ok && existing != s.typ {
+					p.errorAt(translation.Line, translation.Column, "键 %s 的占位符 {%s} 类型不一致: %s 和 %s", entry.Key, s.placeholder, existing, s.typ)
+					valid = false
+					continue
+				}
+				types[s.placeholder] = s.typ
+			}
+		}
+	}
+	if !valid {
+		return false
+	}
+
+	// 复数字符串的数量参数始终是第一个参数，即使某些复数类别的文本中没有使用
+	if entry.Plural {
+		if typ, ok := types[CountPlaceholder]; ok && typ != TypeInt {
+			p.errorAt(baseTranslation.Line, baseTranslation.Column, "键 %s 是复数字符串，数量参数 {%s} 必须是int类型", entry.Key, CountPlaceholder)
+			return false
+		}
+		entry.Placeholders = append(entry.Placeholders, Placeholder{Name: CountPlaceholder, Type: TypeInt})
+	}
+	for _, name := range names[base] {
+		if entry.Plural && name == CountPlaceholder {
+			continue
+		}
+		typ := types[name]
+		if typ == "" {
+			typ = TypeString
+		}
+		entry.Placeholders = append(entry.Placeholders, Placeholder{Name: name, Type: typ})
+	}
+
+	// 各语言使用的占位符必须与基础语言一致，否则运行时参数会错位或缺失
+	for _, locale := range locales[1:] {
+		translation, ok := entry.Translations[locale]
+		if !ok {
+			continue
+		}
+		for _, name := range names[locale] {
+			if entry.placeholderIndex(name) == 0 {
+				p.errorAt(translation.Line, translation.Column, "键 %s 的 %s 翻译使用了基础语言 %s 中不存在的占位符 {%s}", entry.Key, locale, base, name)
+				valid = false
+			}
+		}
+		for _, placeholder := range entry.Placeholders {
+			if entry.Plural && placeholder.Name == CountPlaceholder {
+				continue
+			}
+			if !slices.Contains(names[locale], placeholder.Name) {
+				p.errorAt(translation.Line, translation.Column, "键 %s 的 %s 翻译缺少占位符 {%s}", entry.Key, locale, placeholder.Name)
+				valid = false
+			}
+		}
+	}
+	return valid
+}
+
+// isPluralCategory 判断是否为CLDR复数类别
+func isPluralCategory(category PluralCategory) bool {
+	for _, c := range pluralCategories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedCategories 获取复数翻译中的类别，按CLDR顺序
+func sortedCategories(plural map[PluralCategory]string) []PluralCategory {
+	var categories []PluralCategory
+	for category := range plural {
+		categories = append(categories, category)
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		return categoryIndex(categories[i]) < categoryIndex(categories[j])
+	})
+	return categories
+}
+
+// categoryIndex 获取复数类别在CLDR顺序中的位置
+func categoryIndex(category PluralCategory) int {
+	for i, c := range pluralCategories {
+		if c == category {
+			return i
+		}
+	}
+	return len(pluralCategories)
+}
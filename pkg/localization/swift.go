@@ -0,0 +1,145 @@
+package localization
+
+import (
+	"app-assets-generator/internal/naming"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DefaultSwiftType 默认的Swift访问器枚举名称
+const DefaultSwiftType = "L10n"
+
+// swiftKeywords 作为标识符时需要用反引号转义的Swift关键字
+var swiftKeywords = map[string]bool{
+	"as": true, "associatedtype": true, "break": true, "case": true, "catch": true, "class": true,
+	"continue": true, "default": true, "defer": true, "deinit": true, "do": true, "else": true,
+	"enum": true, "extension": true, "fallthrough": true, "false": true, "fileprivate": true, "for": true,
+	"func": true, "guard": true, "if": true, "import": true, "in": true, "init": true,
+	"inout": true, "internal": true, "is": true, "let": true, "nil": true, "open": true,
+	"operator": true, "private": true, "protocol": true, "public": true, "repeat": true, "rethrows": true,
+	"return": true, "self": true, "static": true, "struct": true, "subscript": true, "super": true,
+	"switch": true, "throw": true, "throws": true, "true": true, "try": true, "typealias": true,
+	"var": true, "where": true, "while": true,
+}
+
+// SwiftGenerator Swift字符串访问器生成器
+type SwiftGenerator struct {
+	outputPath string // 输出的.swift文件路径
+	typeName   string // 枚举名称
+	tableName  string // 字符串表名称
+}
+
+// NewSwiftGenerator 创建Swift访问器生成器，typeName为空时使用DefaultSwiftType
+func NewSwiftGenerator(outputPath, typeName, tableName string) *SwiftGenerator {
+	if typeName == "" {
+		typeName = DefaultSwiftType
+	}
+	if tableName == "" {
+		tableName = DefaultTableName
+	}
+	return &SwiftGenerator{
+		outputPath: outputPath,
+		typeName:   typeName,
+		tableName:  tableName,
+	}
+}
+
+// Generate 生成字符串访问器，没有参数的字符串生成静态属性，有参数的生成带类型参数的静态方法
+// 复数字符串的数量参数不带标签，如 L10n.itemsCount(3)
+func (g *SwiftGenerator) Generate(catalog *Catalog) error {
+	if err := os.MkdirAll(filepath.Dir(g.outputPath), 0755); err != nil {
+		return fmt.Errorf("创建输出目录失败: %w", err)
+	}
+
+	var builder strings.Builder
+	builder.WriteString("// 由 app-assets-generator 自动生成，请勿手动修改\n\n")
+	builder.WriteString("import Foundation\n\n")
+	fmt.Fprintf(&builder, "public enum %s {\n", g.typeName)
+
+	section := ""
+	for i, entry := range catalog.Entries {
+		if entry.Section != section {
+			section = entry.Section
+			if i > 0 {
+				builder.WriteString("\n")
+			}
+			fmt.Fprintf(&builder, "    // MARK: - %s\n\n", naming.SingleLine(section))
+		}
+
+		for _, line := range accessorDoc(entry, catalog.BaseLocale) {
+			fmt.Fprintf(&builder, "    /// %s\n", line)
+		}
+		name := swiftIdentifier(naming.Identifier(entry.Key))
+		key := strconv.Quote(entry.Key)
+		if len(entry.Placeholders) == 0 {
+			fmt.Fprintf(&builder, "    public static var %s: String { tr(%s) }\n", name, key)
+			continue
+		}
+
+		var parameters, arguments []string
+		for j, placeholder := range entry.Placeholders {
+			label := swiftIdentifier(naming.Identifier(placeholder.Name))
+			if entry.Plural && j == 0 {
+				parameters = append(parameters, fmt.Sprintf("_ %s: %s", label, swiftType(placeholder.Type)))
+			} else {
+				parameters = append(parameters, fmt.Sprintf("%s: %s", label, swiftType(placeholder.Type)))
+			}
+			arguments = append(arguments, label)
+		}
+		fmt.Fprintf(&builder, "    public static func %s(%s) -> String {\n", name, strings.Join(parameters, ", "))
+		fmt.Fprintf(&builder, "        tr(%s, %s)\n", key, strings.Join(arguments, ", "))
+		builder.WriteString("    }\n")
+	}
+	builder.WriteString("}\n\n")
+
+	// 通过NSLocalizedString读取，String Catalog和 .strings/.stringsdict 都适用；复数由格式化时按数量选择
+	fmt.Fprintf(&builder, "private extension %s {\n", g.typeName)
+	builder.WriteString("    static func tr(_ key: String, _ args: CVarArg...) -> String {\n")
+	fmt.Fprintf(&builder, "        let format = NSLocalizedString(key, tableName: %s, bundle: .main, comment: \"\")\n", strconv.Quote(g.tableName))
+	builder.WriteString("        guard !args.isEmpty else { return format }\n")
+	builder.WriteString("        return String(format: format, locale: Locale.current, arguments: args)\n")
+	builder.WriteString("    }\n")
+	builder.WriteString("}\n")
+
+	if err := os.WriteFile(g.outputPath, []byte(builder.String()), 0644); err != nil {
+		return fmt.Errorf("写入 %s 失败: %w", g.outputPath, err)
+	}
+
+	return nil
+}
+
+// accessorDoc 获取访问器的文档注释：说明文字和基础语言的文本
+func accessorDoc(entry *Entry, baseLocale string) []string {
+	lines := naming.DocLines(entry.Doc())
+	translation := entry.Translations[baseLocale]
+	if !entry.Plural {
+		return append(lines, naming.SingleLine(plainText(translation.Text)))
+	}
+	for _, category := range sortedCategories(translation.Plural) {
+		lines = append(lines, fmt.Sprintf("%s: %s", category, naming.SingleLine(plainText(translation.Plural[category]))))
+	}
+	return lines
+}
+
+// swiftType 获取参数类型对应的Swift类型
+func swiftType(typ PlaceholderType) string {
+	switch typ {
+	case TypeInt:
+		return "Int"
+	case TypeDouble:
+		return "Double"
+	default:
+		return "String"
+	}
+}
+
+// swiftIdentifier 转义与Swift关键字相同的标识符
+func swiftIdentifier(name string) string {
+	if swiftKeywords[name] {
+		return "`" + name + "`"
+	}
+	return name
+}
@@ -0,0 +1,202 @@
+package localization
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DefaultBaseLocale 默认的基础语言，Android中写入 values/ 目录
+const DefaultBaseLocale = "en"
+
+// PlaceholderType 占位符类型
+type PlaceholderType string
+
+const (
+	TypeString PlaceholderType = "string" // 字符串，默认类型
+	TypeInt    PlaceholderType = "int"    // 整数
+	TypeDouble PlaceholderType = "double" // 浮点数
+)
+
+// CountPlaceholder 复数字符串的数量参数名称，始终是第一个参数
+const CountPlaceholder = "count"
+
+// PluralCategory CLDR复数类别
+type PluralCategory string
+
+// pluralCategories 所有复数类别，按CLDR顺序
+var pluralCategories = []PluralCategory{"zero", "one", "two", "few", "many", "other"}
+
+// Placeholder 字符串中的参数
+type Placeholder struct {
+	Name string          // 参数名称，如 name
+	Type PlaceholderType // 参数类型
+}
+
+// Translation 一种语言的翻译
+type Translation struct {
+	Text   string                    // 普通字符串的文本
+	Plural map[PluralCategory]string // 复数字符串各类别的文本，普通字符串为nil
+
+	Line   int // 在输入文件中的位置，用于报告问题
+	Column int
+}
+
+// Forms 获取翻译中所有的文本，复数按CLDR顺序
+func (t *Translation) Forms() []string {
+	if t.Plural == nil {
+		return []string{t.Text}
+	}
+	var forms []string
+	for _, category := range pluralCategories {
+		if text, ok := t.Plural[category]; ok {
+			forms = append(forms, text)
+		}
+	}
+	return forms
+}
+
+// Entry 一个本地化字符串
+type Entry struct {
+	Key          string                  // 键，同时是Android资源名称，如 welcome_message
+	Plural       bool                    // 是否为复数字符串
+	Placeholders []Placeholder           // 参数，按基础语言中出现的顺序；复数字符串的第一个参数为count
+	Translations map[string]*Translation // 语言代码 -> 翻译
+
+	Description string // 说明文字，提供给翻译人员
+	Comment     string // 键前的注释
+	Section     string // 所属分组（来自YAML中的分组注释块）
+
+	Line   int // 键在输入文件中的位置
+	Column int
+}
+
+// Doc 获取说明文字，优先使用description字段，其次使用YAML注释
+func (e *Entry) Doc() string {
+	if e.Description != "" {
+		return e.Description
+	}
+	return e.Comment
+}
+
+// Formatted 判断是否需要按格式字符串处理（包含参数或为复数），此时文本中的%需要转义
+func (e *Entry) Formatted() bool {
+	return e.Plural || len(e.Placeholders) > 0
+}
+
+// placeholderIndex 获取参数的位置（从1开始），不存在时返回0
+func (e *Entry) placeholderIndex(name string) int {
+	for i, placeholder := range e.Placeholders {
+		if placeholder.Name == name {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// Catalog 解析后的字符串表
+type Catalog struct {
+	BaseLocale string   // 基础语言
+	Locales    []string // 所有语言，基础语言在前，其余按首次出现的顺序
+	Entries    []*Entry // 字符串，按文件顺序
+}
+
+// segment 文本片段，placeholder为空时是普通文本
+type segment struct {
+	text        string
+	placeholder string
+	typ         PlaceholderType // 文本中显式声明的类型，未声明时为空
+}
+
+// placeholderNamePattern 占位符名称，同时用作Swift/Kotlin的参数名
+var placeholderNamePattern = regexp.MustCompile(`^[a-z][A-Za-z0-9_]*$`)
+
+// parseText 将文本拆分为普通文本和占位符
+// 占位符写作 {name} 或 {name:type}，{{ 和 }} 表示字面的花括号
+func parseText(text string) ([]segment, error) {
+	var segments []segment
+	var literal strings.Builder
+	flush := func() {
+		if literal.Len() > 0 {
+			segments = append(segments, segment{text: literal.String()})
+			literal.Reset()
+		}
+	}
+
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; {
+		case c == '{' && i+1 < len(text) && text[i+1] == '{':
+			literal.WriteByte('{')
+			i++
+		case c == '}' && i+1 < len(text) && text[i+1] == '}':
+			literal.WriteByte('}')
+			i++
+		case c == '}':
+			return nil, fmt.Errorf("多余的 }，字面的花括号需要写作 }}")
+		case c == '{':
+			end := strings.IndexByte(text[i:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("占位符缺少 }，字面的花括号需要写作 {{")
+			}
+			body := text[i+1 : i+end]
+			name, typ, hasType := strings.Cut(body, ":")
+			if !placeholderNamePattern.MatchString(name) {
+				return nil, fmt.Errorf("占位符 {%s} 的名称无效，只能包含字母、数字和下划线，且以小写字母开头", body)
+			}
+			if hasType && typ != string(TypeString) && typ != string(TypeInt) && typ != string(TypeDouble) {
+				return nil, fmt.Errorf("占位符 {%s} 的类型 %s 无效，可用类型: string, int, double", body, typ)
+			}
+			flush()
+			segments = append(segments, segment{placeholder: name, typ: PlaceholderType(typ)})
+			i += end
+		default:
+			literal.WriteByte(c)
+		}
+	}
+	flush()
+	return segments, nil
+}
+
+// renderText 将文本中的占位符替换为平台的格式说明符，普通文本经过escape处理
+func renderText(text string, escape func(string) string, specifier func(name string) string) string {
+	segments, err := parseText(text)
+	if err != nil {
+		return escape(text) // 解析阶段已经报告过错误，不会走到这里
+	}
+	var builder strings.Builder
+	for _, s := range segments {
+		if s.placeholder == "" {
+			builder.WriteString(escape(s.text))
+		} else {
+			builder.WriteString(specifier(s.placeholder))
+		}
+	}
+	return builder.String()
+}
+
+// plainText 获取文本中的字面内容，占位符保留 {name} 形式，用于文档注释
+func plainText(text string) string {
+	return renderText(text, func(s string) string { return s }, func(name string) string {
+		return "{" + name + "}"
+	})
+}
+
+// localePattern BCP 47语言代码：语言[-文字][-地区]，如 en、pt-BR、zh-Hans、zh-Hant-TW
+var localePattern = regexp.MustCompile(`^([a-z]{2,3})(?:-([A-Z][a-z]{3}))?(?:-([A-Z]{2}|[0-9]{3}))?$`)
+
+// AndroidValuesDir 获取语言对应的Android values目录名称
+// 如 en -> values-en，pt-BR -> values-pt-rBR，带文字代码时使用BCP 47形式 zh-Hans -> values-b+zh+Hans
+func AndroidValuesDir(locale string) string {
+	match := localePattern.FindStringSubmatch(locale)
+	if match == nil {
+		return "values-" + locale
+	}
+	language, script, region := match[1], match[2], match[3]
+	if script != "" || len(region) == 3 {
+		return "values-b+" + strings.ReplaceAll(locale, "-", "+")
+	}
+	if region != "" {
+		return fmt.Sprintf("values-%s-r%s", language, region)
+	}
+	return "values-" + language
+}
@@ -0,0 +1,181 @@
+package splash
+
+import (
+	"app-assets-generator/pkg/svg"
+	"fmt"
+	"image"
+	"image/draw"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// splashIconSize Android 12启动画面图标的尺寸（dp），没有图标背景时为288dp
+const splashIconSize = 288
+
+// splashIconMask 启动画面图标中可见的圆形区域直径（dp），圆形以外的部分被遮罩裁掉
+const splashIconMask = 192
+
+// nightQualifier 深色模式的资源限定符
+const nightQualifier = "night"
+
+// splashDensity 启动画面图标的屏幕密度
+type splashDensity struct {
+	Name  string  // 密度名称
+	Scale float64 // 相对于mdpi的缩放比例
+}
+
+// splashDensities 位图标志生成的密度
+var splashDensities = []splashDensity{
+	{Name: "mdpi", Scale: 1},
+	{Name: "hdpi", Scale: 1.5},
+	{Name: "xhdpi", Scale: 2},
+	{Name: "xxhdpi", Scale: 3},
+	{Name: "xxxhdpi", Scale: 4},
+}
+
+// pixels 获取dp尺寸在该密度下的像素尺寸
+func (d splashDensity) pixels(dp float64) int {
+	return int(math.Round(dp * d.Scale))
+}
+
+// logoBox 标志较长边的尺寸（dp）：缩放到圆形遮罩的内切正方形，任何宽高比的标志都不会被裁掉
+var logoBox = splashIconMask / math.Sqrt2
+
+// GenerateAndroid 生成Android 12 SplashScreen的启动画面图标和主题
+// values/splash_themes.xml 为androidx.core:core-splashscreen兼容库的主题，
+// values-v31/splash_themes.xml 同时设置Android 12的系统属性
+func (g *Generator) GenerateAndroid() error {
+	if _, err := g.background(); err != nil {
+		return err
+	}
+
+	if err := g.writeAndroidLogo(g.inputPath, ""); err != nil {
+		return err
+	}
+	if g.options.DarkLogo != "" {
+		if err := g.writeAndroidLogo(g.options.DarkLogo, nightQualifier); err != nil {
+			return err
+		}
+	} else if err := g.removeAndroidLogos(nightQualifier, true, true); err != nil {
+		// 不再指定深色标志时删除之前生成的深色图标，避免深色模式继续使用旧的图标
+		return err
+	}
+
+	if err := writeFile(filepath.Join(g.outputPath, "values", themesFileName), g.themesXML(false)); err != nil {
+		return err
+	}
+	return writeFile(filepath.Join(g.outputPath, "values-v31", themesFileName), g.themesXML(true))
+}
+
+// writeAndroidLogo 生成启动画面图标：SVG生成VectorDrawable，位图生成各密度的PNG
+func (g *Generator) writeAndroidLogo(path, qualifier string) error {
+	l, err := g.loadLogo(path)
+	if err != nil {
+		return err
+	}
+
+	if l.doc != nil {
+		data, warnings := paddedDocument(l.doc).VectorDrawable()
+		for _, warning := range warnings {
+			g.warnings = append(g.warnings, fmt.Sprintf("%s: %s", filepath.Base(path), warning))
+		}
+		if err := writeFile(filepath.Join(g.outputPath, drawableDirectory(qualifier, ""), androidLogoName+".xml"), data); err != nil {
+			return err
+		}
+		return g.removeAndroidLogos(qualifier, false, true)
+	}
+
+	g.checkResolution(path, l, logoBox*splashDensities[len(splashDensities)-1].Scale)
+	for _, density := range splashDensities {
+		img := iconLayer(l, density)
+		if err := writePNG(filepath.Join(g.outputPath, drawableDirectory(qualifier, density.Name), androidLogoName+".png"), img); err != nil {
+			return err
+		}
+	}
+	return g.removeAndroidLogos(qualifier, true, false)
+}
+
+// removeAndroidLogos 删除之前生成、本次不再使用的启动画面图标（如在SVG和位图之间切换后）
+func (g *Generator) removeAndroidLogos(qualifier string, vector, bitmap bool) error {
+	var paths []string
+	if vector {
+		paths = append(paths, filepath.Join(g.outputPath, drawableDirectory(qualifier, ""), androidLogoName+".xml"))
+	}
+	if bitmap {
+		for _, density := range splashDensities {
+			paths = append(paths, filepath.Join(g.outputPath, drawableDirectory(qualifier, density.Name), androidLogoName+".png"))
+		}
+	}
+	for _, path := range paths {
+		if err := os.Remove(path); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return fmt.Errorf("删除旧的启动画面图标 %s 失败: %w", path, err)
+		}
+		// 目录中还有其它资源时删除失败，保留目录
+		os.Remove(filepath.Dir(path))
+	}
+	return nil
+}
+
+// drawableDirectory 获取带限定符的drawable目录，如 drawable、drawable-night-xxhdpi
+func drawableDirectory(qualifiers ...string) string {
+	parts := []string{"drawable"}
+	for _, qualifier := range qualifiers {
+		if qualifier != "" {
+			parts = append(parts, qualifier)
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+// iconLayer 获取指定密度的288dp启动画面图标，标志居中缩放到圆形遮罩的内切正方形
+func iconLayer(l *logo, density splashDensity) *image.NRGBA {
+	size := density.pixels(splashIconSize)
+	content := l.renderFit(logoBox * density.Scale)
+	layer := image.NewNRGBA(image.Rect(0, 0, size, size))
+	offset := image.Pt((size-content.Rect.Dx())/2, (size-content.Rect.Dy())/2)
+	draw.Draw(layer, content.Rect.Add(offset), content, image.Point{}, draw.Src)
+	return layer
+}
+
+// paddedDocument 获取扩展为正方形viewBox的文档副本，原有内容居中缩放到288dp图标中的logoBox
+func paddedDocument(doc *svg.Document) *svg.Document {
+	padded := *doc
+	viewBox := doc.ViewBox
+	side := math.Max(viewBox.Width, viewBox.Height) * splashIconSize / logoBox
+	padded.ViewBox = svg.Rect{
+		X:      viewBox.X + viewBox.Width/2 - side/2,
+		Y:      viewBox.Y + viewBox.Height/2 - side/2,
+		Width:  side,
+		Height: side,
+	}
+	padded.Width, padded.Height = splashIconSize, splashIconSize
+	return &padded
+}
+
+// themesXML 生成启动画面主题，platform为true时同时设置Android 12的系统属性
+func (g *Generator) themesXML(platform bool) []byte {
+	background := "@color/" + g.options.Background
+	icon := "@drawable/" + androidLogoName
+
+	var out strings.Builder
+	out.WriteString(`<?xml version="1.0" encoding="utf-8"?>` + "\n")
+	out.WriteString("<!-- 由 app-assets-generator 自动生成，请勿手动修改 -->\n")
+	out.WriteString("<resources>\n")
+	out.WriteString("    <!-- 在AndroidManifest.xml中设置为启动Activity的主题，启动后调用installSplashScreen() -->\n")
+	fmt.Fprintf(&out, "    <style name=\"%s\" parent=\"Theme.SplashScreen\">\n", g.options.Theme)
+	if platform {
+		fmt.Fprintf(&out, "        <item name=\"android:windowSplashScreenBackground\">%s</item>\n", background)
+		fmt.Fprintf(&out, "        <item name=\"android:windowSplashScreenAnimatedIcon\">%s</item>\n", icon)
+	}
+	fmt.Fprintf(&out, "        <item name=\"windowSplashScreenBackground\">%s</item>\n", background)
+	fmt.Fprintf(&out, "        <item name=\"windowSplashScreenAnimatedIcon\">%s</item>\n", icon)
+	fmt.Fprintf(&out, "        <item name=\"postSplashScreenTheme\">@style/%s</item>\n", g.options.PostSplashTheme)
+	out.WriteString("    </style>\n")
+	out.WriteString("</resources>\n")
+	return []byte(out.String())
+}
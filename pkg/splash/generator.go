@@ -0,0 +1,229 @@
+package splash
+
+import (
+	"app-assets-generator/pkg/color"
+	assetimage "app-assets-generator/pkg/image"
+	"app-assets-generator/pkg/svg"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/jpeg" // 注册JPEG解码器
+	_ "image/png"  // 注册PNG解码器
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DefaultIOSLogoSize iOS启动画面标志的默认点尺寸（较长边），与Android启动画面图标的可见区域大致相同
+const DefaultIOSLogoSize = 136
+
+// DefaultTheme 默认的Android启动画面主题名称
+const DefaultTheme = "Theme.App.Starting"
+
+// DefaultPostSplashTheme 默认的启动画面结束后切换到的Android主题
+const DefaultPostSplashTheme = "Theme.App"
+
+// LaunchScreen 类型
+const (
+	LaunchScreenPlist      = "plist"      // Info.plist的UILaunchScreen配置
+	LaunchScreenStoryboard = "storyboard" // LaunchScreen.storyboard
+)
+
+// 生成的资源名称
+const (
+	logoImageSet    = "LaunchLogo"       // iOS标志图片集
+	backgroundColor = "LaunchBackground" // iOS背景颜色集
+	androidLogoName = "splash_logo"      // Android启动画面图标
+	darkSuffix      = "_dark"            // iOS深色标志的文件名后缀
+	themesFileName  = "splash_themes.xml"
+	plistFileName   = "UILaunchScreen.plist"
+	storyboardName  = "LaunchScreen.storyboard"
+)
+
+// Generator 启动画面生成器
+type Generator struct {
+	inputPath  string                            // 标志图片（SVG或PNG）
+	outputPath string                            // 输出目录
+	options    Options                           // 生成选项
+	logos      map[string]*logo                  // 读取后的标志，按路径缓存
+	colors     map[string]*color.ColorDefinition // 解析后的颜色配置
+	warnings   []string
+}
+
+// Options 生成选项
+type Options struct {
+	DarkLogo   string // 深色模式的标志图片，为空时深色模式使用同一个标志
+	Background string // 背景色使用的颜色名称，必须在ColorsPath中定义，深色值用于深色模式
+	ColorsPath string // 颜色配置文件（colors.yaml）
+
+	CatalogPath  string  // 已有的Assets.xcassets路径，设置后iOS图片集和颜色集写入该目录
+	IOSLogoSize  float64 // iOS标志的点尺寸（较长边），为0时使用 DefaultIOSLogoSize
+	LaunchScreen string  // iOS启动画面的形式: plist/storyboard，为空时为plist
+
+	Theme           string // Android启动画面主题名称，为空时使用 DefaultTheme
+	PostSplashTheme string // 启动画面结束后切换到的主题，为空时使用 DefaultPostSplashTheme
+}
+
+// NewGenerator 创建新的生成器
+func NewGenerator(inputPath, outputPath string, options Options) *Generator {
+	if options.IOSLogoSize == 0 {
+		options.IOSLogoSize = DefaultIOSLogoSize
+	}
+	if options.LaunchScreen == "" {
+		options.LaunchScreen = LaunchScreenPlist
+	}
+	if options.Theme == "" {
+		options.Theme = DefaultTheme
+	}
+	if options.PostSplashTheme == "" {
+		options.PostSplashTheme = DefaultPostSplashTheme
+	}
+	return &Generator{
+		inputPath:  inputPath,
+		outputPath: outputPath,
+		options:    options,
+		logos:      make(map[string]*logo),
+	}
+}
+
+// Warnings 获取生成过程中的警告
+func (g *Generator) Warnings() []string {
+	return g.warnings
+}
+
+// background 获取背景色的颜色定义，颜色必须在颜色配置中定义且不能是渐变色
+func (g *Generator) background() (*color.ColorDefinition, error) {
+	if g.colors == nil {
+		colors, err := color.ParseYAML(g.options.ColorsPath)
+		if err != nil {
+			return nil, fmt.Errorf("解析颜色配置失败: %w", err)
+		}
+		g.colors = colors
+	}
+
+	name := g.options.Background
+	definition, ok := g.colors[name]
+	if !ok {
+		return nil, fmt.Errorf("颜色 %s 在 %s 中不存在", name, g.options.ColorsPath)
+	}
+	if definition.IsGradient() {
+		return nil, fmt.Errorf("颜色 %s 是渐变色，不能作为启动画面的背景", name)
+	}
+	return definition, nil
+}
+
+// logo 启动画面标志：位图按目标尺寸缩放，SVG按目标尺寸渲染
+type logo struct {
+	bitmap image.Image
+	doc    *svg.Document
+}
+
+// loadLogo 读取标志图片
+func (g *Generator) loadLogo(path string) (*logo, error) {
+	if l, ok := g.logos[path]; ok {
+		return l, nil
+	}
+
+	var l *logo
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".svg":
+		doc, err := svg.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, warning := range doc.Warnings {
+			g.warnings = append(g.warnings, fmt.Sprintf("%s: %s", filepath.Base(path), warning))
+		}
+		l = &logo{doc: doc}
+	case ".png", ".jpg", ".jpeg":
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("读取标志 %s 失败: %w", filepath.Base(path), err)
+		}
+		defer file.Close()
+		img, _, err := image.Decode(file)
+		if err != nil {
+			return nil, fmt.Errorf("解码标志 %s 失败: %w", filepath.Base(path), err)
+		}
+		l = &logo{bitmap: img}
+	default:
+		return nil, fmt.Errorf("不支持的标志格式: %s (必须是PNG、JPEG或SVG)", filepath.Ext(path))
+	}
+
+	g.logos[path] = l
+	return l, nil
+}
+
+// checkResolution 位图标志的较长边小于需要的像素尺寸时记录警告，放大后会变得模糊
+func (g *Generator) checkResolution(path string, l *logo, pixels float64) {
+	if l.bitmap == nil {
+		return
+	}
+	width, height := l.size()
+	if math.Max(width, height) < math.Round(pixels) {
+		g.warnings = append(g.warnings, fmt.Sprintf("%s: 标志为 %sx%s 像素，较长边小于需要的 %s 像素，放大后会变得模糊",
+			filepath.Base(path), formatSize(width), formatSize(height), formatSize(math.Round(pixels))))
+	}
+}
+
+// size 标志的尺寸，位图为像素尺寸，SVG为显示尺寸
+func (l *logo) size() (float64, float64) {
+	if l.doc != nil {
+		return l.doc.Width, l.doc.Height
+	}
+	bounds := l.bitmap.Bounds()
+	return float64(bounds.Dx()), float64(bounds.Dy())
+}
+
+// fit 获取保持宽高比、较长边为box时的尺寸
+func (l *logo) fit(box float64) (float64, float64) {
+	width, height := l.size()
+	scale := box / math.Max(width, height)
+	return width * scale, height * scale
+}
+
+// render 获取指定像素尺寸的标志
+func (l *logo) render(width, height int) *image.NRGBA {
+	if l.doc != nil {
+		return assetimage.RenderSVG(l.doc, assetimage.Size{Width: float64(width), Height: float64(height)}, 1)
+	}
+	bounds := l.bitmap.Bounds()
+	if bounds.Dx() == width && bounds.Dy() == height {
+		img := image.NewNRGBA(image.Rect(0, 0, width, height))
+		draw.Draw(img, img.Bounds(), l.bitmap, bounds.Min, draw.Src)
+		return img
+	}
+	return assetimage.Resize(l.bitmap, width, height)
+}
+
+// renderFit 获取较长边为box像素的标志
+func (l *logo) renderFit(box float64) *image.NRGBA {
+	width, height := l.fit(box)
+	return l.render(int(math.Max(1, math.Round(width))), int(math.Max(1, math.Round(height))))
+}
+
+// writePNG 写入PNG文件
+func writePNG(path string, img image.Image) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("创建目录 %s 失败: %w", filepath.Dir(path), err)
+	}
+	return assetimage.WriteImage(path, img)
+}
+
+// writeFile 写入文本文件
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("创建目录 %s 失败: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("写入 %s 失败: %w", path, err)
+	}
+	return nil
+}
+
+// formatSize 格式化尺寸
+func formatSize(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,222 @@
+package splash
+
+import (
+	"app-assets-generator/pkg/color"
+	"app-assets-generator/pkg/xcassets"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// logoImageSetContents 标志图片集Contents.json结构
+type logoImageSetContents struct {
+	Images []logoImage `json:"images"`
+	Info   contentInfo `json:"info"`
+}
+
+// logoImage 标志图片条目
+type logoImage struct {
+	Appearances []appearance `json:"appearances,omitempty"` // 为空时为默认外观
+	Filename    string       `json:"filename"`
+	Idiom       string       `json:"idiom"`
+	Scale       string       `json:"scale"`
+}
+
+// appearance 图片条目的外观
+type appearance struct {
+	Appearance string `json:"appearance"`
+	Value      string `json:"value"`
+}
+
+// contentInfo Xcode生成的info字段
+type contentInfo struct {
+	Author  string `json:"author"`
+	Version int    `json:"version"`
+}
+
+// iosScales 标志生成的倍数
+var iosScales = []int{1, 2, 3}
+
+// GenerateIOS 生成启动画面的标志图片集、背景颜色集，以及Info.plist的UILaunchScreen配置或LaunchScreen.storyboard
+// 背景颜色集包含颜色配置中的深色值，指定了深色标志时图片集包含深色外观的条目
+func (g *Generator) GenerateIOS() error {
+	definition, err := g.background()
+	if err != nil {
+		return err
+	}
+
+	catalogPath := g.outputPath
+	if g.options.CatalogPath != "" {
+		if err := xcassets.EnsureCatalog(g.options.CatalogPath); err != nil {
+			return err
+		}
+		catalogPath = g.options.CatalogPath
+	}
+
+	if err := color.NewIOSGenerator(catalogPath).Generate(map[string]*color.ColorDefinition{backgroundColor: definition}); err != nil {
+		return fmt.Errorf("生成背景颜色集失败: %w", err)
+	}
+	width, height, err := g.writeLogoImageSet(filepath.Join(catalogPath, logoImageSet+".imageset"))
+	if err != nil {
+		return err
+	}
+
+	if g.options.LaunchScreen == LaunchScreenStoryboard {
+		return writeFile(filepath.Join(g.outputPath, storyboardName), storyboardXML(width, height))
+	}
+	return writeFile(filepath.Join(g.outputPath, plistFileName), launchScreenPlist())
+}
+
+// writeLogoImageSet 生成标志图片集，返回标志的点尺寸
+func (g *Generator) writeLogoImageSet(imageSetPath string) (width, height float64, err error) {
+	if err := os.MkdirAll(imageSetPath, 0755); err != nil {
+		return 0, 0, fmt.Errorf("创建图片集目录失败: %w", err)
+	}
+
+	contents := logoImageSetContents{Images: []logoImage{}, Info: contentInfo{Author: "xcode", Version: 1}}
+	appearances := []struct {
+		path        string
+		suffix      string
+		appearances []appearance
+	}{
+		{path: g.inputPath},
+		{path: g.options.DarkLogo, suffix: darkSuffix, appearances: []appearance{{Appearance: "luminosity", Value: "dark"}}},
+	}
+	for i, item := range appearances {
+		if item.path == "" {
+			continue
+		}
+		l, err := g.loadLogo(item.path)
+		if err != nil {
+			return 0, 0, err
+		}
+		if i == 0 {
+			width, height = l.fit(g.options.IOSLogoSize)
+		}
+		g.checkResolution(item.path, l, g.options.IOSLogoSize*float64(iosScales[len(iosScales)-1]))
+
+		for _, scale := range iosScales {
+			fileName := logoImageSet + item.suffix + ".png"
+			if scale > 1 {
+				fileName = fmt.Sprintf("%s%s@%dx.png", logoImageSet, item.suffix, scale)
+			}
+			img := l.renderFit(g.options.IOSLogoSize * float64(scale))
+			if err := writePNG(filepath.Join(imageSetPath, fileName), img); err != nil {
+				return 0, 0, err
+			}
+			contents.Images = append(contents.Images, logoImage{
+				Appearances: item.appearances,
+				Filename:    fileName,
+				Idiom:       "universal",
+				Scale:       strconv.Itoa(scale) + "x",
+			})
+		}
+	}
+
+	if err := removeStaleLogos(imageSetPath, contents); err != nil {
+		return 0, 0, err
+	}
+	// 图片集完全由工具生成，不与已有的条目合并，不再指定深色标志后不会残留深色条目
+	if err := xcassets.WriteContents(filepath.Join(imageSetPath, "Contents.json"), contents, false); err != nil {
+		return 0, 0, err
+	}
+	return width, height, nil
+}
+
+// removeStaleLogos 删除之前生成、但不再被Contents.json引用的标志文件
+func removeStaleLogos(imageSetPath string, contents logoImageSetContents) error {
+	referenced := make(map[string]bool)
+	for _, image := range contents.Images {
+		referenced[image.Filename] = true
+	}
+
+	entries, err := os.ReadDir(imageSetPath)
+	if err != nil {
+		return fmt.Errorf("读取图片集目录失败: %w", err)
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || referenced[name] || !strings.HasPrefix(name, logoImageSet) || filepath.Ext(name) != ".png" {
+			continue
+		}
+		if err := os.Remove(filepath.Join(imageSetPath, name)); err != nil {
+			return fmt.Errorf("删除旧的标志 %s 失败: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// launchScreenPlist 生成Info.plist的UILaunchScreen配置片段，合并到应用的Info.plist中
+func launchScreenPlist() []byte {
+	var out strings.Builder
+	out.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	out.WriteString(`<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">` + "\n")
+	out.WriteString("<!-- 由 app-assets-generator 自动生成，请勿手动修改 -->\n")
+	out.WriteString(`<plist version="1.0">` + "\n")
+	out.WriteString("<dict>\n")
+	out.WriteString("\t<key>UILaunchScreen</key>\n")
+	out.WriteString("\t<dict>\n")
+	fmt.Fprintf(&out, "\t\t<key>UIColorName</key>\n\t\t<string>%s</string>\n", backgroundColor)
+	fmt.Fprintf(&out, "\t\t<key>UIImageName</key>\n\t\t<string>%s</string>\n", logoImageSet)
+	out.WriteString("\t</dict>\n")
+	out.WriteString("</dict>\n")
+	out.WriteString("</plist>\n")
+	return []byte(out.String())
+}
+
+// storyboardXML 生成LaunchScreen.storyboard：背景使用命名颜色，标志居中显示
+func storyboardXML(width, height float64) []byte {
+	// 以393×852的设计尺寸计算标志的初始位置，运行时由居中约束决定
+	const screenWidth, screenHeight = 393, 852
+	x, y := (screenWidth-width)/2, (screenHeight-height)/2
+
+	var out strings.Builder
+	out.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	out.WriteString("<!-- 由 app-assets-generator 自动生成，请勿手动修改 -->\n")
+	out.WriteString(`<document type="com.apple.InterfaceBuilder3.CocoaTouch.Storyboard.XIB" version="3.0" toolsVersion="21701" targetRuntime="iOS.CocoaTouch" propertyAccessControl="none" useAutolayout="YES" launchScreen="YES" useTraitCollections="YES" useSafeAreas="YES" colorMatched="YES" initialViewController="01J-lp-oVM">` + "\n")
+	out.WriteString(`    <device id="retina6_12" orientation="portrait" appearance="light"/>` + "\n")
+	out.WriteString("    <dependencies>\n")
+	out.WriteString(`        <plugIn identifier="com.apple.InterfaceBuilder.IBCocoaTouchPlugin" version="21679"/>` + "\n")
+	out.WriteString(`        <capability name="Named colors" minToolsVersion="9.0"/>` + "\n")
+	out.WriteString(`        <capability name="Safe area layout guides" minToolsVersion="9.0"/>` + "\n")
+	out.WriteString(`        <capability name="documents saved in the Xcode 8 format" minToolsVersion="8.0"/>` + "\n")
+	out.WriteString("    </dependencies>\n")
+	out.WriteString("    <scenes>\n")
+	out.WriteString(`        <scene sceneID="EHf-IW-A2E">` + "\n")
+	out.WriteString("            <objects>\n")
+	out.WriteString(`                <viewController id="01J-lp-oVM" sceneMemberID="viewController">` + "\n")
+	out.WriteString(`                    <view key="view" contentMode="scaleToFill" id="Ze5-6b-2t3">` + "\n")
+	fmt.Fprintf(&out, "                        <rect key=\"frame\" x=\"0.0\" y=\"0.0\" width=\"%d\" height=\"%d\"/>\n", screenWidth, screenHeight)
+	out.WriteString(`                        <autoresizingMask key="autoresizingMask" widthSizable="YES" heightSizable="YES"/>` + "\n")
+	out.WriteString("                        <subviews>\n")
+	fmt.Fprintf(&out, "                            <imageView clipsSubviews=\"YES\" userInteractionEnabled=\"NO\" contentMode=\"center\" image=\"%s\" translatesAutoresizingMaskIntoConstraints=\"NO\" id=\"YRO-k0-Ey4\">\n", logoImageSet)
+	fmt.Fprintf(&out, "                                <rect key=\"frame\" x=\"%s\" y=\"%s\" width=\"%s\" height=\"%s\"/>\n",
+		storyboardNumber(x), storyboardNumber(y), storyboardNumber(width), storyboardNumber(height))
+	out.WriteString("                            </imageView>\n")
+	out.WriteString("                        </subviews>\n")
+	out.WriteString(`                        <viewLayoutGuide key="safeArea" id="6Tk-OE-BBY"/>` + "\n")
+	fmt.Fprintf(&out, "                        <color key=\"backgroundColor\" name=\"%s\"/>\n", backgroundColor)
+	out.WriteString("                        <constraints>\n")
+	out.WriteString(`                            <constraint firstItem="YRO-k0-Ey4" firstAttribute="centerX" secondItem="Ze5-6b-2t3" secondAttribute="centerX" id="Wmf-3k-Xyc"/>` + "\n")
+	out.WriteString(`                            <constraint firstItem="YRO-k0-Ey4" firstAttribute="centerY" secondItem="Ze5-6b-2t3" secondAttribute="centerY" id="c6e-Nz-3Ab"/>` + "\n")
+	out.WriteString("                        </constraints>\n")
+	out.WriteString("                    </view>\n")
+	out.WriteString("                </viewController>\n")
+	out.WriteString(`                <placeholder placeholderIdentifier="IBFirstResponder" id="iYj-Kq-Ea1" userLabel="First Responder" sceneMemberID="firstResponder"/>` + "\n")
+	out.WriteString("            </objects>\n")
+	out.WriteString(`            <point key="canvasLocation" x="53" y="375"/>` + "\n")
+	out.WriteString("        </scene>\n")
+	out.WriteString("    </scenes>\n")
+	out.WriteString("    <resources>\n")
+	fmt.Fprintf(&out, "        <image name=\"%s\" width=\"%s\" height=\"%s\"/>\n", logoImageSet, storyboardNumber(width), storyboardNumber(height))
+	out.WriteString("    </resources>\n")
+	out.WriteString("</document>\n")
+	return []byte(out.String())
+}
+
+// storyboardNumber 格式化storyboard中的坐标，与Interface Builder一样保留一位小数
+func storyboardNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', 1, 64)
+}
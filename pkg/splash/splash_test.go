@@ -0,0 +1,314 @@
+package splash
+
+import (
+	assetimage "app-assets-generator/pkg/image"
+	"app-assets-generator/pkg/svg"
+	"encoding/json"
+	"image"
+	"image/color"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// logoSVG 200×100的标志
+const logoSVG = `<svg xmlns="http://www.w3.org/2000/svg" width="200" height="100"><rect width="200" height="100" fill="#336699"/></svg>`
+
+// project 测试用的输入目录，生成结果写入其中的out目录
+type project struct {
+	t   *testing.T
+	dir string
+}
+
+// newProject 创建包含背景色配置的输入目录
+func newProject(t *testing.T) *project {
+	p := &project{t: t, dir: t.TempDir()}
+	p.write("colors.yaml", "splash_bg:\n  light:\n    hex: \"#ffffff\"\n    alpha: 1\n  dark:\n    hex: \"#000000\"\n    alpha: 1\n")
+	return p
+}
+
+// write 写入文本文件
+func (p *project) write(name, content string) string {
+	p.t.Helper()
+	path := filepath.Join(p.dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		p.t.Fatal(err)
+	}
+	return path
+}
+
+// bitmap 写入纯色的PNG标志
+func (p *project) bitmap(name string, width, height int) string {
+	p.t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for i := 0; i < len(img.Pix); i += 4 {
+		img.Pix[i], img.Pix[i+3] = 255, 255
+	}
+	path := filepath.Join(p.dir, name)
+	if err := assetimage.WriteImage(path, img); err != nil {
+		p.t.Fatal(err)
+	}
+	return path
+}
+
+// generator 创建输出到out目录的生成器
+func (p *project) generator(logo string, options Options) *Generator {
+	options.Background = "splash_bg"
+	options.ColorsPath = filepath.Join(p.dir, "colors.yaml")
+	return NewGenerator(logo, p.out(), options)
+}
+
+// out 获取输出目录中的路径
+func (p *project) out(elem ...string) string {
+	return filepath.Join(append([]string{p.dir, "out"}, elem...)...)
+}
+
+// files 列出输出目录中匹配的文件（相对路径）
+func (p *project) files(pattern string) []string {
+	p.t.Helper()
+	matches, err := filepath.Glob(p.out(pattern))
+	if err != nil {
+		p.t.Fatal(err)
+	}
+	var files []string
+	for _, match := range matches {
+		rel, _ := filepath.Rel(p.out(), match)
+		files = append(files, filepath.ToSlash(rel))
+	}
+	sort.Strings(files)
+	return files
+}
+
+func TestGenerateAndroidLogoFormats(t *testing.T) {
+	p := newProject(t)
+	png := p.bitmap("logo.png", 600, 300)
+	svgLogo := p.write("logo.svg", logoSVG)
+
+	// 位图标志生成各密度的PNG
+	if err := p.generator(png, Options{DarkLogo: png}).GenerateAndroid(); err != nil {
+		t.Fatalf("GenerateAndroid() 失败: %v", err)
+	}
+	if got := p.files("drawable-*/splash_logo.png"); len(got) != 10 {
+		t.Errorf("生成了 %v，应为普通和深色模式各5个密度", got)
+	}
+	data, err := os.ReadFile(p.out("drawable-xxxhdpi", "splash_logo.png"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "\x89PNG") {
+		t.Errorf("splash_logo.png 不是PNG文件")
+	}
+
+	// 切换为SVG且不再指定深色标志后，删除之前生成的位图和深色图标
+	if err := p.generator(svgLogo, Options{}).GenerateAndroid(); err != nil {
+		t.Fatalf("GenerateAndroid() 失败: %v", err)
+	}
+	if got := p.files("drawable*/splash_logo.*"); strings.Join(got, ",") != "drawable/splash_logo.xml" {
+		t.Errorf("生成的图标为 %v，应只有 drawable/splash_logo.xml", got)
+	}
+	if _, err := os.Stat(p.out("drawable-night")); !os.IsNotExist(err) {
+		t.Errorf("空的 drawable-night 目录应被删除")
+	}
+}
+
+func TestGenerateAndroidThemes(t *testing.T) {
+	p := newProject(t)
+	if err := p.generator(p.write("logo.svg", logoSVG), Options{Theme: "Theme.Demo.Splash"}).GenerateAndroid(); err != nil {
+		t.Fatalf("GenerateAndroid() 失败: %v", err)
+	}
+
+	tests := []struct {
+		dir      string
+		contains []string
+		excludes []string
+	}{
+		{
+			dir: "values",
+			contains: []string{
+				`<style name="Theme.Demo.Splash" parent="Theme.SplashScreen">`,
+				`<item name="windowSplashScreenBackground">@color/splash_bg</item>`,
+				`<item name="windowSplashScreenAnimatedIcon">@drawable/splash_logo</item>`,
+				`<item name="postSplashScreenTheme">@style/Theme.App</item>`,
+			},
+			excludes: []string{"android:windowSplashScreen"},
+		},
+		{
+			dir: "values-v31",
+			contains: []string{
+				`<item name="android:windowSplashScreenBackground">@color/splash_bg</item>`,
+				`<item name="android:windowSplashScreenAnimatedIcon">@drawable/splash_logo</item>`,
+				`<item name="windowSplashScreenBackground">@color/splash_bg</item>`,
+			},
+		},
+	}
+	for _, test := range tests {
+		data, err := os.ReadFile(p.out(test.dir, themesFileName))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range test.contains {
+			if !strings.Contains(string(data), want) {
+				t.Errorf("%s/%s 中没有 %s", test.dir, themesFileName, want)
+			}
+		}
+		for _, unwanted := range test.excludes {
+			if strings.Contains(string(data), unwanted) {
+				t.Errorf("%s/%s 中不应有 %s", test.dir, themesFileName, unwanted)
+			}
+		}
+	}
+}
+
+func TestGenerateBackgroundErrors(t *testing.T) {
+	p := newProject(t)
+	p.write("gradient.yaml", "splash_bg:\n  type: linear\n  angle: 90\n  stops:\n"+
+		"    - color: \"#000000\"\n      position: 0\n    - color: \"#ffffff\"\n      position: 1\n")
+	logo := p.write("logo.svg", logoSVG)
+
+	tests := []struct {
+		name    string
+		options Options
+		want    string
+	}{
+		{name: "颜色不存在", options: Options{Background: "missing", ColorsPath: filepath.Join(p.dir, "colors.yaml")}, want: "颜色 missing 在"},
+		{name: "渐变色", options: Options{Background: "splash_bg", ColorsPath: filepath.Join(p.dir, "gradient.yaml")}, want: "是渐变色"},
+		{name: "配置文件不存在", options: Options{Background: "splash_bg", ColorsPath: filepath.Join(p.dir, "none.yaml")}, want: "解析颜色配置失败"},
+	}
+	for _, test := range tests {
+		for platform, generate := range map[string]func(*Generator) error{
+			"Android": (*Generator).GenerateAndroid,
+			"iOS":     (*Generator).GenerateIOS,
+		} {
+			err := generate(NewGenerator(logo, p.out(), test.options))
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Errorf("%s %s: 错误为 %v，应包含 %q", platform, test.name, err, test.want)
+			}
+		}
+	}
+	if _, err := os.Stat(p.out()); !os.IsNotExist(err) {
+		t.Errorf("背景色有错误时不应写入任何文件")
+	}
+}
+
+func TestGenerateIOS(t *testing.T) {
+	p := newProject(t)
+	logo := p.bitmap("logo.png", 300, 150)
+	dark := p.bitmap("dark.png", 408, 204)
+
+	generator := p.generator(logo, Options{DarkLogo: dark, LaunchScreen: LaunchScreenStoryboard})
+	if err := generator.GenerateIOS(); err != nil {
+		t.Fatalf("GenerateIOS() 失败: %v", err)
+	}
+
+	want := []string{
+		"LaunchLogo.imageset/LaunchLogo.png", "LaunchLogo.imageset/LaunchLogo@2x.png", "LaunchLogo.imageset/LaunchLogo@3x.png",
+		"LaunchLogo.imageset/LaunchLogo_dark.png", "LaunchLogo.imageset/LaunchLogo_dark@2x.png", "LaunchLogo.imageset/LaunchLogo_dark@3x.png",
+	}
+	if got := p.files("LaunchLogo.imageset/*.png"); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("图片集中的文件为 %v，应为 %v", got, want)
+	}
+	if _, err := os.Stat(p.out("LaunchBackground.colorset", "Contents.json")); err != nil {
+		t.Errorf("缺少背景颜色集: %v", err)
+	}
+
+	// 300×150的标志在3x时需要408像素，应警告会变模糊；深色标志足够大
+	if warnings := generator.Warnings(); len(warnings) != 1 || !strings.HasPrefix(warnings[0], "logo.png: 标志为 300x150 像素") {
+		t.Errorf("警告为 %q", warnings)
+	}
+
+	// 标志按较长边136点缩放为136×68，在393×852的画布上居中
+	storyboard, err := os.ReadFile(p.out(storyboardName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`<rect key="frame" x="128.5" y="392.0" width="136.0" height="68.0"/>`,
+		`<image name="LaunchLogo" width="136.0" height="68.0"/>`,
+		`<color key="backgroundColor" name="LaunchBackground"/>`,
+	} {
+		if !strings.Contains(string(storyboard), want) {
+			t.Errorf("storyboard中没有 %s", want)
+		}
+	}
+
+	// 不再指定深色标志后删除深色条目和文件
+	if err := p.generator(logo, Options{}).GenerateIOS(); err != nil {
+		t.Fatalf("GenerateIOS() 失败: %v", err)
+	}
+	if got := p.files("LaunchLogo.imageset/*.png"); len(got) != 3 {
+		t.Errorf("图片集中的文件为 %v，应只有3个普通外观的标志", got)
+	}
+	var contents logoImageSetContents
+	data, err := os.ReadFile(p.out("LaunchLogo.imageset", "Contents.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &contents); err != nil {
+		t.Fatal(err)
+	}
+	for _, image := range contents.Images {
+		if len(image.Appearances) > 0 {
+			t.Errorf("Contents.json中残留了深色条目 %s", image.Filename)
+		}
+	}
+	if _, err := os.Stat(p.out(plistFileName)); err != nil {
+		t.Errorf("默认应生成 %s: %v", plistFileName, err)
+	}
+}
+
+func TestIconLayer(t *testing.T) {
+	// 2:1的位图标志，较长边缩放到圆形遮罩的内切正方形
+	img := image.NewNRGBA(image.Rect(0, 0, 100, 50))
+	for i := range img.Pix {
+		img.Pix[i] = 255
+	}
+	l := &logo{bitmap: img}
+
+	for _, density := range splashDensities {
+		layer := iconLayer(l, density)
+		size := density.pixels(splashIconSize)
+		if layer.Rect.Dx() != size || layer.Rect.Dy() != size {
+			t.Errorf("%s 的图标为 %v，应为 %dx%d", density.Name, layer.Rect.Size(), size, size)
+			continue
+		}
+
+		// 不透明内容的宽度应为logoBox，且居中
+		left, right := -1, -1
+		for x := 0; x < size; x++ {
+			if layer.NRGBAAt(x, size/2).A > 0 {
+				if left < 0 {
+					left = x
+				}
+				right = x
+			}
+		}
+		want := int(math.Round(logoBox * density.Scale))
+		if right-left+1 != want || left != (size-want)/2 {
+			t.Errorf("%s 的标志位于 %d-%d，应宽 %d 像素并居中", density.Name, left, right, want)
+		}
+		if layer.NRGBAAt(size/2, size/2) != (color.NRGBA{R: 255, G: 255, B: 255, A: 255}) {
+			t.Errorf("%s 的图标中心不是标志", density.Name)
+		}
+	}
+}
+
+func TestPaddedDocument(t *testing.T) {
+	doc := &svg.Document{Width: 200, Height: 100, ViewBox: svg.Rect{X: 10, Y: 20, Width: 200, Height: 100}}
+	padded := paddedDocument(doc)
+
+	side := 200 * splashIconSize / logoBox
+	want := svg.Rect{X: 110 - side/2, Y: 70 - side/2, Width: side, Height: side}
+	got := padded.ViewBox
+	if math.Abs(got.X-want.X) > 1e-9 || math.Abs(got.Y-want.Y) > 1e-9 || math.Abs(got.Width-want.Width) > 1e-9 || got.Width != got.Height {
+		t.Errorf("viewBox为 %+v，应为 %+v", got, want)
+	}
+	if padded.Width != splashIconSize || padded.Height != splashIconSize {
+		t.Errorf("尺寸为 %gx%g，应为 %dx%d", padded.Width, padded.Height, splashIconSize, splashIconSize)
+	}
+	if doc.ViewBox.Width != 200 || doc.Width != 200 {
+		t.Errorf("paddedDocument不应修改原文档")
+	}
+}
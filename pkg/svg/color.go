@@ -0,0 +1,138 @@
+package svg
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Color RGBA颜色，各分量为0-1
+type Color struct {
+	R, G, B, A float64
+}
+
+// Hex 格式化为 #AARRGGBB（Android）形式，alpha为1时省略为 #RRGGBB
+func (c Color) Hex() string {
+	r, g, b, a := channel(c.R), channel(c.G), channel(c.B), channel(c.A)
+	if a == 255 {
+		return fmt.Sprintf("#%02X%02X%02X", r, g, b)
+	}
+	return fmt.Sprintf("#%02X%02X%02X%02X", a, r, g, b)
+}
+
+// channel 将0-1的分量转换为0-255
+func channel(value float64) int {
+	return int(math.Round(math.Max(0, math.Min(1, value)) * 255))
+}
+
+// Paint 填充或描边的绘制方式，Color和Gradient都为空时不绘制
+type Paint struct {
+	Color    *Color
+	Gradient *Gradient
+}
+
+// IsNone 判断是否不绘制
+func (p Paint) IsNone() bool {
+	return p.Color == nil && p.Gradient == nil
+}
+
+// Gradient 渐变，坐标为所在图形的用户坐标（objectBoundingBox已经换算）
+type Gradient struct {
+	Linear bool // true为线性渐变，false为径向渐变
+
+	X1, Y1, X2, Y2 float64 // 线性渐变的起点和终点
+	CX, CY, R      float64 // 径向渐变的圆心和半径
+	FX, FY         float64 // 径向渐变的焦点
+
+	Transform Matrix // gradientTransform，以及objectBoundingBox换算到用户坐标的变换
+	Spread    string // 超出范围时的处理 pad/reflect/repeat
+	Stops     []Stop
+}
+
+// Stop 渐变色标
+type Stop struct {
+	Offset float64 // 0-1
+	Color  Color
+}
+
+// namedColors CSS颜色名称
+var namedColors = map[string]string{
+	"black": "#000000", "white": "#ffffff", "red": "#ff0000", "green": "#008000", "blue": "#0000ff",
+	"yellow": "#ffff00", "cyan": "#00ffff", "aqua": "#00ffff", "magenta": "#ff00ff", "fuchsia": "#ff00ff",
+	"gray": "#808080", "grey": "#808080", "silver": "#c0c0c0", "maroon": "#800000", "olive": "#808000",
+	"lime": "#00ff00", "teal": "#008080", "navy": "#000080", "purple": "#800080", "orange": "#ffa500",
+	"pink": "#ffc0cb", "brown": "#a52a2a", "gold": "#ffd700", "indigo": "#4b0082", "violet": "#ee82ee",
+	"darkgray": "#a9a9a9", "darkgrey": "#a9a9a9", "lightgray": "#d3d3d3", "lightgrey": "#d3d3d3",
+	"dimgray": "#696969", "dimgrey": "#696969", "gainsboro": "#dcdcdc", "whitesmoke": "#f5f5f5",
+	"darkred": "#8b0000", "darkgreen": "#006400", "darkblue": "#00008b", "lightblue": "#add8e6",
+	"skyblue": "#87ceeb", "steelblue": "#4682b4", "royalblue": "#4169e1", "dodgerblue": "#1e90ff",
+	"tomato": "#ff6347", "coral": "#ff7f50", "salmon": "#fa8072", "crimson": "#dc143c",
+	"orangered": "#ff4500", "darkorange": "#ff8c00", "khaki": "#f0e68c", "beige": "#f5f5dc",
+	"ivory": "#fffff0", "tan": "#d2b48c", "chocolate": "#d2691e", "sienna": "#a0522d",
+	"forestgreen": "#228b22", "seagreen": "#2e8b57", "limegreen": "#32cd32", "turquoise": "#40e0d0",
+	"slategray": "#708090", "slategrey": "#708090", "lavender": "#e6e6fa", "plum": "#dda0dd",
+	"orchid": "#da70d6", "hotpink": "#ff69b4", "deeppink": "#ff1493", "transparent": "#00000000",
+}
+
+// parseColor 解析CSS颜色：#rgb、#rgba、#rrggbb、#rrggbbaa、rgb()、rgba()和颜色名称
+func parseColor(value string) (Color, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if hex, ok := namedColors[value]; ok {
+		value = hex
+	}
+
+	if strings.HasPrefix(value, "#") {
+		hex := value[1:]
+		if len(hex) == 3 || len(hex) == 4 {
+			var expanded strings.Builder
+			for _, c := range hex {
+				expanded.WriteRune(c)
+				expanded.WriteRune(c)
+			}
+			hex = expanded.String()
+		}
+		if len(hex) != 6 && len(hex) != 8 {
+			return Color{}, fmt.Errorf("无效的颜色: %s", value)
+		}
+		n, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil {
+			return Color{}, fmt.Errorf("无效的颜色: %s", value)
+		}
+		alpha := 1.0
+		if len(hex) == 8 {
+			alpha = float64(n&0xFF) / 255
+			n >>= 8
+		}
+		return Color{R: float64(n>>16&0xFF) / 255, G: float64(n>>8&0xFF) / 255, B: float64(n&0xFF) / 255, A: alpha}, nil
+	}
+
+	if strings.HasPrefix(value, "rgb") && strings.HasSuffix(value, ")") {
+		open := strings.IndexByte(value, '(')
+		parts := strings.FieldsFunc(value[open+1:len(value)-1], func(r rune) bool {
+			return r == ',' || r == ' ' || r == '/'
+		})
+		if len(parts) != 3 && len(parts) != 4 {
+			return Color{}, fmt.Errorf("无效的颜色: %s", value)
+		}
+		var channels [4]float64
+		channels[3] = 1
+		for i, part := range parts {
+			scale := 255.0
+			if i == 3 {
+				scale = 1
+			}
+			if strings.HasSuffix(part, "%") {
+				part, scale = strings.TrimSuffix(part, "%"), 100
+			}
+			n, err := strconv.ParseFloat(part, 64)
+			if err != nil {
+				return Color{}, fmt.Errorf("无效的颜色: %s", value)
+			}
+			channels[i] = math.Max(0, math.Min(1, n/scale))
+		}
+		return Color{R: channels[0], G: channels[1], B: channels[2], A: channels[3]}, nil
+	}
+
+	return Color{}, fmt.Errorf("不支持的颜色: %s", value)
+}
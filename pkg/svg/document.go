@@ -0,0 +1,117 @@
+package svg
+
+import (
+	"fmt"
+	"math"
+)
+
+// Rect 矩形区域
+type Rect struct {
+	X, Y, Width, Height float64
+}
+
+// Document 解析后的SVG文档，所有图形按绘制顺序展开为扁平的列表
+type Document struct {
+	Width   float64 // 显示宽度（px），未指定时与viewBox相同
+	Height  float64 // 显示高度（px）
+	ViewBox Rect    // viewBox，未指定时为 0 0 Width Height
+	Shapes  []*Shape
+
+	// Warnings 不支持而被忽略、或者只能近似处理的特性，同类问题只记录一次
+	Warnings []string
+	// Unsupported 不支持的特性（不含处理方式），输出格式要求精确还原时据此拒绝转换
+	Unsupported []string
+
+	align string // preserveAspectRatio的对齐方式，如 xMidYMid、none
+	slice bool   // preserveAspectRatio是否为slice
+}
+
+// Shape 一个需要绘制的图形
+// 路径为图形自身的用户坐标，Transform将其换算到viewBox坐标（包括所有祖先元素的transform）
+type Shape struct {
+	ID        string // 元素的id，用于提示信息
+	Path      Path
+	Transform Matrix
+
+	Fill        Paint
+	FillOpacity float64
+	EvenOdd     bool // fill-rule为evenodd
+
+	Stroke        Paint
+	StrokeOpacity float64
+	StrokeWidth   float64 // 用户坐标中的线宽
+	LineCap       string  // butt/round/square
+	LineJoin      string  // miter/round/bevel
+	MiterLimit    float64
+
+	// Opacity 元素及其所有祖先的opacity之积
+	// 组的opacity按子元素分别应用，子元素重叠时与整体合成的效果略有不同
+	Opacity float64
+
+	// Clips 由外到内的裁剪路径，全部取交集
+	Clips []*Clip
+}
+
+// Clip 裁剪路径，Path为clipPath所引用元素的用户坐标，Transform将其换算到viewBox坐标
+type Clip struct {
+	Path      Path
+	Transform Matrix
+	EvenOdd   bool
+}
+
+// FillAlpha 填充的整体不透明度（不含颜色自身的alpha）
+func (s *Shape) FillAlpha() float64 {
+	return s.Opacity * s.FillOpacity
+}
+
+// StrokeAlpha 描边的整体不透明度（不含颜色自身的alpha）
+func (s *Shape) StrokeAlpha() float64 {
+	return s.Opacity * s.StrokeOpacity
+}
+
+// Name 获取图形的描述，用于提示信息
+func (s *Shape) Name() string {
+	if s.ID != "" {
+		return fmt.Sprintf("#%s", s.ID)
+	}
+	return "未命名图形"
+}
+
+// ViewBoxTransform 获取从viewBox坐标到显示区域（0,0 - Width,Height）的变换，遵循preserveAspectRatio
+func (d *Document) ViewBoxTransform() Matrix {
+	if d.ViewBox.Width <= 0 || d.ViewBox.Height <= 0 {
+		return Identity
+	}
+	sx, sy := d.Width/d.ViewBox.Width, d.Height/d.ViewBox.Height
+	offsetX, offsetY := 0.0, 0.0
+	if d.align != "none" {
+		scale := math.Min(sx, sy)
+		if d.slice {
+			scale = math.Max(sx, sy)
+		}
+		sx, sy = scale, scale
+
+		extraX, extraY := d.Width-d.ViewBox.Width*scale, d.Height-d.ViewBox.Height*scale
+		switch {
+		case len(d.align) >= 4 && d.align[:4] == "xMid":
+			offsetX = extraX / 2
+		case len(d.align) >= 4 && d.align[:4] == "xMax":
+			offsetX = extraX
+		}
+		switch {
+		case len(d.align) >= 8 && d.align[4:] == "YMid":
+			offsetY = extraY / 2
+		case len(d.align) >= 8 && d.align[4:] == "YMax":
+			offsetY = extraY
+		}
+	}
+	return Translate(offsetX-d.ViewBox.X*sx, offsetY-d.ViewBox.Y*sy).Multiply(Scale(sx, sy))
+}
+
+// AspectMatches 判断显示尺寸与viewBox的宽高比是否一致，不一致时不同平台的缩放方式可能不同
+func (d *Document) AspectMatches() bool {
+	if d.ViewBox.Width <= 0 || d.ViewBox.Height <= 0 {
+		return true
+	}
+	return math.Abs(d.Width/d.ViewBox.Width-d.Height/d.ViewBox.Height) < 1e-6*math.Max(1, d.Width/d.ViewBox.Width)
+}
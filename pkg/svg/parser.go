@@ -0,0 +1,914 @@
+package svg
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// svgNamespace SVG的XML命名空间，其它命名空间的元素（如Inkscape的编辑信息）直接忽略
+const svgNamespace = "http://www.w3.org/2000/svg"
+
+// maxUseDepth <use> 的最大嵌套深度，防止循环引用
+const maxUseDepth = 16
+
+// ReadFile 读取并解析SVG文件
+func ReadFile(path string) (*Document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取SVG文件失败: %w", err)
+	}
+	doc, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("解析SVG文件 %s 失败: %w", path, err)
+	}
+	return doc, nil
+}
+
+// Parse 解析SVG文档，展开分组、<use>、样式继承和CSS，得到按绘制顺序排列的图形
+func Parse(data []byte) (*Document, error) {
+	root, err := parseXML(data)
+	if err != nil {
+		return nil, err
+	}
+	if root == nil || root.name != "svg" {
+		return nil, fmt.Errorf("根元素不是<svg>")
+	}
+
+	p := &parser{ids: make(map[string]*node), warned: make(map[string]bool)}
+	p.index(root)
+	doc, err := p.viewport(root)
+	if err != nil {
+		return nil, err
+	}
+	p.doc = doc
+	p.rules = parseStyleSheets(root, p)
+	p.walkChildren(root, Identity, defaultState(), 1, nil, 0)
+	return doc, nil
+}
+
+// node XML元素
+type node struct {
+	name     string            // 元素名称（不含命名空间）
+	attrs    map[string]string // 属性，xlink:href 同时记为 href
+	children []*node
+	text     string // 文本内容，用于<style>
+}
+
+// parseXML 解析XML为元素树，只保留SVG命名空间的元素
+func parseXML(data []byte) (*node, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = false // 容忍Illustrator导出文件中未声明的实体
+	decoder.Entity = xml.HTMLEntity
+
+	var root *node
+	var stack []*node
+	skip := 0 // 位于非SVG命名空间元素内部的深度
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("解析XML失败: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if skip > 0 || (t.Name.Space != "" && t.Name.Space != svgNamespace) {
+				skip++
+				continue
+			}
+			n := &node{name: t.Name.Local, attrs: make(map[string]string)}
+			for _, attr := range t.Attr {
+				switch {
+				case attr.Name.Space == "":
+					n.attrs[attr.Name.Local] = attr.Value
+				case attr.Name.Local == "href":
+					// xlink:href，href属性优先
+					if _, ok := n.attrs["href"]; !ok {
+						n.attrs["href"] = attr.Value
+					}
+				}
+			}
+			if len(stack) == 0 {
+				if root != nil {
+					return nil, fmt.Errorf("文档包含多个根元素")
+				}
+				root = n
+			} else {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, n)
+			}
+			stack = append(stack, n)
+		case xml.EndElement:
+			if skip > 0 {
+				skip--
+				continue
+			}
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		case xml.CharData:
+			if skip == 0 && len(stack) > 0 {
+				stack[len(stack)-1].text += string(t)
+			}
+		}
+	}
+	return root, nil
+}
+
+// parser 将元素树转换为图形列表
+type parser struct {
+	doc    *Document
+	ids    map[string]*node
+	rules  []cssRule
+	warned map[string]bool
+}
+
+// warn 记录警告，相同的警告只记录一次
+func (p *parser) warn(format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	if p.warned[message] {
+		return
+	}
+	p.warned[message] = true
+	p.doc.Warnings = append(p.doc.Warnings, message)
+}
+
+// unsupported 记录不支持的特性，警告中附加处理方式（如“已忽略”）
+func (p *parser) unsupported(handling, format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	warning := message + "，" + handling
+	if !p.warned[warning] {
+		p.doc.Unsupported = append(p.doc.Unsupported, message)
+	}
+	p.warn("%s", warning)
+}
+
+// index 登记所有带id的元素
+func (p *parser) index(n *node) {
+	if id := n.attrs["id"]; id != "" {
+		if _, exists := p.ids[id]; !exists {
+			p.ids[id] = n
+		}
+	}
+	for _, child := range n.children {
+		p.index(child)
+	}
+}
+
+// viewport 解析根元素的尺寸、viewBox和preserveAspectRatio
+func (p *parser) viewport(root *node) (*Document, error) {
+	doc := &Document{align: "xMidYMid"}
+	if value := root.attrs["viewBox"]; value != "" {
+		numbers, err := parseNumberList(value)
+		if err != nil || len(numbers) != 4 || numbers[2] <= 0 || numbers[3] <= 0 {
+			return nil, fmt.Errorf("无效的viewBox: %s", value)
+		}
+		doc.ViewBox = Rect{X: numbers[0], Y: numbers[1], Width: numbers[2], Height: numbers[3]}
+	}
+
+	// 百分比尺寸没有参照，按viewBox处理
+	width, widthOK := parseAbsoluteLength(root.attrs["width"])
+	height, heightOK := parseAbsoluteLength(root.attrs["height"])
+	switch {
+	case widthOK && heightOK:
+		doc.Width, doc.Height = width, height
+	case widthOK && doc.ViewBox.Width > 0:
+		doc.Width, doc.Height = width, width*doc.ViewBox.Height/doc.ViewBox.Width
+	case heightOK && doc.ViewBox.Height > 0:
+		doc.Width, doc.Height = height*doc.ViewBox.Width/doc.ViewBox.Height, height
+	default:
+		doc.Width, doc.Height = doc.ViewBox.Width, doc.ViewBox.Height
+	}
+	if doc.Width <= 0 || doc.Height <= 0 {
+		return nil, fmt.Errorf("无法确定SVG尺寸，需要width/height属性或viewBox")
+	}
+	if doc.ViewBox.Width == 0 {
+		doc.ViewBox = Rect{Width: doc.Width, Height: doc.Height}
+	}
+
+	if fields := strings.Fields(root.attrs["preserveAspectRatio"]); len(fields) > 0 {
+		doc.align = fields[0]
+		doc.slice = len(fields) > 1 && fields[1] == "slice"
+	}
+	return doc, nil
+}
+
+// state 可继承的样式属性
+type state struct {
+	fill, stroke               string
+	fillOpacity, strokeOpacity float64
+	strokeWidth, miterLimit    float64
+	fillRule, clipRule         string
+	lineCap, lineJoin          string
+	color                      string
+	visibility                 string
+	dashArray                  string
+}
+
+// defaultState SVG规范中的初始值
+func defaultState() state {
+	return state{
+		fill:          "black",
+		stroke:        "none",
+		fillOpacity:   1,
+		strokeOpacity: 1,
+		strokeWidth:   1,
+		miterLimit:    4,
+		fillRule:      "nonzero",
+		clipRule:      "nonzero",
+		lineCap:       "butt",
+		lineJoin:      "miter",
+		color:         "black",
+		visibility:    "visible",
+		dashArray:     "none",
+	}
+}
+
+// inherit 应用元素自身的样式属性，inherit关键字保持父元素的值
+func (p *parser) inherit(s state, props map[string]string) state {
+	text := func(name string, target *string) {
+		if value, ok := props[name]; ok && value != "inherit" {
+			*target = value
+		}
+	}
+	number := func(name string, target *float64, reference float64) {
+		if value, ok := props[name]; ok && value != "inherit" {
+			if n, ok := parseLength(value, reference); ok {
+				*target = n
+			}
+		}
+	}
+	text("fill", &s.fill)
+	text("stroke", &s.stroke)
+	text("fill-rule", &s.fillRule)
+	text("clip-rule", &s.clipRule)
+	text("stroke-linecap", &s.lineCap)
+	text("stroke-linejoin", &s.lineJoin)
+	text("color", &s.color)
+	text("visibility", &s.visibility)
+	text("stroke-dasharray", &s.dashArray)
+	number("fill-opacity", &s.fillOpacity, 1)
+	number("stroke-opacity", &s.strokeOpacity, 1)
+	number("stroke-width", &s.strokeWidth, p.diagonal())
+	number("stroke-miterlimit", &s.miterLimit, 1)
+	return s
+}
+
+// diagonal 百分比长度（非水平、非垂直方向）的参照值
+func (p *parser) diagonal() float64 {
+	return math.Hypot(p.doc.ViewBox.Width, p.doc.ViewBox.Height) / math.Sqrt2
+}
+
+// walkChildren 依次处理子元素
+func (p *parser) walkChildren(n *node, ctm Matrix, s state, opacity float64, clips []*Clip, depth int) {
+	for _, child := range n.children {
+		p.walk(child, ctm, s, opacity, clips, depth)
+	}
+}
+
+// walk 处理一个元素，ctm为父元素用户坐标到viewBox坐标的变换
+func (p *parser) walk(n *node, ctm Matrix, parent state, opacity float64, clips []*Clip, depth int) {
+	switch n.name {
+	case "defs", "clipPath", "linearGradient", "radialGradient", "symbol", "style", "title", "desc",
+		"metadata", "mask", "pattern", "marker", "filter":
+		return // 定义类元素只在被引用时使用
+	}
+
+	props := p.properties(n)
+	if props["display"] == "none" {
+		return
+	}
+	s := p.inherit(parent, props)
+
+	if value := props["transform"]; value != "" {
+		transform, err := parseTransform(value)
+		if err != nil {
+			p.warn("%v，已忽略", err)
+		} else {
+			ctm = ctm.Multiply(transform)
+		}
+	}
+	if value, ok := props["opacity"]; ok {
+		if n, ok := parseLength(value, 1); ok {
+			opacity *= math.Max(0, math.Min(1, n))
+		}
+	}
+	if value := props["clip-path"]; value != "" && value != "none" {
+		if clip := p.clip(value, ctm, depth); clip != nil {
+			clips = append(append([]*Clip(nil), clips...), clip)
+		}
+	}
+	for _, name := range []string{"mask", "filter", "marker-start", "marker-mid", "marker-end"} {
+		if value := props[name]; value != "" && value != "none" {
+			p.unsupported("已忽略", "不支持 %s 属性", name)
+		}
+	}
+
+	switch n.name {
+	case "g", "a", "switch", "svg":
+		if n.name == "svg" {
+			ctm = ctm.Multiply(p.nestedViewport(n))
+		}
+		before := len(p.doc.Shapes)
+		p.walkChildren(n, ctm, s, opacity, clips, depth)
+		if opacity < 1 && len(p.doc.Shapes)-before > 1 {
+			p.warn("组的opacity按子元素分别应用，子元素重叠处的效果可能与原图不同")
+		}
+	case "use":
+		p.use(n, ctm, s, opacity, clips, depth)
+	case "path", "rect", "circle", "ellipse", "line", "polyline", "polygon":
+		p.shape(n, props, ctm, s, opacity, clips)
+	case "text", "image", "foreignObject":
+		p.unsupported("已忽略（文字需要先在设计工具中转换为路径）", "不支持 <%s> 元素", n.name)
+	default:
+		p.unsupported("已忽略", "不支持 <%s> 元素", n.name)
+	}
+}
+
+// nestedViewport 嵌套<svg>的x/y偏移和viewBox换算
+func (p *parser) nestedViewport(n *node) Matrix {
+	x, _ := parseLength(n.attrs["x"], p.doc.ViewBox.Width)
+	y, _ := parseLength(n.attrs["y"], p.doc.ViewBox.Height)
+	transform := Translate(x, y)
+	if n.attrs["viewBox"] == "" {
+		return transform
+	}
+
+	nested, err := p.viewport(n)
+	if err != nil {
+		p.warn("嵌套<svg>的%v，已忽略viewBox", err)
+		return transform
+	}
+	if width, ok := parseLength(n.attrs["width"], p.doc.ViewBox.Width); ok {
+		nested.Width = width
+	}
+	if height, ok := parseLength(n.attrs["height"], p.doc.ViewBox.Height); ok {
+		nested.Height = height
+	}
+	return transform.Multiply(nested.ViewBoxTransform())
+}
+
+// use 展开<use>引用的元素
+func (p *parser) use(n *node, ctm Matrix, s state, opacity float64, clips []*Clip, depth int) {
+	target := p.reference(n.attrs["href"])
+	if target == nil {
+		p.warn("<use> 引用的元素 %s 不存在，已忽略", n.attrs["href"])
+		return
+	}
+	if depth >= maxUseDepth {
+		p.warn("<use> 嵌套过深（可能存在循环引用），已忽略")
+		return
+	}
+
+	x, _ := parseLength(n.attrs["x"], p.doc.ViewBox.Width)
+	y, _ := parseLength(n.attrs["y"], p.doc.ViewBox.Height)
+	ctm = ctm.Multiply(Translate(x, y))
+	if target.name == "symbol" {
+		if target.attrs["viewBox"] != "" {
+			p.unsupported("按原始坐标绘制", "不支持 <symbol> 的viewBox")
+		}
+		p.walkChildren(target, ctm, p.inherit(s, p.properties(target)), opacity, clips, depth+1)
+		return
+	}
+	p.walk(target, ctm, s, opacity, clips, depth+1)
+}
+
+// reference 解析 #id 形式的引用
+func (p *parser) reference(href string) *node {
+	if !strings.HasPrefix(href, "#") {
+		return nil
+	}
+	return p.ids[href[1:]]
+}
+
+// shape 创建图形
+func (p *parser) shape(n *node, props map[string]string, ctm Matrix, s state, opacity float64, clips []*Clip) {
+	if s.visibility == "hidden" || s.visibility == "collapse" {
+		return
+	}
+	path, err := p.geometry(n, props)
+	if err != nil {
+		p.warn("<%s> %v，已忽略", n.name, err)
+		return
+	}
+	if len(path) == 0 {
+		return
+	}
+
+	shape := &Shape{
+		ID:            n.attrs["id"],
+		Path:          path,
+		Transform:     ctm,
+		FillOpacity:   clamp01(s.fillOpacity),
+		EvenOdd:       s.fillRule == "evenodd",
+		StrokeOpacity: clamp01(s.strokeOpacity),
+		StrokeWidth:   s.strokeWidth,
+		LineCap:       s.lineCap,
+		LineJoin:      s.lineJoin,
+		MiterLimit:    s.miterLimit,
+		Opacity:       opacity,
+		Clips:         clips,
+	}
+	// 直线和折线没有填充区域
+	if n.name != "line" {
+		shape.Fill = p.paint(s.fill, s.color, path)
+	}
+	if s.strokeWidth > 0 {
+		shape.Stroke = p.paint(s.stroke, s.color, path)
+		if !shape.Stroke.IsNone() && s.dashArray != "none" && s.dashArray != "" {
+			p.unsupported("按实线绘制", "不支持虚线描边（stroke-dasharray）")
+		}
+	}
+	if shape.Fill.IsNone() && shape.Stroke.IsNone() {
+		return
+	}
+	p.doc.Shapes = append(p.doc.Shapes, shape)
+}
+
+// geometry 将基本图形转换为路径
+func (p *parser) geometry(n *node, props map[string]string) (Path, error) {
+	width, height, diagonal := p.doc.ViewBox.Width, p.doc.ViewBox.Height, p.diagonal()
+	length := func(name string, reference float64) float64 {
+		value, _ := parseLength(n.attrs[name], reference)
+		return value
+	}
+
+	switch n.name {
+	case "path":
+		return ParsePathData(props["d"])
+	case "rect":
+		x, y := length("x", width), length("y", height)
+		w, h := length("width", width), length("height", height)
+		if w <= 0 || h <= 0 {
+			return nil, nil
+		}
+		rx, rxOK := parseLength(n.attrs["rx"], width)
+		ry, ryOK := parseLength(n.attrs["ry"], height)
+		if !rxOK {
+			rx = ry
+		}
+		if !ryOK {
+			ry = rx
+		}
+		rx, ry = math.Max(0, math.Min(rx, w/2)), math.Max(0, math.Min(ry, h/2))
+		if rx == 0 || ry == 0 {
+			return Path{
+				{Op: MoveTo, Points: [3]Point{{x, y}}},
+				{Op: LineTo, Points: [3]Point{{x + w, y}}},
+				{Op: LineTo, Points: [3]Point{{x + w, y + h}}},
+				{Op: LineTo, Points: [3]Point{{x, y + h}}},
+				{Op: Close},
+			}, nil
+		}
+		path := Path{{Op: MoveTo, Points: [3]Point{{x + rx, y}}}}
+		path = append(path, Segment{Op: LineTo, Points: [3]Point{{x + w - rx, y}}})
+		path = appendArc(path, Point{x + w - rx, y}, Point{x + w, y + ry}, rx, ry, 0, false, true)
+		path = append(path, Segment{Op: LineTo, Points: [3]Point{{x + w, y + h - ry}}})
+		path = appendArc(path, Point{x + w, y + h - ry}, Point{x + w - rx, y + h}, rx, ry, 0, false, true)
+		path = append(path, Segment{Op: LineTo, Points: [3]Point{{x + rx, y + h}}})
+		path = appendArc(path, Point{x + rx, y + h}, Point{x, y + h - ry}, rx, ry, 0, false, true)
+		path = append(path, Segment{Op: LineTo, Points: [3]Point{{x, y + ry}}})
+		path = appendArc(path, Point{x, y + ry}, Point{x + rx, y}, rx, ry, 0, false, true)
+		return append(path, Segment{Op: Close}), nil
+	case "circle", "ellipse":
+		cx, cy := length("cx", width), length("cy", height)
+		var rx, ry float64
+		if n.name == "circle" {
+			rx = length("r", diagonal)
+			ry = rx
+		} else {
+			rx, ry = length("rx", width), length("ry", height)
+		}
+		if rx <= 0 || ry <= 0 {
+			return nil, nil
+		}
+		path := Path{{Op: MoveTo, Points: [3]Point{{cx + rx, cy}}}}
+		path = appendArc(path, Point{cx + rx, cy}, Point{cx - rx, cy}, rx, ry, 0, false, true)
+		path = appendArc(path, Point{cx - rx, cy}, Point{cx + rx, cy}, rx, ry, 0, false, true)
+		return append(path, Segment{Op: Close}), nil
+	case "line":
+		return Path{
+			{Op: MoveTo, Points: [3]Point{{length("x1", width), length("y1", height)}}},
+			{Op: LineTo, Points: [3]Point{{length("x2", width), length("y2", height)}}},
+		}, nil
+	default: // polyline, polygon
+		numbers, err := parseNumberList(n.attrs["points"])
+		if err != nil {
+			return nil, fmt.Errorf("的points无效: %w", err)
+		}
+		if len(numbers) < 4 {
+			return nil, nil
+		}
+		var path Path
+		for i := 0; i+1 < len(numbers); i += 2 {
+			op := LineTo
+			if i == 0 {
+				op = MoveTo
+			}
+			path = append(path, Segment{Op: op, Points: [3]Point{{numbers[i], numbers[i+1]}}})
+		}
+		if n.name == "polygon" {
+			path = append(path, Segment{Op: Close})
+		}
+		return path, nil
+	}
+}
+
+// paint 解析fill/stroke的值：none、颜色、currentColor或 url(#渐变) [备用颜色]
+func (p *parser) paint(value, currentColor string, path Path) Paint {
+	value = strings.TrimSpace(value)
+	switch {
+	case value == "" || value == "none":
+		return Paint{}
+	case value == "currentColor":
+		return p.paint(currentColor, "black", path)
+	case strings.HasPrefix(value, "url("):
+		end := strings.IndexByte(value, ')')
+		if end < 0 {
+			p.warn("无效的绘制引用 %s，已忽略", value)
+			return Paint{}
+		}
+		reference := strings.Trim(strings.TrimSpace(value[4:end]), `"'`)
+		fallback := strings.TrimSpace(value[end+1:])
+
+		target := p.reference(reference)
+		if target != nil && (target.name == "linearGradient" || target.name == "radialGradient") {
+			if paint, ok := p.gradient(target, path); ok {
+				return paint
+			}
+			return Paint{}
+		}
+		if target != nil && target.name == "pattern" {
+			p.unsupported("已忽略", "不支持图案填充（<pattern>）")
+		} else {
+			p.warn("绘制引用的元素 %s 不存在", reference)
+		}
+		if fallback != "" {
+			return p.paint(fallback, currentColor, path)
+		}
+		return Paint{}
+	}
+
+	color, err := parseColor(value)
+	if err != nil {
+		p.warn("%v，按黑色处理", err)
+		color = Color{A: 1}
+	}
+	return Paint{Color: &color}
+}
+
+// gradient 解析渐变，href引用的渐变提供未指定的属性和色标
+func (p *parser) gradient(n *node, path Path) (Paint, bool) {
+	attrs := make(map[string]string)
+	var stops []*node
+	for current, depth := n, 0; current != nil && depth < maxUseDepth; current, depth = p.reference(current.attrs["href"]), depth+1 {
+		if current.name != "linearGradient" && current.name != "radialGradient" {
+			break
+		}
+		for name, value := range current.attrs {
+			if _, ok := attrs[name]; !ok {
+				attrs[name] = value
+			}
+		}
+		if stops == nil {
+			for _, child := range current.children {
+				if child.name == "stop" {
+					stops = append(stops, child)
+				}
+			}
+		}
+	}
+
+	gradient := &Gradient{Linear: n.name == "linearGradient", Transform: Identity, Spread: "pad"}
+	if value := attrs["spreadMethod"]; value == "reflect" || value == "repeat" {
+		gradient.Spread = value
+	}
+
+	// 色标的位置单调不减
+	last := 0.0
+	for _, stop := range stops {
+		props := p.properties(stop)
+		offset, _ := parseLength(props["offset"], 1)
+		offset = math.Max(last, clamp01(offset))
+		last = offset
+
+		color := Color{A: 1}
+		if value := props["stop-color"]; value != "" && value != "currentColor" {
+			parsed, err := parseColor(value)
+			if err != nil {
+				p.warn("%v，按黑色处理", err)
+			} else {
+				color = parsed
+			}
+		}
+		if value, ok := props["stop-opacity"]; ok {
+			if n, ok := parseLength(value, 1); ok {
+				color.A *= clamp01(n)
+			}
+		}
+		gradient.Stops = append(gradient.Stops, Stop{Offset: offset, Color: color})
+	}
+	switch len(gradient.Stops) {
+	case 0:
+		return Paint{}, false
+	case 1:
+		color := gradient.Stops[0].Color
+		return Paint{Color: &color}, true
+	}
+
+	// objectBoundingBox时坐标为图形边界框的比例
+	userSpace := attrs["gradientUnits"] == "userSpaceOnUse"
+	width, height, diagonal := 1.0, 1.0, 1.0
+	if userSpace {
+		width, height, diagonal = p.doc.ViewBox.Width, p.doc.ViewBox.Height, p.diagonal()
+	}
+	value := func(name, fallback string, reference float64) float64 {
+		text, ok := attrs[name]
+		if !ok {
+			text = fallback
+		}
+		n, _ := parseLength(text, reference)
+		return n
+	}
+	if gradient.Linear {
+		gradient.X1 = value("x1", "0%", width)
+		gradient.Y1 = value("y1", "0%", height)
+		gradient.X2 = value("x2", "100%", width)
+		gradient.Y2 = value("y2", "0%", height)
+	} else {
+		gradient.CX = value("cx", "50%", width)
+		gradient.CY = value("cy", "50%", height)
+		gradient.R = value("r", "50%", diagonal)
+		gradient.FX = gradient.CX
+		gradient.FY = gradient.CY
+		if _, ok := attrs["fx"]; ok {
+			gradient.FX = value("fx", "", width)
+		}
+		if _, ok := attrs["fy"]; ok {
+			gradient.FY = value("fy", "", height)
+		}
+	}
+
+	if text := attrs["gradientTransform"]; text != "" {
+		transform, err := parseTransform(text)
+		if err != nil {
+			p.warn("渐变的%v，已忽略", err)
+		} else {
+			gradient.Transform = transform
+		}
+	}
+	if !userSpace {
+		min, max, ok := path.Bounds()
+		if !ok || max.X-min.X <= 0 || max.Y-min.Y <= 0 {
+			// 规范要求不绘制宽或高为0的objectBoundingBox渐变，这里退化为最后一个色标的颜色
+			color := gradient.Stops[len(gradient.Stops)-1].Color
+			return Paint{Color: &color}, true
+		}
+		box := Translate(min.X, min.Y).Multiply(Scale(max.X-min.X, max.Y-min.Y))
+		gradient.Transform = box.Multiply(gradient.Transform)
+	}
+	return Paint{Gradient: gradient}, true
+}
+
+// clip 解析clip-path引用，clipPath的子元素合并为一条路径
+func (p *parser) clip(value string, ctm Matrix, depth int) *Clip {
+	reference := strings.TrimSpace(value)
+	if strings.HasPrefix(reference, "url(") && strings.HasSuffix(reference, ")") {
+		reference = strings.Trim(strings.TrimSpace(reference[4:len(reference)-1]), `"'`)
+	}
+	target := p.reference(reference)
+	if target == nil || target.name != "clipPath" {
+		p.warn("clip-path引用的 %s 不存在，已忽略", value)
+		return nil
+	}
+	if target.attrs["clipPathUnits"] == "objectBoundingBox" {
+		p.unsupported("已忽略裁剪", "不支持 clipPathUnits=\"objectBoundingBox\"")
+		return nil
+	}
+
+	clip := &Clip{Transform: ctm}
+	if text := target.attrs["transform"]; text != "" {
+		if transform, err := parseTransform(text); err == nil {
+			clip.Transform = ctm.Multiply(transform)
+		}
+	}
+
+	var collect func(n *node, transform Matrix, depth int)
+	collect = func(n *node, transform Matrix, depth int) {
+		props := p.properties(n)
+		if props["display"] == "none" {
+			return
+		}
+		if text := props["transform"]; text != "" {
+			if local, err := parseTransform(text); err == nil {
+				transform = transform.Multiply(local)
+			}
+		}
+		switch n.name {
+		case "use":
+			if child := p.reference(n.attrs["href"]); child != nil && depth < maxUseDepth {
+				x, _ := parseLength(n.attrs["x"], p.doc.ViewBox.Width)
+				y, _ := parseLength(n.attrs["y"], p.doc.ViewBox.Height)
+				collect(child, transform.Multiply(Translate(x, y)), depth+1)
+			}
+		case "path", "rect", "circle", "ellipse", "polygon", "polyline":
+			path, err := p.geometry(n, props)
+			if err != nil {
+				p.warn("裁剪路径中的<%s> %v，已忽略", n.name, err)
+				return
+			}
+			if props["clip-rule"] == "evenodd" {
+				clip.EvenOdd = true
+			}
+			clip.Path = append(clip.Path, path.Transform(transform)...)
+		case "text":
+			p.unsupported("已忽略", "不支持裁剪路径中的<text>")
+		}
+	}
+	for _, child := range target.children {
+		collect(child, Identity, depth)
+	}
+	return clip
+}
+
+// cssRule 样式表中的一条规则
+type cssRule struct {
+	selector     string // 简单选择器：*、元素名、.类名、#id、元素名.类名
+	specificity  int
+	order        int
+	declarations map[string]string
+}
+
+// parseStyleSheets 解析所有<style>元素中的CSS规则，只支持简单选择器
+func parseStyleSheets(root *node, p *parser) []cssRule {
+	var rules []cssRule
+	var visit func(n *node)
+	visit = func(n *node) {
+		if n.name == "style" {
+			rules = append(rules, parseCSS(n.text, len(rules), p)...)
+		}
+		for _, child := range n.children {
+			visit(child)
+		}
+	}
+	visit(root)
+
+	sort.SliceStable(rules, func(i, j int) bool {
+		if rules[i].specificity != rules[j].specificity {
+			return rules[i].specificity < rules[j].specificity
+		}
+		return rules[i].order < rules[j].order
+	})
+	return rules
+}
+
+// cssComment CSS注释
+var cssComment = regexp.MustCompile(`(?s)/\*.*?\*/`)
+
+// simpleSelector 支持的简单选择器
+var simpleSelector = regexp.MustCompile(`^(\*|[A-Za-z][\w-]*)?(\.[\w-]+)?(#[\w-]+)?$`)
+
+// parseCSS 解析CSS文本
+func parseCSS(text string, order int, p *parser) []cssRule {
+	text = cssComment.ReplaceAllString(text, "")
+	text = strings.NewReplacer("<![CDATA[", "", "]]>", "").Replace(text)
+
+	var rules []cssRule
+	for _, block := range strings.Split(text, "}") {
+		open := strings.IndexByte(block, '{')
+		if open < 0 {
+			continue
+		}
+		declarations := parseDeclarations(block[open+1:])
+		for _, selector := range strings.Split(block[:open], ",") {
+			selector = strings.TrimSpace(selector)
+			if selector == "" || strings.HasPrefix(selector, "@") {
+				continue
+			}
+			match := simpleSelector.FindStringSubmatch(selector)
+			if match == nil {
+				p.unsupported("已忽略", "不支持CSS选择器 %s", selector)
+				continue
+			}
+			specificity := 0
+			if match[1] != "" && match[1] != "*" {
+				specificity++
+			}
+			if match[2] != "" {
+				specificity += 10
+			}
+			if match[3] != "" {
+				specificity += 100
+			}
+			rules = append(rules, cssRule{selector: selector, specificity: specificity, order: order, declarations: declarations})
+			order++
+		}
+	}
+	return rules
+}
+
+// matches 判断规则是否适用于元素
+func (r cssRule) matches(n *node) bool {
+	match := simpleSelector.FindStringSubmatch(r.selector)
+	if match[1] != "" && match[1] != "*" && match[1] != n.name {
+		return false
+	}
+	if match[2] != "" && !containsField(n.attrs["class"], match[2][1:]) {
+		return false
+	}
+	if match[3] != "" && n.attrs["id"] != match[3][1:] {
+		return false
+	}
+	return true
+}
+
+// parseDeclarations 解析 name: value; 形式的样式声明
+func parseDeclarations(text string) map[string]string {
+	declarations := make(map[string]string)
+	for _, declaration := range strings.Split(text, ";") {
+		name, value, ok := strings.Cut(declaration, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(value), "!important"))
+		declarations[strings.TrimSpace(name)] = value
+	}
+	return declarations
+}
+
+// properties 获取元素的样式属性：表现属性 < 样式表 < style属性
+func (p *parser) properties(n *node) map[string]string {
+	props := make(map[string]string, len(n.attrs))
+	for name, value := range n.attrs {
+		props[name] = strings.TrimSpace(value)
+	}
+	for _, rule := range p.rules {
+		if rule.matches(n) {
+			for name, value := range rule.declarations {
+				props[name] = value
+			}
+		}
+	}
+	for name, value := range parseDeclarations(n.attrs["style"]) {
+		props[name] = value
+	}
+	return props
+}
+
+// containsField 判断以空白分隔的列表中是否包含指定值
+func containsField(list, value string) bool {
+	for _, field := range strings.Fields(list) {
+		if field == value {
+			return true
+		}
+	}
+	return false
+}
+
+// unitScales 长度单位换算为px的比例
+var unitScales = map[string]float64{
+	"": 1, "px": 1, "pt": 96.0 / 72, "pc": 16, "mm": 96 / 25.4, "cm": 96 / 2.54, "in": 96, "em": 16, "ex": 8,
+}
+
+// lengthPattern 长度值
+var lengthPattern = regexp.MustCompile(`^([+-]?(?:\d+\.?\d*|\.\d+)(?:[eE][+-]?\d+)?)\s*(px|pt|pc|mm|cm|in|em|ex|%)?$`)
+
+// parseLength 解析长度，百分比按reference换算
+func parseLength(value string, reference float64) (float64, bool) {
+	match := lengthPattern.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return 0, false
+	}
+	n, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, false
+	}
+	if match[2] == "%" {
+		return n / 100 * reference, true
+	}
+	return n * unitScales[match[2]], true
+}
+
+// parseAbsoluteLength 解析不是百分比的长度
+func parseAbsoluteLength(value string) (float64, bool) {
+	if strings.HasSuffix(strings.TrimSpace(value), "%") {
+		return 0, false
+	}
+	n, ok := parseLength(value, 0)
+	return n, ok && n > 0
+}
+
+// clamp01 限制在0-1之间
+func clamp01(value float64) float64 {
+	return math.Max(0, math.Min(1, value))
+}
@@ -0,0 +1,497 @@
+package svg
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Point 二维坐标
+type Point struct {
+	X, Y float64
+}
+
+// Op 路径命令
+type Op int
+
+const (
+	MoveTo  Op = iota // 移动到 Points[0]
+	LineTo            // 直线到 Points[0]
+	QuadTo            // 二次贝塞尔曲线，控制点 Points[0]，终点 Points[1]
+	CubicTo           // 三次贝塞尔曲线，控制点 Points[0]、Points[1]，终点 Points[2]
+	Close             // 闭合当前子路径
+)
+
+// Segment 路径片段，坐标均为绝对坐标
+type Segment struct {
+	Op     Op
+	Points [3]Point
+}
+
+// Path 路径，圆弧已转换为三次贝塞尔曲线，H/V/S/T等简写已展开
+type Path []Segment
+
+// End 获取片段的终点，Close没有终点
+func (s Segment) End() Point {
+	switch s.Op {
+	case QuadTo:
+		return s.Points[1]
+	case CubicTo:
+		return s.Points[2]
+	default:
+		return s.Points[0]
+	}
+}
+
+// Transform 变换路径中的所有点
+func (p Path) Transform(m Matrix) Path {
+	result := make(Path, len(p))
+	for i, segment := range p {
+		result[i] = segment
+		for j := range segment.Points {
+			result[i].Points[j] = m.Apply(segment.Points[j])
+		}
+	}
+	return result
+}
+
+// Polyline 折线化后的子路径
+type Polyline struct {
+	Points []Point
+	Closed bool
+}
+
+// Flatten 将曲线按容差折线化，每个子路径一条折线
+func (p Path) Flatten(tolerance float64) []Polyline {
+	var result []Polyline
+	var current *Polyline
+	var start, last Point
+	begin := func(point Point) {
+		result = append(result, Polyline{Points: []Point{point}})
+		current = &result[len(result)-1]
+	}
+
+	for _, segment := range p {
+		if segment.Op != MoveTo && current == nil {
+			begin(last) // 闭合后没有MoveTo的绘制从上一个起点开始
+		}
+		switch segment.Op {
+		case MoveTo:
+			start, last = segment.Points[0], segment.Points[0]
+			begin(start)
+		case LineTo:
+			last = segment.Points[0]
+			current.Points = append(current.Points, last)
+		case QuadTo:
+			// 二次曲线升阶为三次曲线
+			c1 := Point{last.X + 2.0/3*(segment.Points[0].X-last.X), last.Y + 2.0/3*(segment.Points[0].Y-last.Y)}
+			c2 := Point{segment.Points[1].X + 2.0/3*(segment.Points[0].X-segment.Points[1].X), segment.Points[1].Y + 2.0/3*(segment.Points[0].Y-segment.Points[1].Y)}
+			current.Points = flattenCubic(current.Points, last, c1, c2, segment.Points[1], tolerance)
+			last = segment.Points[1]
+		case CubicTo:
+			current.Points = flattenCubic(current.Points, last, segment.Points[0], segment.Points[1], segment.Points[2], tolerance)
+			last = segment.Points[2]
+		case Close:
+			current.Closed = true
+			current = nil
+			last = start
+		}
+	}
+	return result
+}
+
+// flattenCubic 按容差将三次贝塞尔曲线细分为线段，追加到points
+func flattenCubic(points []Point, p0, p1, p2, p3 Point, tolerance float64) []Point {
+	// 控制点到弦的距离决定细分段数
+	dd := math.Max(math.Hypot(p0.X-2*p1.X+p2.X, p0.Y-2*p1.Y+p2.Y), math.Hypot(p1.X-2*p2.X+p3.X, p1.Y-2*p2.Y+p3.Y))
+	n := int(math.Ceil(math.Sqrt(dd * 0.75 / tolerance)))
+	if n < 1 {
+		n = 1
+	}
+	if n > 256 {
+		n = 256
+	}
+	for i := 1; i <= n; i++ {
+		t := float64(i) / float64(n)
+		mt := 1 - t
+		a, b, c, d := mt*mt*mt, 3*mt*mt*t, 3*mt*t*t, t*t*t
+		points = append(points, Point{
+			X: a*p0.X + b*p1.X + c*p2.X + d*p3.X,
+			Y: a*p0.Y + b*p1.Y + c*p2.Y + d*p3.Y,
+		})
+	}
+	return points
+}
+
+// Bounds 获取路径的边界框
+func (p Path) Bounds() (min, max Point, ok bool) {
+	min = Point{math.Inf(1), math.Inf(1)}
+	max = Point{math.Inf(-1), math.Inf(-1)}
+	for _, polyline := range p.Flatten(0.1) {
+		for _, point := range polyline.Points {
+			min.X, min.Y = math.Min(min.X, point.X), math.Min(min.Y, point.Y)
+			max.X, max.Y = math.Max(max.X, point.X), math.Max(max.Y, point.Y)
+		}
+	}
+	return min, max, !math.IsInf(min.X, 1)
+}
+
+// Data 格式化为SVG路径数据，坐标最多保留3位小数
+func (p Path) Data() string {
+	var builder strings.Builder
+	for _, segment := range p {
+		if builder.Len() > 0 {
+			builder.WriteByte(' ')
+		}
+		switch segment.Op {
+		case MoveTo:
+			builder.WriteString("M" + formatPoints(segment.Points[:1]))
+		case LineTo:
+			builder.WriteString("L" + formatPoints(segment.Points[:1]))
+		case QuadTo:
+			builder.WriteString("Q" + formatPoints(segment.Points[:2]))
+		case CubicTo:
+			builder.WriteString("C" + formatPoints(segment.Points[:3]))
+		case Close:
+			builder.WriteString("Z")
+		}
+	}
+	return builder.String()
+}
+
+// formatPoints 格式化坐标列表
+func formatPoints(points []Point) string {
+	parts := make([]string, 0, len(points)*2)
+	for _, point := range points {
+		parts = append(parts, FormatNumber(point.X, 3), FormatNumber(point.Y, 3))
+	}
+	return strings.Join(parts, ",")
+}
+
+// FormatNumber 格式化数值，最多保留指定位数的小数并去除多余的0
+func FormatNumber(value float64, decimals int) string {
+	scale := math.Pow(10, float64(decimals))
+	value = math.Round(value*scale) / scale
+	if value == 0 {
+		value = 0 // 避免输出 -0
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// ParsePathData 解析SVG路径数据（d属性）
+func ParsePathData(data string) (Path, error) {
+	s := &pathScanner{data: data}
+	var path Path
+	var current, start, lastControl Point
+	var lastCommand byte
+	command := byte(0)
+
+	for {
+		s.skipSeparators()
+		if s.done() {
+			break
+		}
+		if c := s.data[s.pos]; isPathCommand(c) {
+			command = c
+			s.pos++
+		} else if command == 0 {
+			return nil, fmt.Errorf("路径数据必须以M命令开始")
+		} else if command == 'Z' || command == 'z' {
+			return nil, fmt.Errorf("位置 %d: Z命令后不能跟数字", s.pos)
+		}
+
+		relative := command >= 'a'
+		upper := command &^ 0x20
+		base := Point{}
+		if relative {
+			base = current
+		}
+
+		switch upper {
+		case 'M', 'L', 'T':
+			p, err := s.point(base)
+			if err != nil {
+				return nil, err
+			}
+			switch {
+			case upper == 'M':
+				path = append(path, Segment{Op: MoveTo, Points: [3]Point{p}})
+				start = p
+				// M后的隐式坐标按L处理
+				if relative {
+					command = 'l'
+				} else {
+					command = 'L'
+				}
+			case upper == 'L':
+				path = append(path, Segment{Op: LineTo, Points: [3]Point{p}})
+			default:
+				control := current
+				if lastCommand == 'Q' || lastCommand == 'T' {
+					control = reflect(lastControl, current)
+				}
+				path = append(path, Segment{Op: QuadTo, Points: [3]Point{control, p}})
+				lastControl = control
+			}
+			current = p
+		case 'H', 'V':
+			value, err := s.number()
+			if err != nil {
+				return nil, err
+			}
+			p := current
+			if upper == 'H' {
+				p.X = value + base.X
+			} else {
+				p.Y = value + base.Y
+			}
+			path = append(path, Segment{Op: LineTo, Points: [3]Point{p}})
+			current = p
+		case 'C', 'S':
+			var c1 Point
+			if upper == 'C' {
+				var err error
+				if c1, err = s.point(base); err != nil {
+					return nil, err
+				}
+			} else {
+				c1 = current
+				if lastCommand == 'C' || lastCommand == 'S' {
+					c1 = reflect(lastControl, current)
+				}
+			}
+			c2, err := s.point(base)
+			if err != nil {
+				return nil, err
+			}
+			p, err := s.point(base)
+			if err != nil {
+				return nil, err
+			}
+			path = append(path, Segment{Op: CubicTo, Points: [3]Point{c1, c2, p}})
+			lastControl, current = c2, p
+		case 'Q':
+			c, err := s.point(base)
+			if err != nil {
+				return nil, err
+			}
+			p, err := s.point(base)
+			if err != nil {
+				return nil, err
+			}
+			path = append(path, Segment{Op: QuadTo, Points: [3]Point{c, p}})
+			lastControl, current = c, p
+		case 'A':
+			rx, err := s.number()
+			if err != nil {
+				return nil, err
+			}
+			ry, err := s.number()
+			if err != nil {
+				return nil, err
+			}
+			rotation, err := s.number()
+			if err != nil {
+				return nil, err
+			}
+			large, err := s.flag()
+			if err != nil {
+				return nil, err
+			}
+			sweep, err := s.flag()
+			if err != nil {
+				return nil, err
+			}
+			p, err := s.point(base)
+			if err != nil {
+				return nil, err
+			}
+			path = appendArc(path, current, p, rx, ry, rotation, large, sweep)
+			current = p
+		case 'Z':
+			path = append(path, Segment{Op: Close})
+			current = start
+		}
+		lastCommand = upper
+	}
+
+	return path, nil
+}
+
+// reflect 获取控制点关于当前点的对称点，用于S/T命令
+func reflect(control, current Point) Point {
+	return Point{2*current.X - control.X, 2*current.Y - control.Y}
+}
+
+// isPathCommand 判断是否为路径命令字母
+func isPathCommand(c byte) bool {
+	return strings.IndexByte("MmLlHhVvCcSsQqTtAaZz", c) >= 0
+}
+
+// appendArc 将椭圆弧转换为不超过90度的三次贝塞尔曲线，按SVG规范的端点参数化换算中心点
+func appendArc(path Path, from, to Point, rx, ry, rotation float64, large, sweep bool) Path {
+	if from == to {
+		return path
+	}
+	rx, ry = math.Abs(rx), math.Abs(ry)
+	if rx == 0 || ry == 0 {
+		return append(path, Segment{Op: LineTo, Points: [3]Point{to}})
+	}
+
+	sinPhi, cosPhi := math.Sincos(rotation * math.Pi / 180)
+	dx, dy := (from.X-to.X)/2, (from.Y-to.Y)/2
+	x1 := cosPhi*dx + sinPhi*dy
+	y1 := -sinPhi*dx + cosPhi*dy
+
+	// 半径过小时按比例放大
+	if lambda := x1*x1/(rx*rx) + y1*y1/(ry*ry); lambda > 1 {
+		rx *= math.Sqrt(lambda)
+		ry *= math.Sqrt(lambda)
+	}
+
+	numerator := rx*rx*ry*ry - rx*rx*y1*y1 - ry*ry*x1*x1
+	denominator := rx*rx*y1*y1 + ry*ry*x1*x1
+	coefficient := math.Sqrt(math.Max(0, numerator/denominator))
+	if large == sweep {
+		coefficient = -coefficient
+	}
+	cx1 := coefficient * rx * y1 / ry
+	cy1 := -coefficient * ry * x1 / rx
+	cx := cosPhi*cx1 - sinPhi*cy1 + (from.X+to.X)/2
+	cy := sinPhi*cx1 + cosPhi*cy1 + (from.Y+to.Y)/2
+
+	angle := func(ux, uy, vx, vy float64) float64 {
+		return math.Atan2(ux*vy-uy*vx, ux*vx+uy*vy)
+	}
+	theta := angle(1, 0, (x1-cx1)/rx, (y1-cy1)/ry)
+	delta := angle((x1-cx1)/rx, (y1-cy1)/ry, (-x1-cx1)/rx, (-y1-cy1)/ry)
+	if !sweep && delta > 0 {
+		delta -= 2 * math.Pi
+	} else if sweep && delta < 0 {
+		delta += 2 * math.Pi
+	}
+
+	segments := int(math.Ceil(math.Abs(delta) / (math.Pi / 2)))
+	step := delta / float64(segments)
+	k := 4.0 / 3 * math.Tan(step/4)
+	point := func(t float64) (Point, Point) {
+		sin, cos := math.Sincos(t)
+		// 椭圆上的点和切线方向
+		px, py := rx*cos, ry*sin
+		tx, ty := -rx*sin, ry*cos
+		return Point{cosPhi*px - sinPhi*py + cx, sinPhi*px + cosPhi*py + cy},
+			Point{cosPhi*tx - sinPhi*ty, sinPhi*tx + cosPhi*ty}
+	}
+
+	start, startTangent := point(theta)
+	for i := 1; i <= segments; i++ {
+		end, endTangent := point(theta + step*float64(i))
+		if i == segments {
+			end = to
+		}
+		path = append(path, Segment{Op: CubicTo, Points: [3]Point{
+			{start.X + k*startTangent.X, start.Y + k*startTangent.Y},
+			{end.X - k*endTangent.X, end.Y - k*endTangent.Y},
+			end,
+		}})
+		start, startTangent = end, endTangent
+	}
+	return path
+}
+
+// pathScanner 路径数据的词法扫描
+type pathScanner struct {
+	data string
+	pos  int
+}
+
+// done 判断是否已扫描完
+func (s *pathScanner) done() bool {
+	return s.pos >= len(s.data)
+}
+
+// skipSeparators 跳过空白和逗号
+func (s *pathScanner) skipSeparators() {
+	for !s.done() && strings.IndexByte(" \t\r\n,", s.data[s.pos]) >= 0 {
+		s.pos++
+	}
+}
+
+// number 读取一个数值，支持 .5.5、1e-3、-1-2 等紧凑写法
+func (s *pathScanner) number() (float64, error) {
+	s.skipSeparators()
+	start := s.pos
+	if !s.done() && (s.data[s.pos] == '+' || s.data[s.pos] == '-') {
+		s.pos++
+	}
+	digits, dot := 0, false
+	for !s.done() {
+		c := s.data[s.pos]
+		if c >= '0' && c <= '9' {
+			digits++
+		} else if c == '.' && !dot {
+			dot = true
+		} else {
+			break
+		}
+		s.pos++
+	}
+	if digits > 0 && !s.done() && (s.data[s.pos] == 'e' || s.data[s.pos] == 'E') {
+		exponent := s.pos + 1
+		if exponent < len(s.data) && (s.data[exponent] == '+' || s.data[exponent] == '-') {
+			exponent++
+		}
+		if exponent < len(s.data) && s.data[exponent] >= '0' && s.data[exponent] <= '9' {
+			s.pos = exponent
+			for !s.done() && s.data[s.pos] >= '0' && s.data[s.pos] <= '9' {
+				s.pos++
+			}
+		}
+	}
+	if digits == 0 {
+		s.pos = start
+		return 0, fmt.Errorf("位置 %d: 缺少数值", start)
+	}
+	return strconv.ParseFloat(s.data[start:s.pos], 64)
+}
+
+// flag 读取圆弧的标志位，标志位后可以不带分隔符，如 a1 1 0 011 1
+func (s *pathScanner) flag() (bool, error) {
+	s.skipSeparators()
+	if s.done() || (s.data[s.pos] != '0' && s.data[s.pos] != '1') {
+		return false, fmt.Errorf("位置 %d: 圆弧标志位必须是0或1", s.pos)
+	}
+	s.pos++
+	return s.data[s.pos-1] == '1', nil
+}
+
+// point 读取一对坐标，相对命令加上base
+func (s *pathScanner) point(base Point) (Point, error) {
+	x, err := s.number()
+	if err != nil {
+		return Point{}, err
+	}
+	y, err := s.number()
+	if err != nil {
+		return Point{}, err
+	}
+	return Point{x + base.X, y + base.Y}, nil
+}
+
+// parseNumberList 解析以空白或逗号分隔的数值列表
+func parseNumberList(value string) ([]float64, error) {
+	s := &pathScanner{data: value}
+	var numbers []float64
+	for {
+		s.skipSeparators()
+		if s.done() {
+			return numbers, nil
+		}
+		number, err := s.number()
+		if err != nil {
+			return nil, err
+		}
+		numbers = append(numbers, number)
+	}
+}
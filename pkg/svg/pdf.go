@@ -0,0 +1,277 @@
+package svg
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// PDF 转换为单页PDF，页面尺寸为SVG的显示尺寸（1px = 1pt），viewBox按preserveAspectRatio映射到页面
+// PDF需要精确还原，文档包含不支持的特性或PDF无法表示的绘制方式时返回错误
+func (d *Document) PDF() ([]byte, error) {
+	if len(d.Unsupported) > 0 {
+		return nil, fmt.Errorf("包含不支持的特性: %s", strings.Join(d.Unsupported, "; "))
+	}
+
+	// PDF的原点在左下角，y轴向上
+	base := Matrix{A: 1, D: -1, F: d.Height}.Multiply(d.ViewBoxTransform())
+	w := &pdfWriter{states: make(map[string]string)}
+	for _, shape := range d.Shapes {
+		if err := w.shape(shape, base); err != nil {
+			return nil, fmt.Errorf("%s %w", shape.Name(), err)
+		}
+	}
+
+	var stream bytes.Buffer
+	zw := zlib.NewWriter(&stream)
+	if _, err := zw.Write([]byte(w.content.String())); err != nil {
+		return nil, fmt.Errorf("压缩PDF内容失败: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("压缩PDF内容失败: %w", err)
+	}
+
+	var resources strings.Builder
+	resources.WriteString("<< ")
+	if len(w.stateDicts) > 0 {
+		resources.WriteString("/ExtGState << " + strings.Join(w.stateDicts, " ") + " >> ")
+	}
+	if len(w.patterns) > 0 {
+		resources.WriteString("/Pattern << " + strings.Join(w.patterns, " ") + " >> ")
+	}
+	resources.WriteString(">>")
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources %s /Contents 4 0 R >>",
+			pdfNumber(d.Width), pdfNumber(d.Height), resources.String()),
+		fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", stream.Len(), stream.String()),
+	}
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n") // 1.4起支持透明度，第二行的二进制注释标记文件包含二进制数据
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return out.Bytes(), nil
+}
+
+// pdfWriter 生成页面内容和资源
+type pdfWriter struct {
+	content    strings.Builder
+	states     map[string]string // 透明度设置到ExtGState名称
+	stateDicts []string          // /GS0 << ... >> 形式的资源
+	patterns   []string          // /P0 << ... >> 形式的资源
+}
+
+// pdfLineCaps SVG线帽对应的PDF线帽样式
+var pdfLineCaps = map[string]int{"butt": 0, "round": 1, "square": 2}
+
+// pdfLineJoins SVG线连接对应的PDF线连接样式
+var pdfLineJoins = map[string]int{"miter": 0, "miter-clip": 0, "round": 1, "bevel": 2}
+
+// shape 绘制一个图形，裁剪路径和填充、描边都在独立的图形状态中
+func (w *pdfWriter) shape(shape *Shape, base Matrix) error {
+	transform := base.Multiply(shape.Transform)
+	if transform.Determinant() == 0 {
+		return nil // 缩放为0的图形不可见
+	}
+
+	w.content.WriteString("q\n")
+	for _, clip := range shape.Clips {
+		w.path(clip.Path.Transform(base.Multiply(clip.Transform)))
+		if clip.EvenOdd {
+			w.content.WriteString("W* n\n")
+		} else {
+			w.content.WriteString("W n\n")
+		}
+	}
+	// 之后的路径和线宽都使用图形自身的用户坐标
+	w.content.WriteString(pdfMatrix(transform) + " cm\n")
+
+	if !shape.Fill.IsNone() {
+		w.content.WriteString("q\n")
+		if err := w.paint(shape.Fill, shape.FillAlpha(), false, transform); err != nil {
+			return fmt.Errorf("的填充%w", err)
+		}
+		w.path(shape.Path)
+		if shape.EvenOdd {
+			w.content.WriteString("f*\nQ\n")
+		} else {
+			w.content.WriteString("f\nQ\n")
+		}
+	}
+
+	if !shape.Stroke.IsNone() {
+		if shape.LineJoin == "arcs" {
+			return fmt.Errorf("的 stroke-linejoin=\"arcs\" 无法转换为PDF")
+		}
+		w.content.WriteString("q\n")
+		if err := w.paint(shape.Stroke, shape.StrokeAlpha(), true, transform); err != nil {
+			return fmt.Errorf("的描边%w", err)
+		}
+		fmt.Fprintf(&w.content, "%s w %d J %d j %s M\n", pdfNumber(shape.StrokeWidth),
+			pdfLineCaps[shape.LineCap], pdfLineJoins[shape.LineJoin], pdfNumber(math.Max(shape.MiterLimit, 1)))
+		w.path(shape.Path)
+		w.content.WriteString("S\nQ\n")
+	}
+
+	w.content.WriteString("Q\n")
+	return nil
+}
+
+// paint 设置填充或描边的颜色、透明度，transform为图形用户坐标到页面坐标的变换
+func (w *pdfWriter) paint(paint Paint, alpha float64, stroke bool, transform Matrix) error {
+	if paint.Color != nil {
+		alpha *= paint.Color.A
+	}
+	if alpha < 1 {
+		key := "ca"
+		if stroke {
+			key = "CA"
+		}
+		w.content.WriteString("/" + w.state(key, alpha) + " gs\n")
+	}
+
+	if paint.Color != nil {
+		operator := "rg"
+		if stroke {
+			operator = "RG"
+		}
+		fmt.Fprintf(&w.content, "%s %s\n", pdfColor(*paint.Color), operator)
+		return nil
+	}
+
+	// 渐变使用图案颜色空间，图案的矩阵相对于页面坐标
+	name, err := w.pattern(paint.Gradient, transform.Multiply(paint.Gradient.Transform))
+	if err != nil {
+		return err
+	}
+	if stroke {
+		fmt.Fprintf(&w.content, "/Pattern CS /%s SCN\n", name)
+	} else {
+		fmt.Fprintf(&w.content, "/Pattern cs /%s scn\n", name)
+	}
+	return nil
+}
+
+// state 获取指定透明度的ExtGState名称，相同的设置共用一个资源
+func (w *pdfWriter) state(key string, alpha float64) string {
+	value := fmt.Sprintf("/%s %s", key, pdfNumber(alpha))
+	if name, ok := w.states[value]; ok {
+		return name
+	}
+	name := fmt.Sprintf("GS%d", len(w.stateDicts))
+	w.states[value] = name
+	w.stateDicts = append(w.stateDicts, fmt.Sprintf("/%s << /Type /ExtGState %s >>", name, value))
+	return name
+}
+
+// pattern 将渐变转换为着色图案（Shading Pattern）
+func (w *pdfWriter) pattern(g *Gradient, matrix Matrix) (string, error) {
+	if g.Spread != "pad" {
+		return "", fmt.Errorf("使用了 spreadMethod=\"%s\" 的渐变，无法转换为PDF", g.Spread)
+	}
+	for _, stop := range g.Stops {
+		if stop.Color.A < 1 {
+			return "", fmt.Errorf("使用了半透明的渐变色标，无法转换为PDF")
+		}
+	}
+
+	var shading string
+	if g.Linear {
+		shading = fmt.Sprintf("/ShadingType 2 /Coords [%s %s %s %s]",
+			pdfNumber(g.X1), pdfNumber(g.Y1), pdfNumber(g.X2), pdfNumber(g.Y2))
+	} else {
+		shading = fmt.Sprintf("/ShadingType 3 /Coords [%s %s 0 %s %s %s]",
+			pdfNumber(g.FX), pdfNumber(g.FY), pdfNumber(g.CX), pdfNumber(g.CY), pdfNumber(g.R))
+	}
+
+	name := fmt.Sprintf("P%d", len(w.patterns))
+	w.patterns = append(w.patterns, fmt.Sprintf(
+		"/%s << /PatternType 2 /Matrix [%s] /Shading << %s /ColorSpace /DeviceRGB /Function %s /Extend [true true] >> >>",
+		name, pdfMatrix(matrix), shading, gradientFunction(g.Stops)))
+	return name, nil
+}
+
+// gradientFunction 生成色标插值函数：每两个色标之间为线性插值（Type 2），多段时拼接（Type 3）
+func gradientFunction(stops []Stop) string {
+	// 首尾色标不在0和1时，延伸为纯色
+	if stops[0].Offset > 0 {
+		stops = append([]Stop{{Offset: 0, Color: stops[0].Color}}, stops...)
+	}
+	if last := stops[len(stops)-1]; last.Offset < 1 {
+		stops = append(stops, Stop{Offset: 1, Color: last.Color})
+	}
+
+	var functions, bounds, encode []string
+	for i := 0; i+1 < len(stops); i++ {
+		functions = append(functions, fmt.Sprintf("<< /FunctionType 2 /Domain [0 1] /C0 [%s] /C1 [%s] /N 1 >>",
+			pdfColor(stops[i].Color), pdfColor(stops[i+1].Color)))
+		encode = append(encode, "0 1")
+		if i > 0 {
+			bounds = append(bounds, pdfNumber(stops[i].Offset))
+		}
+	}
+	if len(functions) == 1 {
+		return functions[0]
+	}
+	return fmt.Sprintf("<< /FunctionType 3 /Domain [0 1] /Functions [%s] /Bounds [%s] /Encode [%s] >>",
+		strings.Join(functions, " "), strings.Join(bounds, " "), strings.Join(encode, " "))
+}
+
+// path 写入路径构造操作，二次贝塞尔曲线转换为三次
+func (w *pdfWriter) path(path Path) {
+	var current, start Point
+	for _, segment := range path {
+		points := segment.Points
+		switch segment.Op {
+		case MoveTo:
+			fmt.Fprintf(&w.content, "%s %s m\n", pdfNumber(points[0].X), pdfNumber(points[0].Y))
+			start = points[0]
+		case LineTo:
+			fmt.Fprintf(&w.content, "%s %s l\n", pdfNumber(points[0].X), pdfNumber(points[0].Y))
+		case QuadTo:
+			c1 := Point{current.X + 2.0/3*(points[0].X-current.X), current.Y + 2.0/3*(points[0].Y-current.Y)}
+			c2 := Point{points[1].X + 2.0/3*(points[0].X-points[1].X), points[1].Y + 2.0/3*(points[0].Y-points[1].Y)}
+			fmt.Fprintf(&w.content, "%s %s %s %s %s %s c\n", pdfNumber(c1.X), pdfNumber(c1.Y),
+				pdfNumber(c2.X), pdfNumber(c2.Y), pdfNumber(points[1].X), pdfNumber(points[1].Y))
+		case CubicTo:
+			fmt.Fprintf(&w.content, "%s %s %s %s %s %s c\n", pdfNumber(points[0].X), pdfNumber(points[0].Y),
+				pdfNumber(points[1].X), pdfNumber(points[1].Y), pdfNumber(points[2].X), pdfNumber(points[2].Y))
+		case Close:
+			w.content.WriteString("h\n")
+			current = start
+			continue
+		}
+		current = segment.End()
+	}
+}
+
+// pdfMatrix 格式化变换矩阵
+func pdfMatrix(m Matrix) string {
+	return strings.Join([]string{
+		pdfNumber(m.A), pdfNumber(m.B), pdfNumber(m.C), pdfNumber(m.D), pdfNumber(m.E), pdfNumber(m.F),
+	}, " ")
+}
+
+// pdfColor 格式化RGB颜色分量
+func pdfColor(c Color) string {
+	return fmt.Sprintf("%s %s %s", pdfNumber(c.R), pdfNumber(c.G), pdfNumber(c.B))
+}
+
+// pdfNumber 格式化数值，PDF不支持科学计数法
+func pdfNumber(value float64) string {
+	return FormatNumber(value, 4)
+}
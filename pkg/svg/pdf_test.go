@@ -0,0 +1,272 @@
+package svg
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// pdfFile 解析后的PDF
+type pdfFile struct {
+	data    []byte
+	objects map[int]string // 对象编号 -> obj和endobj之间的内容
+	content string         // 解压后的页面内容
+}
+
+// objectPattern PDF中的间接对象
+var objectPattern = regexp.MustCompile(`(?s)(\d+) 0 obj\n(.*?)\nendobj\n`)
+
+// convertPDF 解析SVG并转换为PDF，检查文件结构和交叉引用表
+func convertPDF(t *testing.T, source string) *pdfFile {
+	t.Helper()
+	doc, err := Parse([]byte(source))
+	if err != nil {
+		t.Fatalf("解析SVG失败: %v", err)
+	}
+	data, err := doc.PDF()
+	if err != nil {
+		t.Fatalf("PDF() 失败: %v", err)
+	}
+
+	file := &pdfFile{data: data, objects: make(map[int]string)}
+	if !bytes.HasPrefix(data, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(data, []byte("%%EOF\n")) {
+		t.Fatalf("PDF文件头或文件尾不正确:\n%s", data)
+	}
+	for _, match := range objectPattern.FindAllSubmatchIndex(data, -1) {
+		number, _ := strconv.Atoi(string(data[match[2]:match[3]]))
+		file.objects[number] = string(data[match[4]:match[5]])
+	}
+
+	// startxref指向xref表，xref表中的偏移指向各个对象
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(data)
+	if startxref == nil {
+		t.Fatalf("没有startxref")
+	}
+	xref, _ := strconv.Atoi(string(startxref[1]))
+	if !bytes.HasPrefix(data[xref:], []byte("xref\n")) {
+		t.Fatalf("startxref %d 没有指向xref表", xref)
+	}
+	offsets := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(data[xref:], -1)
+	if len(offsets) != len(file.objects) {
+		t.Fatalf("xref表有 %d 个对象，文件中有 %d 个", len(offsets), len(file.objects))
+	}
+	for i, offset := range offsets {
+		position, _ := strconv.Atoi(string(offset[1]))
+		if want := fmt.Sprintf("%d 0 obj\n", i+1); !bytes.HasPrefix(data[position:], []byte(want)) {
+			t.Errorf("xref中对象 %d 的偏移 %d 不正确", i+1, position)
+		}
+	}
+
+	// 页面内容流
+	stream := file.objects[4]
+	length := regexp.MustCompile(`/Length (\d+)`).FindStringSubmatch(stream)
+	start := strings.Index(stream, "stream\n") + len("stream\n")
+	end := strings.LastIndex(stream, "\nendstream")
+	if length == nil || start < len("stream\n") || end < start {
+		t.Fatalf("内容流格式不正确:\n%s", stream)
+	}
+	if want, _ := strconv.Atoi(length[1]); end-start != want {
+		t.Errorf("内容流长度为 %d，/Length为 %d", end-start, want)
+	}
+	reader, err := zlib.NewReader(strings.NewReader(stream[start:end]))
+	if err != nil {
+		t.Fatalf("解压内容流失败: %v", err)
+	}
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("解压内容流失败: %v", err)
+	}
+	file.content = string(content)
+	return file
+}
+
+func TestPDF(t *testing.T) {
+	tests := []struct {
+		name      string
+		svg       string
+		content   []string // 页面内容中应依次出现的片段
+		resources []string // 页面资源中应包含的内容
+	}{
+		{
+			name: "填充",
+			svg: `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="32" viewBox="0 0 24 32">
+				<rect x="2" y="4" width="10" height="6" fill="#ff0000"/>
+			</svg>`,
+			content: []string{
+				"q\n",
+				"1 0 0 -1 0 32 cm\n", // y轴翻转到PDF坐标
+				"1 0 0 rg\n",
+				"2 4 m\n12 4 l\n12 10 l\n2 10 l\nh\n",
+				"f\nQ\nQ\n",
+			},
+		},
+		{
+			name: "viewBox缩放",
+			svg: `<svg xmlns="http://www.w3.org/2000/svg" width="48" height="48" viewBox="0 0 24 24">
+				<rect width="24" height="24" fill="#000" fill-rule="evenodd"/>
+			</svg>`,
+			content: []string{"2 0 0 -2 0 48 cm\n", "0 0 0 rg\n", "f*\n"},
+		},
+		{
+			name: "透明度",
+			svg: `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24">
+				<rect width="10" height="10" fill="#000" fill-opacity="0.5"/>
+				<rect width="10" height="10" fill="rgba(0,0,0,0.5)"/>
+				<rect width="10" height="10" fill="none" stroke="#000" stroke-opacity="0.25"/>
+			</svg>`,
+			content: []string{"/GS0 gs\n", "/GS0 gs\n", "/GS1 gs\n"},
+			resources: []string{
+				"/ExtGState << /GS0 << /Type /ExtGState /ca 0.5 >> /GS1 << /Type /ExtGState /CA 0.25 >> >>",
+			},
+		},
+		{
+			name: "描边",
+			svg: `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24">
+				<path d="M2 2 L22 22" fill="none" stroke="#0000ff" stroke-width="3" stroke-linecap="round"
+					stroke-linejoin="bevel" stroke-miterlimit="0.5"/>
+			</svg>`,
+			content: []string{"0 0 1 RG\n", "3 w 1 J 2 j 1 M\n", "2 2 m\n22 22 l\nS\n"},
+		},
+		{
+			name: "二次贝塞尔曲线转换为三次",
+			svg: `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24">
+				<path d="M0 0 Q3 3 6 0" fill="#000"/>
+			</svg>`,
+			content: []string{"0 0 m\n2 2 4 2 6 0 c\n"},
+		},
+		{
+			name: "裁剪路径",
+			svg: `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24">
+				<defs><clipPath id="c"><rect width="12" height="24" clip-rule="evenodd"/></clipPath></defs>
+				<rect width="24" height="24" fill="#000" clip-path="url(#c)"/>
+			</svg>`,
+			content: []string{"q\n0 24 m\n12 24 l\n12 0 l\n0 0 l\nh\nW* n\n", "1 0 0 -1 0 24 cm\n"},
+		},
+		{
+			name: "线性渐变",
+			svg: `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24">
+				<defs><linearGradient id="g" x1="0" y1="0" x2="24" y2="0" gradientUnits="userSpaceOnUse">
+					<stop offset="0" stop-color="#ff0000"/><stop offset="1" stop-color="#0000ff"/>
+				</linearGradient></defs>
+				<rect width="24" height="24" fill="url(#g)"/>
+			</svg>`,
+			content: []string{"/Pattern cs /P0 scn\n"},
+			resources: []string{
+				"/P0 << /PatternType 2 /Matrix [1 0 0 -1 0 24] /Shading << /ShadingType 2 /Coords [0 0 24 0]",
+				"/Function << /FunctionType 2 /Domain [0 1] /C0 [1 0 0] /C1 [0 0 1] /N 1 >>",
+			},
+		},
+		{
+			name: "多个色标的径向渐变",
+			svg: `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24">
+				<defs><radialGradient id="g" cx="12" cy="12" r="10" fx="10" fy="10" gradientUnits="userSpaceOnUse">
+					<stop offset="0.2" stop-color="#fff"/><stop offset="0.5" stop-color="#f00"/><stop offset="0.8" stop-color="#000"/>
+				</radialGradient></defs>
+				<rect width="24" height="24" fill="none" stroke="url(#g)"/>
+			</svg>`,
+			content: []string{"/Pattern CS /P0 SCN\n"},
+			resources: []string{
+				"/ShadingType 3 /Coords [10 10 0 12 12 10]",
+				// 首尾色标延伸到0和1
+				"/FunctionType 3 /Domain [0 1] /Functions [" +
+					"<< /FunctionType 2 /Domain [0 1] /C0 [1 1 1] /C1 [1 1 1] /N 1 >> " +
+					"<< /FunctionType 2 /Domain [0 1] /C0 [1 1 1] /C1 [1 0 0] /N 1 >> " +
+					"<< /FunctionType 2 /Domain [0 1] /C0 [1 0 0] /C1 [0 0 0] /N 1 >> " +
+					"<< /FunctionType 2 /Domain [0 1] /C0 [0 0 0] /C1 [0 0 0] /N 1 >>] " +
+					"/Bounds [0.2 0.5 0.8] /Encode [0 1 0 1 0 1 0 1]",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			file := convertPDF(t, test.svg)
+
+			rest := file.content
+			for _, want := range test.content {
+				index := strings.Index(rest, want)
+				if index < 0 {
+					t.Fatalf("页面内容中没有 %q（或顺序不正确）:\n%s", want, file.content)
+				}
+				rest = rest[index+len(want):]
+			}
+
+			page := file.objects[3]
+			for _, want := range test.resources {
+				if !strings.Contains(page, want) {
+					t.Errorf("页面资源中没有 %s:\n%s", want, page)
+				}
+			}
+		})
+	}
+}
+
+func TestPDFMediaBox(t *testing.T) {
+	file := convertPDF(t, `<svg xmlns="http://www.w3.org/2000/svg" width="20.5" height="10" viewBox="0 0 41 20"></svg>`)
+	if !strings.Contains(file.objects[3], "/MediaBox [0 0 20.5 10]") {
+		t.Errorf("页面尺寸应为SVG的显示尺寸:\n%s", file.objects[3])
+	}
+	if file.objects[1] != "<< /Type /Catalog /Pages 2 0 R >>" {
+		t.Errorf("根对象为 %s", file.objects[1])
+	}
+}
+
+func TestPDFErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		svg  string
+		want string
+	}{
+		{
+			name: "不支持的特性",
+			svg: `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24">
+				<text x="0" y="12">Hi</text>
+			</svg>`,
+			want: "包含不支持的特性: 不支持 <text> 元素",
+		},
+		{
+			name: "重复的渐变",
+			svg: `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24">
+				<defs><linearGradient id="g" spreadMethod="repeat">
+					<stop offset="0" stop-color="#fff"/><stop offset="1" stop-color="#000"/>
+				</linearGradient></defs>
+				<rect id="box" width="24" height="24" fill="url(#g)"/>
+			</svg>`,
+			want: `box 的填充使用了 spreadMethod="repeat" 的渐变`,
+		},
+		{
+			name: "半透明的渐变色标",
+			svg: `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24">
+				<defs><linearGradient id="g">
+					<stop offset="0" stop-color="#fff" stop-opacity="0.5"/><stop offset="1" stop-color="#000"/>
+				</linearGradient></defs>
+				<rect id="box" width="24" height="24" fill="none" stroke="url(#g)"/>
+			</svg>`,
+			want: "box 的描边使用了半透明的渐变色标",
+		},
+		{
+			name: "arcs线连接",
+			svg: `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24">
+				<path id="line" d="M0 0 L10 10 L20 0" fill="none" stroke="#000" stroke-linejoin="arcs"/>
+			</svg>`,
+			want: `line 的 stroke-linejoin="arcs" 无法转换为PDF`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			doc, err := Parse([]byte(test.svg))
+			if err != nil {
+				t.Fatalf("解析SVG失败: %v", err)
+			}
+			if _, err := doc.PDF(); err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Errorf("PDF() 错误为 %v，应包含 %q", err, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package svg
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Matrix 二维仿射变换 [A C E; B D F; 0 0 1]，x' = A*x + C*y + E，y' = B*x + D*y + F
+type Matrix struct {
+	A, B, C, D, E, F float64
+}
+
+// Identity 单位矩阵
+var Identity = Matrix{A: 1, D: 1}
+
+// Translate 平移矩阵
+func Translate(x, y float64) Matrix {
+	return Matrix{A: 1, D: 1, E: x, F: y}
+}
+
+// Scale 缩放矩阵
+func Scale(x, y float64) Matrix {
+	return Matrix{A: x, D: y}
+}
+
+// Rotate 旋转矩阵，角度为度数
+func Rotate(degrees float64) Matrix {
+	sin, cos := math.Sincos(degrees * math.Pi / 180)
+	return Matrix{A: cos, B: sin, C: -sin, D: cos}
+}
+
+// Multiply 矩阵乘法 m×n，即先应用n再应用m
+func (m Matrix) Multiply(n Matrix) Matrix {
+	return Matrix{
+		A: m.A*n.A + m.C*n.B,
+		B: m.B*n.A + m.D*n.B,
+		C: m.A*n.C + m.C*n.D,
+		D: m.B*n.C + m.D*n.D,
+		E: m.A*n.E + m.C*n.F + m.E,
+		F: m.B*n.E + m.D*n.F + m.F,
+	}
+}
+
+// Apply 变换点
+func (m Matrix) Apply(p Point) Point {
+	return Point{X: m.A*p.X + m.C*p.Y + m.E, Y: m.B*p.X + m.D*p.Y + m.F}
+}
+
+// Determinant 行列式
+func (m Matrix) Determinant() float64 {
+	return m.A*m.D - m.B*m.C
+}
+
+// Invert 逆矩阵，不可逆时返回false
+func (m Matrix) Invert() (Matrix, bool) {
+	det := m.Determinant()
+	if math.Abs(det) < 1e-12 {
+		return Matrix{}, false
+	}
+	return Matrix{
+		A: m.D / det,
+		B: -m.B / det,
+		C: -m.C / det,
+		D: m.A / det,
+		E: (m.C*m.F - m.D*m.E) / det,
+		F: (m.B*m.E - m.A*m.F) / det,
+	}, true
+}
+
+// IsIdentity 判断是否为单位矩阵
+func (m Matrix) IsIdentity() bool {
+	return m == Identity
+}
+
+// ScaleFactor 平均缩放比例（面积缩放的平方根），用于换算线宽等长度
+func (m Matrix) ScaleFactor() float64 {
+	return math.Sqrt(math.Abs(m.Determinant()))
+}
+
+// IsUniform 判断是否为等比例缩放（没有斜切和非等比缩放），此时圆仍然是圆，线宽可以直接换算
+func (m Matrix) IsUniform() bool {
+	const epsilon = 1e-6
+	orthogonal := math.Abs(m.A*m.C+m.B*m.D) < epsilon
+	equal := math.Abs((m.A*m.A+m.B*m.B)-(m.C*m.C+m.D*m.D)) < epsilon*math.Max(1, m.A*m.A+m.B*m.B)
+	return orthogonal && equal
+}
+
+// parseTransform 解析transform属性，如 translate(10 20) rotate(45)
+func parseTransform(value string) (Matrix, error) {
+	result := Identity
+	rest := strings.TrimSpace(value)
+	for rest != "" {
+		open := strings.IndexByte(rest, '(')
+		end := strings.IndexByte(rest, ')')
+		if open < 0 || end < open {
+			return Identity, fmt.Errorf("无效的transform: %s", value)
+		}
+		name := strings.TrimSpace(rest[:open])
+		args, err := parseNumberList(rest[open+1 : end])
+		if err != nil {
+			return Identity, fmt.Errorf("无效的transform %s: %w", value, err)
+		}
+		rest = strings.TrimLeft(rest[end+1:], " \t\r\n,")
+
+		var m Matrix
+		switch {
+		case name == "matrix" && len(args) == 6:
+			m = Matrix{A: args[0], B: args[1], C: args[2], D: args[3], E: args[4], F: args[5]}
+		case name == "translate" && len(args) == 1:
+			m = Translate(args[0], 0)
+		case name == "translate" && len(args) == 2:
+			m = Translate(args[0], args[1])
+		case name == "scale" && len(args) == 1:
+			m = Scale(args[0], args[0])
+		case name == "scale" && len(args) == 2:
+			m = Scale(args[0], args[1])
+		case name == "rotate" && len(args) == 1:
+			m = Rotate(args[0])
+		case name == "rotate" && len(args) == 3:
+			m = Translate(args[1], args[2]).Multiply(Rotate(args[0])).Multiply(Translate(-args[1], -args[2]))
+		case name == "skewX" && len(args) == 1:
+			m = Matrix{A: 1, C: math.Tan(args[0] * math.Pi / 180), D: 1}
+		case name == "skewY" && len(args) == 1:
+			m = Matrix{A: 1, B: math.Tan(args[0] * math.Pi / 180), D: 1}
+		default:
+			return Identity, fmt.Errorf("无效的transform: %s(%s)", name, strings.TrimSpace(rest))
+		}
+		result = result.Multiply(m)
+	}
+	return result, nil
+}
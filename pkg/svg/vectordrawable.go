@@ -0,0 +1,220 @@
+package svg
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// VectorDrawable 转换为Android VectorDrawable的XML
+// 所有变换都烘焙进路径数据，返回的警告为只能近似处理的特性（不包含Document.Warnings）
+func (d *Document) VectorDrawable() ([]byte, []string) {
+	w := &vectorWriter{warned: make(map[string]bool)}
+
+	// 宽高比一致时保留viewBox坐标，不一致时按preserveAspectRatio换算到显示尺寸
+	viewport := Rect{Width: d.ViewBox.Width, Height: d.ViewBox.Height}
+	base := Translate(-d.ViewBox.X, -d.ViewBox.Y)
+	if !d.AspectMatches() {
+		viewport = Rect{Width: d.Width, Height: d.Height}
+		base = d.ViewBoxTransform()
+	}
+
+	var body strings.Builder
+	var open []*Clip // 当前打开的裁剪分组
+	for _, shape := range d.Shapes {
+		common := 0
+		for common < len(open) && common < len(shape.Clips) && open[common] == shape.Clips[common] {
+			common++
+		}
+		for len(open) > common {
+			open = open[:len(open)-1]
+			body.WriteString(strings.Repeat("    ", len(open)+1) + "</group>\n")
+		}
+		for _, clip := range shape.Clips[common:] {
+			indent := strings.Repeat("    ", len(open)+1)
+			body.WriteString(indent + "<group>\n")
+			body.WriteString(indent + "    " + w.clipPath(clip, base) + "\n")
+			open = append(open, clip)
+		}
+		w.path(&body, shape, base, strings.Repeat("    ", len(open)+1))
+	}
+	for len(open) > 0 {
+		open = open[:len(open)-1]
+		body.WriteString(strings.Repeat("    ", len(open)+1) + "</group>\n")
+	}
+	if len(d.Shapes) == 0 {
+		w.warn("SVG中没有可绘制的图形")
+	}
+
+	var out strings.Builder
+	out.WriteString(`<?xml version="1.0" encoding="utf-8"?>` + "\n")
+	out.WriteString("<!-- 由 app-assets-generator 自动生成，请勿手动修改 -->\n")
+	out.WriteString(`<vector xmlns:android="http://schemas.android.com/apk/res/android"` + "\n")
+	if w.gradients {
+		out.WriteString(`    xmlns:aapt="http://schemas.android.com/aapt"` + "\n")
+	}
+	fmt.Fprintf(&out, "    android:width=\"%sdp\"\n", FormatNumber(d.Width, 2))
+	fmt.Fprintf(&out, "    android:height=\"%sdp\"\n", FormatNumber(d.Height, 2))
+	fmt.Fprintf(&out, "    android:viewportWidth=\"%s\"\n", FormatNumber(viewport.Width, 3))
+	fmt.Fprintf(&out, "    android:viewportHeight=\"%s\">\n", FormatNumber(viewport.Height, 3))
+	out.WriteString(body.String())
+	out.WriteString("</vector>\n")
+	return []byte(out.String()), w.warnings
+}
+
+// vectorWriter 生成VectorDrawable元素
+type vectorWriter struct {
+	gradients bool // 是否使用了渐变（需要aapt命名空间）
+	warnings  []string
+	warned    map[string]bool
+}
+
+// warn 记录警告，相同的警告只记录一次
+func (w *vectorWriter) warn(format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	if w.warned[message] {
+		return
+	}
+	w.warned[message] = true
+	w.warnings = append(w.warnings, message)
+}
+
+// clipPath 生成裁剪路径
+func (w *vectorWriter) clipPath(clip *Clip, base Matrix) string {
+	if clip.EvenOdd {
+		w.warn("VectorDrawable的裁剪路径只支持nonzero规则，clip-rule=\"evenodd\" 可能显示不正确")
+	}
+	data := clip.Path.Transform(base.Multiply(clip.Transform)).Data()
+	return fmt.Sprintf(`<clip-path android:pathData="%s" />`, data)
+}
+
+// path 生成一个<path>元素
+func (w *vectorWriter) path(out *strings.Builder, shape *Shape, base Matrix, indent string) {
+	transform := base.Multiply(shape.Transform)
+	data := shape.Path.Transform(transform).Data()
+
+	var attrs []string
+	if shape.ID != "" {
+		attrs = append(attrs, fmt.Sprintf(`android:name="%s"`, escapeAttr(shape.ID)))
+	}
+	attrs = append(attrs, fmt.Sprintf(`android:pathData="%s"`, data))
+
+	var gradients []string
+	if shape.Fill.Color != nil {
+		attrs = append(attrs, fmt.Sprintf(`android:fillColor="%s"`, shape.Fill.Color.Hex()))
+	} else if shape.Fill.Gradient != nil {
+		gradients = append(gradients, w.gradient("android:fillColor", shape.Fill.Gradient, transform, indent))
+	}
+	if !shape.Fill.IsNone() {
+		if alpha := shape.FillAlpha(); alpha < 1 {
+			attrs = append(attrs, fmt.Sprintf(`android:fillAlpha="%s"`, FormatNumber(alpha, 3)))
+		}
+		if shape.EvenOdd {
+			attrs = append(attrs, `android:fillType="evenOdd"`)
+		}
+	}
+
+	if !shape.Stroke.IsNone() {
+		if shape.Stroke.Color != nil {
+			attrs = append(attrs, fmt.Sprintf(`android:strokeColor="%s"`, shape.Stroke.Color.Hex()))
+		} else {
+			gradients = append(gradients, w.gradient("android:strokeColor", shape.Stroke.Gradient, transform, indent))
+		}
+		if !transform.IsUniform() {
+			w.warn("%s 的描边经过非等比缩放或斜切，VectorDrawable只能使用统一的线宽", shape.Name())
+		}
+		attrs = append(attrs, fmt.Sprintf(`android:strokeWidth="%s"`, FormatNumber(shape.StrokeWidth*transform.ScaleFactor(), 3)))
+		if alpha := shape.StrokeAlpha(); alpha < 1 {
+			attrs = append(attrs, fmt.Sprintf(`android:strokeAlpha="%s"`, FormatNumber(alpha, 3)))
+		}
+		if shape.LineCap == "round" || shape.LineCap == "square" {
+			attrs = append(attrs, fmt.Sprintf(`android:strokeLineCap="%s"`, shape.LineCap))
+		}
+		switch shape.LineJoin {
+		case "round", "bevel":
+			attrs = append(attrs, fmt.Sprintf(`android:strokeLineJoin="%s"`, shape.LineJoin))
+		case "miter", "miter-clip":
+			if shape.MiterLimit != 4 {
+				attrs = append(attrs, fmt.Sprintf(`android:strokeMiterLimit="%s"`, FormatNumber(shape.MiterLimit, 3)))
+			}
+		case "arcs":
+			w.warn("不支持 stroke-linejoin=\"arcs\"，按miter处理")
+		}
+	}
+
+	separator := "\n" + indent + "    "
+	if len(gradients) == 0 {
+		out.WriteString(indent + "<path" + separator + strings.Join(attrs, separator) + " />\n")
+		return
+	}
+	out.WriteString(indent + "<path" + separator + strings.Join(attrs, separator) + ">\n")
+	for _, gradient := range gradients {
+		out.WriteString(gradient)
+	}
+	out.WriteString(indent + "</path>\n")
+}
+
+// tileModes SVG spreadMethod对应的VectorDrawable tileMode
+var tileModes = map[string]string{"pad": "clamp", "reflect": "mirror", "repeat": "repeated"}
+
+// gradient 生成 <aapt:attr> 内联渐变，坐标换算到viewport坐标
+func (w *vectorWriter) gradient(attr string, g *Gradient, shapeTransform Matrix, indent string) string {
+	w.gradients = true
+	transform := shapeTransform.Multiply(g.Transform)
+
+	var attrs []string
+	if g.Linear {
+		// 仿射变换后等值线不一定垂直于起点到终点的连线，需要重新计算终点，使渐变方向沿等值线的法线
+		start := transform.Apply(Point{g.X1, g.Y1})
+		end := transform.Apply(Point{g.X2, g.Y2})
+		if inverse, ok := transform.Invert(); ok {
+			dx, dy := g.X2-g.X1, g.Y2-g.Y1
+			if length := dx*dx + dy*dy; length > 0 {
+				// t(q) = n·(q - start)，n = (T⁻¹)ᵀ·d / |d|²
+				nx := (inverse.A*dx + inverse.B*dy) / length
+				ny := (inverse.C*dx + inverse.D*dy) / length
+				if norm := nx*nx + ny*ny; norm > 0 {
+					end = Point{start.X + nx/norm, start.Y + ny/norm}
+				}
+			}
+		}
+		attrs = append(attrs,
+			`android:type="linear"`,
+			fmt.Sprintf(`android:startX="%s"`, FormatNumber(start.X, 3)),
+			fmt.Sprintf(`android:startY="%s"`, FormatNumber(start.Y, 3)),
+			fmt.Sprintf(`android:endX="%s"`, FormatNumber(end.X, 3)),
+			fmt.Sprintf(`android:endY="%s"`, FormatNumber(end.Y, 3)))
+	} else {
+		if !transform.IsUniform() {
+			w.warn("VectorDrawable不支持椭圆形的径向渐变，已按圆形近似")
+		}
+		if g.FX != g.CX || g.FY != g.CY {
+			w.warn("VectorDrawable不支持径向渐变的焦点（fx/fy），已忽略")
+		}
+		center := transform.Apply(Point{g.CX, g.CY})
+		attrs = append(attrs,
+			`android:type="radial"`,
+			fmt.Sprintf(`android:centerX="%s"`, FormatNumber(center.X, 3)),
+			fmt.Sprintf(`android:centerY="%s"`, FormatNumber(center.Y, 3)),
+			fmt.Sprintf(`android:gradientRadius="%s"`, FormatNumber(math.Max(g.R*transform.ScaleFactor(), 0.001), 3)))
+	}
+	if mode := tileModes[g.Spread]; mode != "clamp" {
+		attrs = append(attrs, fmt.Sprintf(`android:tileMode="%s"`, mode))
+	}
+
+	var out strings.Builder
+	inner := indent + "    "
+	fmt.Fprintf(&out, "%s<aapt:attr name=\"%s\">\n", inner, attr)
+	fmt.Fprintf(&out, "%s    <gradient\n%s        %s>\n", inner, inner, strings.Join(attrs, "\n"+inner+"        "))
+	for _, stop := range g.Stops {
+		fmt.Fprintf(&out, "%s        <item android:offset=\"%s\" android:color=\"%s\" />\n", inner, FormatNumber(stop.Offset, 4), stop.Color.Hex())
+	}
+	fmt.Fprintf(&out, "%s    </gradient>\n", inner)
+	fmt.Fprintf(&out, "%s</aapt:attr>\n", inner)
+	return out.String()
+}
+
+// escapeAttr 转义XML属性值
+func escapeAttr(value string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;").Replace(value)
+}
@@ -0,0 +1,204 @@
+package svg
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+// vectorDrawable 解析SVG并转换为VectorDrawable，检查输出是格式正确的XML
+func vectorDrawable(t *testing.T, source string) (string, []string) {
+	t.Helper()
+	doc, err := Parse([]byte(source))
+	if err != nil {
+		t.Fatalf("解析SVG失败: %v", err)
+	}
+	data, warnings := doc.VectorDrawable()
+
+	decoder := xml.NewDecoder(strings.NewReader(string(data)))
+	for {
+		if _, err := decoder.Token(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("VectorDrawable不是格式正确的XML: %v\n%s", err, data)
+		}
+	}
+	return string(data), warnings
+}
+
+func TestVectorDrawable(t *testing.T) {
+	tests := []struct {
+		name     string
+		svg      string
+		contains []string // 输出中应包含的内容
+		absent   []string // 输出中不应包含的内容
+		warnings []string // 每条警告应包含的内容，按顺序
+	}{
+		{
+			name: "填充路径",
+			svg: `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24">
+				<path id="tri&amp;angle" d="M2 2 L22 2 L12 20 Z" fill="#ff0000" fill-opacity="0.5" fill-rule="evenodd"/>
+			</svg>`,
+			contains: []string{
+				`android:width="24dp"`,
+				`android:viewportWidth="24"`,
+				`android:name="tri&amp;angle"`,
+				`android:pathData="M2,2 L22,2 L12,20 Z"`,
+				`android:fillColor="#FF0000"`,
+				`android:fillAlpha="0.5"`,
+				`android:fillType="evenOdd"`,
+			},
+			absent: []string{"xmlns:aapt", "strokeColor"},
+		},
+		{
+			name: "变换烘焙进路径",
+			svg: `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24">
+				<g transform="translate(4 6)"><rect x="1" y="1" width="10" height="5" fill="#000"/></g>
+			</svg>`,
+			contains: []string{`android:pathData="M5,7 L15,7 L15,12 L5,12 Z"`},
+			absent:   []string{"<group"},
+		},
+		{
+			name: "viewBox的原点",
+			svg: `<svg xmlns="http://www.w3.org/2000/svg" width="48" height="48" viewBox="10 20 24 24">
+				<rect x="10" y="20" width="24" height="24" fill="#000"/>
+			</svg>`,
+			contains: []string{
+				`android:width="48dp"`,
+				`android:viewportWidth="24"`,
+				`android:pathData="M0,0 L24,0 L24,24 L0,24 Z"`,
+			},
+		},
+		{
+			name: "宽高比不一致时换算到显示尺寸",
+			svg: `<svg xmlns="http://www.w3.org/2000/svg" width="48" height="24" viewBox="0 0 24 24">
+				<rect width="24" height="24" fill="#000"/>
+			</svg>`,
+			contains: []string{
+				`android:viewportWidth="48"`,
+				`android:viewportHeight="24"`,
+				`android:pathData="M12,0 L36,0 L36,24 L12,24 Z"`,
+			},
+		},
+		{
+			name: "描边",
+			svg: `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24">
+				<path d="M2 2 L22 22" fill="none" stroke="#00ff00" stroke-width="1.5" stroke-opacity="0.25"
+					stroke-linecap="round" stroke-linejoin="bevel" transform="scale(2)"/>
+			</svg>`,
+			contains: []string{
+				`android:pathData="M4,4 L44,44"`,
+				`android:strokeColor="#00FF00"`,
+				`android:strokeWidth="3"`,
+				`android:strokeAlpha="0.25"`,
+				`android:strokeLineCap="round"`,
+				`android:strokeLineJoin="bevel"`,
+			},
+			absent: []string{"fillColor"},
+		},
+		{
+			name: "非等比缩放的描边",
+			svg: `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24">
+				<path id="line" d="M0 0 L10 0" fill="none" stroke="#000" transform="scale(2 1)"/>
+			</svg>`,
+			contains: []string{`android:strokeColor="#000000"`},
+			warnings: []string{"line 的描边经过非等比缩放或斜切"},
+		},
+		{
+			name: "线性渐变",
+			svg: `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24">
+				<defs><linearGradient id="g" x1="0" y1="0" x2="24" y2="0" gradientUnits="userSpaceOnUse" spreadMethod="reflect">
+					<stop offset="0" stop-color="#ff0000"/><stop offset="1" stop-color="#0000ff" stop-opacity="0.5"/>
+				</linearGradient></defs>
+				<rect width="24" height="24" fill="url(#g)"/>
+			</svg>`,
+			contains: []string{
+				`xmlns:aapt="http://schemas.android.com/aapt"`,
+				`<aapt:attr name="android:fillColor">`,
+				`android:type="linear"`,
+				`android:startX="0"`,
+				`android:endX="24"`,
+				`android:tileMode="mirror"`,
+				`<item android:offset="0" android:color="#FF0000" />`,
+				`<item android:offset="1" android:color="#800000FF" />`,
+			},
+		},
+		{
+			name: "带焦点的径向渐变",
+			svg: `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24">
+				<defs><radialGradient id="g" cx="12" cy="12" r="10" fx="8" fy="8" gradientUnits="userSpaceOnUse">
+					<stop offset="0" stop-color="#fff"/><stop offset="1" stop-color="#000"/>
+				</radialGradient></defs>
+				<circle cx="12" cy="12" r="10" fill="url(#g)"/>
+			</svg>`,
+			contains: []string{`android:type="radial"`, `android:centerX="12"`, `android:gradientRadius="10"`},
+			absent:   []string{"tileMode"},
+			warnings: []string{"不支持径向渐变的焦点"},
+		},
+		{
+			name: "裁剪路径",
+			svg: `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24">
+				<defs><clipPath id="c"><rect width="12" height="24" clip-rule="evenodd"/></clipPath></defs>
+				<g clip-path="url(#c)">
+					<rect width="24" height="24" fill="#000"/>
+					<rect width="24" height="12" fill="#fff"/>
+				</g>
+				<rect y="20" width="24" height="4" fill="#f00"/>
+			</svg>`,
+			contains: []string{
+				"    <group>\n        <clip-path android:pathData=\"M0,0 L12,0 L12,24 L0,24 Z\" />\n",
+				"    </group>\n    <path",
+			},
+			warnings: []string{`clip-rule="evenodd"`},
+		},
+		{
+			name:     "没有图形",
+			svg:      `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24"></svg>`,
+			contains: []string{`android:viewportWidth="24"`},
+			absent:   []string{"<path"},
+			warnings: []string{"没有可绘制的图形"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, warnings := vectorDrawable(t, test.svg)
+			for _, want := range test.contains {
+				if !strings.Contains(data, want) {
+					t.Errorf("输出中没有 %s\n%s", want, data)
+				}
+			}
+			for _, unwanted := range test.absent {
+				if strings.Contains(data, unwanted) {
+					t.Errorf("输出中不应有 %s\n%s", unwanted, data)
+				}
+			}
+			if len(warnings) != len(test.warnings) {
+				t.Fatalf("警告为 %q，应有 %d 条", warnings, len(test.warnings))
+			}
+			for i, want := range test.warnings {
+				if !strings.Contains(warnings[i], want) {
+					t.Errorf("警告 %d 为 %q，应包含 %q", i, warnings[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestVectorDrawableClipGroups(t *testing.T) {
+	// 相邻图形共用同一个裁剪分组，只打开一次
+	data, _ := vectorDrawable(t, `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24">
+		<defs><clipPath id="c"><circle cx="12" cy="12" r="12"/></clipPath></defs>
+		<g clip-path="url(#c)">
+			<rect width="24" height="24" fill="#000"/>
+			<rect width="24" height="12" fill="#fff"/>
+		</g>
+	</svg>`)
+	if groups := strings.Count(data, "<group>"); groups != 1 {
+		t.Errorf("有 %d 个裁剪分组，应为 1 个\n%s", groups, data)
+	}
+	if paths := strings.Count(data, "<path"); paths != 2 {
+		t.Errorf("有 %d 个路径，应为 2 个\n%s", paths, data)
+	}
+}
@@ -0,0 +1,110 @@
+package typography
+
+import (
+	"app-assets-generator/internal/naming"
+	"app-assets-generator/pkg/font"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultAndroidStylePrefix 默认的TextAppearance样式名称前缀
+const DefaultAndroidStylePrefix = "App"
+
+// AndroidGenerator Android文字样式生成器
+type AndroidGenerator struct {
+	outputPath string // res目录路径
+	prefix     string // 样式名称前缀
+}
+
+// NewAndroidGenerator 创建Android生成器，prefix为空时使用DefaultAndroidStylePrefix
+func NewAndroidGenerator(outputPath, prefix string) *AndroidGenerator {
+	if prefix == "" {
+		prefix = DefaultAndroidStylePrefix
+	}
+	return &AndroidGenerator{
+		outputPath: outputPath,
+		prefix:     prefix,
+	}
+}
+
+// Generate 生成values/styles.xml，每个文字样式对应一个 TextAppearance.<prefix>.<样式> 样式
+// 字号和行高使用sp，字间距使用em；textFontWeight需要API 28，低版本用textStyle近似粗体
+// TextAppearance中的android:lineHeight从API 33才生效，更低的版本需要在TextView上直接设置行高
+func (g *AndroidGenerator) Generate(styles []*TextStyle) error {
+	valuesPath := filepath.Join(g.outputPath, "values")
+	if err := os.MkdirAll(valuesPath, 0755); err != nil {
+		return fmt.Errorf("创建values目录失败: %w", err)
+	}
+
+	var builder strings.Builder
+	builder.WriteString("<?xml version=\"1.0\" encoding=\"utf-8\"?>\n")
+	builder.WriteString("<resources>\n")
+
+	section := ""
+	for i, style := range styles {
+		if style.Section != section {
+			section = style.Section
+			if i > 0 {
+				builder.WriteString("\n")
+			}
+			fmt.Fprintf(&builder, "    <!-- ===== %s ===== -->\n", naming.XMLComment(section))
+		}
+		if doc := style.Doc(); doc != "" {
+			fmt.Fprintf(&builder, "    <!-- %s -->\n", naming.XMLComment(doc))
+		}
+
+		fmt.Fprintf(&builder, "    <style name=\"%s\" parent=\"\">\n", g.StyleName(style.Name))
+		writeItem(&builder, "android:fontFamily", androidFontFamily(style))
+		writeItem(&builder, "android:textFontWeight", fmt.Sprint(style.Weight))
+		if style.Weight >= 600 {
+			writeItem(&builder, "android:textStyle", "bold")
+		} else {
+			writeItem(&builder, "android:textStyle", "normal")
+		}
+		writeItem(&builder, "android:textSize", formatNumber(style.Size, 2)+"sp")
+		if style.LineHeight > 0 {
+			// API 33以下TextView会忽略TextAppearance中的行高
+			writeItem(&builder, "android:lineHeight", formatNumber(style.LineHeight, 2)+"sp")
+		}
+		if style.LetterSpacing != 0 {
+			writeItem(&builder, "android:letterSpacing", formatNumber(style.LetterSpacing, 4))
+		}
+		if style.TextCase == TextCaseUpper {
+			writeItem(&builder, "android:textAllCaps", "true")
+		}
+		builder.WriteString("    </style>\n")
+	}
+
+	builder.WriteString("</resources>\n")
+
+	filePath := filepath.Join(valuesPath, "styles.xml")
+	if err := os.WriteFile(filePath, []byte(builder.String()), 0644); err != nil {
+		return fmt.Errorf("写入 %s 失败: %w", filePath, err)
+	}
+	return nil
+}
+
+// StyleName 获取TextAppearance样式名称，如 (App, title_large) -> TextAppearance.App.TitleLarge
+func (g *AndroidGenerator) StyleName(name string) string {
+	return fmt.Sprintf("TextAppearance.%s.%s", g.prefix, naming.PascalCase(name))
+}
+
+// androidFontFamily 获取android:fontFamily的值，自定义字体引用res/font中同名的font-family资源
+func androidFontFamily(style *TextStyle) string {
+	if style.IsSystemFont() {
+		return "sans-serif"
+	}
+	return "@font/" + FontResourceName(style.FontFamily)
+}
+
+// FontResourceName 获取字体家族对应的Android资源名称，与font命令生成的font-family XML同名
+func FontResourceName(family string) string {
+	return font.FamilyResourceName(family)
+}
+
+// writeItem 写入样式属性
+func writeItem(builder *strings.Builder, name, value string) {
+	fmt.Fprintf(builder, "        <item name=\"%s\">%s</item>\n", name, value)
+}
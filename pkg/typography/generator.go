@@ -0,0 +1,113 @@
+package typography
+
+import (
+	"app-assets-generator/internal/diag"
+	"fmt"
+	"slices"
+)
+
+// Generator 文字样式生成器
+type Generator struct {
+	inputPath  string           // 输入文件路径
+	outputPath string           // Android res目录路径
+	options    Options          // 生成选项
+	styles     []*TextStyle     // 解析后的文字样式，按文件顺序
+	warnings   diag.Diagnostics // 解析时发现的警告
+}
+
+// Options 生成选项
+type Options struct {
+	AndroidStylePrefix string // TextAppearance样式名称前缀，如 App -> TextAppearance.App.Body
+
+	SwiftOutput string // Swift文字样式输出文件，为空时不生成
+	SwiftType   string // Swift文字样式类型名称，为空时使用 DefaultSwiftType
+
+	KotlinOutput     string // Kotlin(Compose)文字样式输出文件，为空时不生成
+	KotlinPackage    string // Kotlin代码的包名
+	AndroidNamespace string // R类所在的包名，为空时与KotlinPackage相同
+}
+
+// NewGenerator 创建新的生成器
+func NewGenerator(inputPath, outputPath string, options Options) *Generator {
+	return &Generator{
+		inputPath:  inputPath,
+		outputPath: outputPath,
+		options:    options,
+	}
+}
+
+// GenerateIOS 生成Swift文字样式
+func (g *Generator) GenerateIOS() error {
+	if err := g.parseStyles(); err != nil {
+		return err
+	}
+	if g.options.SwiftOutput == "" {
+		return fmt.Errorf("生成iOS文字样式需要指定Swift输出文件")
+	}
+
+	swiftGen := NewSwiftGenerator(g.options.SwiftOutput, g.options.SwiftType)
+	if err := swiftGen.Generate(g.styles); err != nil {
+		return fmt.Errorf("生成Swift文字样式失败: %w", err)
+	}
+	return nil
+}
+
+// GenerateAndroid 生成Android TextAppearance样式和Compose TextStyle
+func (g *Generator) GenerateAndroid() error {
+	if err := g.parseStyles(); err != nil {
+		return err
+	}
+
+	androidGen := NewAndroidGenerator(g.outputPath, g.options.AndroidStylePrefix)
+	if err := androidGen.Generate(g.styles); err != nil {
+		return err
+	}
+
+	if g.options.KotlinOutput != "" {
+		kotlinGen := NewKotlinGenerator(g.options.KotlinOutput, g.options.KotlinPackage, g.options.AndroidNamespace)
+		if err := kotlinGen.Generate(g.styles); err != nil {
+			return fmt.Errorf("生成Kotlin文字样式失败: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// FontFamilies 获取文字样式用到的自定义字体家族，按首次出现的顺序
+func (g *Generator) FontFamilies() ([]string, error) {
+	if err := g.parseStyles(); err != nil {
+		return nil, err
+	}
+
+	var families []string
+	for _, style := range g.styles {
+		if !style.IsSystemFont() && !slices.Contains(families, style.FontFamily) {
+			families = append(families, style.FontFamily)
+		}
+	}
+	return families, nil
+}
+
+// Warnings 获取解析文字样式配置时发现的警告
+func (g *Generator) Warnings() diag.Diagnostics {
+	return g.warnings
+}
+
+// parseStyles 解析文字样式配置
+func (g *Generator) parseStyles() error {
+	if g.styles != nil {
+		return nil // 已经解析过了
+	}
+
+	styles, diagnostics, err := ParseYAML(g.inputPath)
+	if err != nil {
+		return fmt.Errorf("解析文字样式配置失败: %w", err)
+	}
+	if diagnostics.HasErrors() {
+		return fmt.Errorf("解析文字样式配置失败: %w", &diag.ValidationError{Diagnostics: diagnostics})
+	}
+
+	g.styles = styles
+	g.warnings = diagnostics.Warnings()
+	return nil
+}
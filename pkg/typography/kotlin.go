@@ -0,0 +1,152 @@
+package typography
+
+import (
+	"app-assets-generator/internal/naming"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// KotlinGenerator Kotlin(Jetpack Compose)文字样式生成器
+type KotlinGenerator struct {
+	outputPath  string // 输出的.kt文件路径
+	packageName string // 生成代码的包名
+	namespace   string // R类所在的包名（Android namespace）
+}
+
+// NewKotlinGenerator 创建Kotlin生成器，namespace为空时使用packageName
+func NewKotlinGenerator(outputPath, packageName, namespace string) *KotlinGenerator {
+	if namespace == "" {
+		namespace = packageName
+	}
+	return &KotlinGenerator{
+		outputPath:  outputPath,
+		packageName: packageName,
+		namespace:   namespace,
+	}
+}
+
+// Generate 生成AppTextStyles对象，每个文字样式对应一个TextStyle
+// 字号和行高使用sp，字间距使用em；自定义字体通过res/font中的font-family资源加载
+func (g *KotlinGenerator) Generate(styles []*TextStyle) error {
+	if g.packageName == "" {
+		return fmt.Errorf("生成Kotlin代码需要指定包名")
+	}
+	if err := os.MkdirAll(filepath.Dir(g.outputPath), 0755); err != nil {
+		return fmt.Errorf("创建输出目录失败: %w", err)
+	}
+
+	// 用到的自定义字体家族，按首次出现的顺序
+	var families []string
+	hasLetterSpacing := false
+	for _, style := range styles {
+		if !style.IsSystemFont() && !slices.Contains(families, style.FontFamily) {
+			families = append(families, style.FontFamily)
+		}
+		if style.LetterSpacing != 0 {
+			hasLetterSpacing = true
+		}
+	}
+
+	var builder strings.Builder
+	builder.WriteString("// 由 app-assets-generator 自动生成，请勿手动修改\n\n")
+	fmt.Fprintf(&builder, "package %s\n\n", g.packageName)
+	builder.WriteString("import androidx.compose.ui.text.TextStyle\n")
+	if len(families) > 0 {
+		builder.WriteString("import androidx.compose.ui.text.font.Font\n")
+	}
+	builder.WriteString("import androidx.compose.ui.text.font.FontFamily\n")
+	builder.WriteString("import androidx.compose.ui.text.font.FontWeight\n")
+	if hasLetterSpacing {
+		builder.WriteString("import androidx.compose.ui.unit.em\n")
+	}
+	builder.WriteString("import androidx.compose.ui.unit.sp\n")
+	if len(families) > 0 && g.namespace != g.packageName {
+		fmt.Fprintf(&builder, "import %s.R\n", g.namespace)
+	}
+	builder.WriteString("\nobject AppTextStyles {\n")
+
+	for _, family := range families {
+		fmt.Fprintf(&builder, "    private val %s = FontFamily(Font(R.font.%s))\n", familyIdentifier(family), FontResourceName(family))
+	}
+	if len(families) > 0 {
+		builder.WriteString("\n")
+	}
+
+	section := ""
+	written := 0
+	for _, style := range styles {
+		if style.Section != section {
+			if section != "" {
+				builder.WriteString("    // endregion\n")
+			}
+			section = style.Section
+			if written > 0 {
+				builder.WriteString("\n")
+			}
+			fmt.Fprintf(&builder, "    // region %s\n\n", naming.SingleLine(section))
+		}
+
+		if lines := naming.DocLines(style.Doc()); len(lines) > 0 {
+			builder.WriteString("    /**\n")
+			for _, line := range lines {
+				fmt.Fprintf(&builder, "     * %s\n", strings.ReplaceAll(line, "*/", "* /"))
+			}
+			builder.WriteString("     */\n")
+		}
+
+		fontFamily := "FontFamily.Default"
+		if !style.IsSystemFont() {
+			fontFamily = familyIdentifier(style.FontFamily)
+		}
+		fmt.Fprintf(&builder, "    val %s = TextStyle(\n", naming.Identifier(style.Name))
+		fmt.Fprintf(&builder, "        fontFamily = %s,\n", fontFamily)
+		fmt.Fprintf(&builder, "        fontWeight = %s,\n", composeFontWeight(style.Weight))
+		fmt.Fprintf(&builder, "        fontSize = %s.sp,\n", formatNumber(style.Size, 2))
+		if style.LineHeight > 0 {
+			fmt.Fprintf(&builder, "        lineHeight = %s.sp,\n", formatNumber(style.LineHeight, 2))
+		}
+		if style.LetterSpacing != 0 {
+			fmt.Fprintf(&builder, "        letterSpacing = %s.em,\n", kotlinDouble(style.LetterSpacing))
+		}
+		builder.WriteString("    )\n")
+		written++
+	}
+	if section != "" {
+		builder.WriteString("    // endregion\n")
+	}
+	builder.WriteString("}\n")
+
+	if err := os.WriteFile(g.outputPath, []byte(builder.String()), 0644); err != nil {
+		return fmt.Errorf("写入 %s 失败: %w", g.outputPath, err)
+	}
+
+	return nil
+}
+
+// composeFontWeight 获取Compose的FontWeight表达式，整百的字重使用W100-W900常量
+func composeFontWeight(weight int) string {
+	if weight%100 == 0 && weight >= 100 && weight <= 900 {
+		return fmt.Sprintf("FontWeight.W%d", weight)
+	}
+	return fmt.Sprintf("FontWeight(%d)", weight)
+}
+
+// kotlinDouble 格式化为Kotlin的Double字面量，负数加括号以便调用扩展属性，如 (-0.02).em
+func kotlinDouble(value float64) string {
+	text := formatNumber(value, 4)
+	if !strings.Contains(text, ".") {
+		text += ".0"
+	}
+	if value < 0 {
+		return "(" + text + ")"
+	}
+	return text
+}
+
+// familyIdentifier 获取字体家族在Kotlin中的变量名，如 Source Sans Pro -> sourceSansProFontFamily
+func familyIdentifier(family string) string {
+	return naming.Identifier(FontResourceName(family)) + "FontFamily"
+}
@@ -0,0 +1,292 @@
+package typography
+
+import (
+	"app-assets-generator/internal/diag"
+	"app-assets-generator/internal/naming"
+	"app-assets-generator/internal/yamlutil"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// styleKeys 文字样式中允许的字段
+var styleKeys = []string{"font_family", "weight", "size", "line_height", "letter_spacing", "text_case", "ios_text_style", "description"}
+
+// namePattern 在Android样式名称和Swift/Kotlin标识符中都可用的名称
+var namePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// lengthPattern 带可选单位的长度，如 16、16sp、1.5em、150%
+var lengthPattern = regexp.MustCompile(`^(-?[0-9]*\.?[0-9]+)\s*(pt|sp|px|em|%)?$`)
+
+// ParseYAML 解析文字样式配置文件，按文件中的顺序返回样式
+// 一次性收集所有带位置信息的错误和警告，只有读取文件失败时才返回error
+func ParseYAML(filePath string) ([]*TextStyle, diag.Diagnostics, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("读取文件失败: %w", err)
+	}
+
+	p := &parser{file: filePath}
+	styles := p.parse(data)
+	return styles, p.diagnostics, nil
+}
+
+// parser 文字样式配置解析器
+type parser struct {
+	file        string
+	diagnostics diag.Diagnostics
+}
+
+// errorf 记录节点位置的错误
+func (p *parser) errorf(node *yaml.Node, format string, args ...interface{}) {
+	p.add(diag.SeverityError, node, format, args...)
+}
+
+// warnf 记录节点位置的警告
+func (p *parser) warnf(node *yaml.Node, format string, args ...interface{}) {
+	p.add(diag.SeverityWarning, node, format, args...)
+}
+
+// add 记录问题
+func (p *parser) add(severity diag.Severity, node *yaml.Node, format string, args ...interface{}) {
+	diagnostic := diag.Diagnostic{File: p.file, Severity: severity, Message: fmt.Sprintf(format, args...)}
+	if node != nil {
+		diagnostic.Line, diagnostic.Column = node.Line, node.Column
+	}
+	p.diagnostics = append(p.diagnostics, diagnostic)
+}
+
+// parse 解析所有文字样式
+func (p *parser) parse(data []byte) []*TextStyle {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		p.diagnostics = append(p.diagnostics, diag.Diagnostic{
+			File:     p.file,
+			Line:     yamlutil.ErrorLine(err),
+			Column:   1,
+			Severity: diag.SeverityError,
+			Message:  "解析YAML失败: " + strings.TrimPrefix(err.Error(), "yaml: "),
+		})
+		return nil
+	}
+	if len(document.Content) == 0 {
+		return nil // 空文件
+	}
+
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		p.errorf(root, "顶层必须是样式名称到文字样式的映射")
+		return nil
+	}
+
+	// 文档开头的注释属于第一个样式，分组注释块对后续所有样式生效
+	var styles []*TextStyle
+	defined := make(map[string]bool)
+	section := ""
+	headComment := document.HeadComment
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		keyNode, valueNode := root.Content[i], root.Content[i+1]
+		comments := []string{headComment, keyNode.HeadComment}
+		headComment = ""
+		comment := ""
+		for _, block := range comments {
+			blockSection, blockComment := yamlutil.ParseHeadComment(block)
+			if blockSection != "" {
+				section = blockSection
+			}
+			if blockComment != "" {
+				comment = blockComment
+			}
+		}
+
+		name := keyNode.Value
+		if !namePattern.MatchString(name) {
+			p.errorf(keyNode, "样式名称 %s 无效，只能包含字母、数字和下划线，且不能以数字开头", name)
+			continue
+		}
+		if defined[name] {
+			p.errorf(keyNode, "样式 %s 重复定义", name)
+			continue
+		}
+		defined[name] = true
+
+		style := &TextStyle{Name: name, Comment: comment, Section: section}
+		if p.decode(style, keyNode, valueNode) {
+			styles = append(styles, style)
+		}
+	}
+
+	return styles
+}
+
+// decode 解析一个文字样式，存在错误时返回false
+// 行高和字间距先按原始写法解析，字号确定后再统一换算
+func (p *parser) decode(style *TextStyle, keyNode, node *yaml.Node) bool {
+	name := style.Name
+	if node.Kind != yaml.MappingNode {
+		p.errorf(node, "样式 %s 必须是包含size等字段的映射", name)
+		return false
+	}
+
+	valid := true
+	fields := make(map[string]*yaml.Node)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if !slices.Contains(styleKeys, key.Value) {
+			p.errorf(key, "样式 %s 包含未知字段 %s，可用字段: %s", name, key.Value, strings.Join(styleKeys, ", "))
+			valid = false
+			continue
+		}
+		if value.Kind != yaml.ScalarNode {
+			p.errorf(value, "样式 %s 的%s必须是标量值", name, key.Value)
+			valid = false
+			continue
+		}
+		fields[key.Value] = value
+	}
+
+	// 字号
+	sizeNode := fields["size"]
+	if sizeNode == nil {
+		p.errorf(keyNode, "样式 %s 缺少size字段", name)
+		return false
+	}
+	size, unit, ok := parseLength(sizeNode.Value)
+	if !ok || unit == "em" || unit == "%" || size <= 0 {
+		p.errorf(sizeNode, "样式 %s 的字号无效: %s，必须是大于0的pt/sp值", name, sizeNode.Value)
+		return false
+	}
+	style.Size = size
+
+	// 字体家族
+	if familyNode := fields["font_family"]; familyNode != nil && !strings.EqualFold(familyNode.Value, SystemFontFamily) {
+		style.FontFamily = strings.TrimSpace(familyNode.Value)
+		if naming.NormalizeName(style.FontFamily) == "" {
+			p.errorf(familyNode, "样式 %s 的字体家族名称无效: %s", name, familyNode.Value)
+			valid = false
+		}
+	}
+
+	// 字重
+	style.Weight = 400
+	if weightNode := fields["weight"]; weightNode != nil {
+		weight, ok := parseWeight(weightNode.Value)
+		if !ok {
+			p.errorf(weightNode, "样式 %s 的字重无效: %s，必须是100-900的数值或 thin/light/regular/medium/semibold/bold/black 等名称", name, weightNode.Value)
+			valid = false
+		}
+		style.Weight = weight
+	}
+
+	// 行高：绝对值（pt/sp/px）或相对字号的倍数（em/%）
+	if lineNode := fields["line_height"]; lineNode != nil {
+		value, unit, ok := parseLength(lineNode.Value)
+		switch {
+		case !ok || value <= 0:
+			p.errorf(lineNode, "样式 %s 的行高无效: %s，可以是 24、24sp、1.5em 或 150%%", name, lineNode.Value)
+			valid = false
+		case unit == "em":
+			style.LineHeight = value * size
+		case unit == "%":
+			style.LineHeight = value / 100 * size
+		default:
+			style.LineHeight = value
+		}
+		if style.LineHeight > 0 && style.LineHeight < size {
+			p.warnf(lineNode, "样式 %s 的行高 %s 小于字号 %s，文字可能被裁剪", name, formatNumber(style.LineHeight, 2), formatNumber(size, 2))
+		}
+	}
+
+	// 字间距：绝对值（pt/sp/px）按字号换算为em，%为字号的百分比
+	if letterNode := fields["letter_spacing"]; letterNode != nil {
+		value, unit, ok := parseLength(letterNode.Value)
+		switch {
+		case !ok:
+			p.errorf(letterNode, "样式 %s 的字间距无效: %s，可以是 0.5、0.5pt、0.02em 或 2%%", name, letterNode.Value)
+			valid = false
+		case unit == "em":
+			style.LetterSpacing = value
+		case unit == "%":
+			style.LetterSpacing = value / 100
+		default:
+			style.LetterSpacing = value / size
+		}
+	}
+
+	// 大小写
+	style.TextCase = TextCaseNone
+	if caseNode := fields["text_case"]; caseNode != nil {
+		switch TextCase(caseNode.Value) {
+		case TextCaseNone, TextCaseUpper:
+			style.TextCase = TextCase(caseNode.Value)
+		case TextCaseLower:
+			style.TextCase = TextCaseLower
+			p.warnf(caseNode, "样式 %s: Android的TextAppearance和Compose TextStyle不支持小写转换，只在iOS中生效", name)
+		default:
+			p.errorf(caseNode, "样式 %s 的text_case无效: %s (必须是 none/upper/lower)", name, caseNode.Value)
+			valid = false
+		}
+	}
+
+	// Dynamic Type文字样式
+	if textStyleNode := fields["ios_text_style"]; textStyleNode != nil {
+		if !isIOSTextStyle(textStyleNode.Value) {
+			names := make([]string, len(iosTextStyles))
+			for i, candidate := range iosTextStyles {
+				names[i] = candidate.Name
+			}
+			p.errorf(textStyleNode, "样式 %s 的ios_text_style无效: %s，可用值: %s", name, textStyleNode.Value, strings.Join(names, ", "))
+			valid = false
+		}
+		style.IOSTextStyle = textStyleNode.Value
+	} else {
+		style.IOSTextStyle = inferIOSTextStyle(style.Size, style.Weight)
+	}
+
+	if descriptionNode := fields["description"]; descriptionNode != nil {
+		style.Description = descriptionNode.Value
+	}
+
+	return valid
+}
+
+// parseLength 解析带可选单位的数值，返回数值和单位（pt/sp/px/em/%，无单位时为空）
+func parseLength(text string) (float64, string, bool) {
+	match := lengthPattern.FindStringSubmatch(strings.TrimSpace(text))
+	if match == nil {
+		return 0, "", false
+	}
+	value, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, "", false
+	}
+	return value, match[2], true
+}
+
+// parseWeight 解析字重，支持100-900的数值和常用名称
+func parseWeight(text string) (int, bool) {
+	text = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(text), "-", ""))
+	if weight, ok := weightNames[strings.ReplaceAll(text, " ", "")]; ok {
+		return weight, true
+	}
+	weight, err := strconv.Atoi(text)
+	if err != nil || weight < 1 || weight > 1000 {
+		return 0, false
+	}
+	return weight, true
+}
+
+// isIOSTextStyle 判断是否为有效的Dynamic Type文字样式
+func isIOSTextStyle(name string) bool {
+	for _, style := range iosTextStyles {
+		if style.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,157 @@
+package typography
+
+import (
+	"app-assets-generator/internal/diag"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestParseStyles(t *testing.T) {
+	p := &parser{file: "typography.yaml"}
+	styles := p.parse([]byte(`# ==========
+# 标题
+# ==========
+
+# 页面大标题
+title_large:
+  font_family: Inter
+  weight: semi-bold
+  size: 28
+  line_height: 1.25em
+  letter_spacing: -0.5pt
+  ios_text_style: largeTitle
+
+label:
+  font_family: System
+  weight: 500
+  size: 12sp
+  line_height: 150%
+  letter_spacing: 8%
+  text_case: upper
+  description: 按钮文字
+
+body:
+  size: 17
+  line_height: 22px
+`))
+	if len(p.diagnostics) != 0 {
+		t.Fatalf("诊断为 %v，应没有问题", p.diagnostics)
+	}
+
+	want := []TextStyle{
+		{
+			Name: "title_large", FontFamily: "Inter", Weight: 600, Size: 28, LineHeight: 35,
+			LetterSpacing: -0.5 / 28, TextCase: TextCaseNone, IOSTextStyle: "largeTitle",
+			Comment: "页面大标题", Section: "标题",
+		},
+		{
+			Name: "label", Weight: 500, Size: 12, LineHeight: 18, LetterSpacing: 0.08,
+			TextCase: TextCaseUpper, IOSTextStyle: "caption1", Description: "按钮文字", Section: "标题",
+		},
+		{
+			Name: "body", Weight: 400, Size: 17, LineHeight: 22, TextCase: TextCaseNone,
+			IOSTextStyle: "body", Section: "标题",
+		},
+	}
+	if len(styles) != len(want) {
+		t.Fatalf("解析出 %d 个样式，应为 %d 个", len(styles), len(want))
+	}
+	for i, style := range styles {
+		got := *style
+		// 换算出的浮点数单独比较
+		if math.Abs(got.LetterSpacing-want[i].LetterSpacing) > 1e-12 {
+			t.Errorf("%s 的字间距为 %g，应为 %g", got.Name, got.LetterSpacing, want[i].LetterSpacing)
+		}
+		got.LetterSpacing = want[i].LetterSpacing
+		if got != want[i] {
+			t.Errorf("样式 %d 为 %+v，应为 %+v", i, got, want[i])
+		}
+	}
+}
+
+func TestParseDiagnostics(t *testing.T) {
+	tests := []struct {
+		source   string
+		styles   int
+		severity diag.Severity
+		message  string
+		line     int
+	}{
+		{source: "h1:\n  size: 0\n", severity: diag.SeverityError, message: "样式 h1 的字号无效: 0", line: 2},
+		{source: "h1:\n  size: 2em\n", severity: diag.SeverityError, message: "样式 h1 的字号无效: 2em", line: 2},
+		{source: "h1:\n  weight: bold\n", severity: diag.SeverityError, message: "样式 h1 缺少size字段", line: 1},
+		{source: "h1:\n  size: 20\n  weight: fat\n", severity: diag.SeverityError, message: "样式 h1 的字重无效: fat", line: 3},
+		{source: "h1:\n  size: 20\n  colour: red\n", severity: diag.SeverityError, message: "包含未知字段 colour", line: 3},
+		{source: "h1:\n  size: 20\n  line_height: 16\n", styles: 1, severity: diag.SeverityWarning, message: "行高 16 小于字号 20", line: 3},
+		{source: "h1:\n  size: 20\n  text_case: lower\n", styles: 1, severity: diag.SeverityWarning, message: "不支持小写转换", line: 3},
+		{source: "h1:\n  size: 20\n  text_case: title\n", severity: diag.SeverityError, message: "text_case无效: title", line: 3},
+		{source: "h1:\n  size: 20\n  ios_text_style: huge\n", severity: diag.SeverityError, message: "ios_text_style无效: huge", line: 3},
+		{source: "h1:\n  size: 20\n  font_family: \"中文\"\n", severity: diag.SeverityError, message: "字体家族名称无效", line: 3},
+		{source: "h1: 20\n", severity: diag.SeverityError, message: "必须是包含size等字段的映射", line: 1},
+		{source: "1st:\n  size: 20\n", severity: diag.SeverityError, message: "样式名称 1st 无效", line: 1},
+		{source: "h1:\n  size: 20\nh1:\n  size: 24\n", styles: 1, severity: diag.SeverityError, message: "样式 h1 重复定义", line: 3},
+		{source: "h1:\n  size: [20]\n", severity: diag.SeverityError, message: "size必须是标量值", line: 2},
+		{source: "h1:\n  size: 20\n size: 3\n", severity: diag.SeverityError, message: "解析YAML失败", line: 2},
+	}
+
+	for _, test := range tests {
+		p := &parser{file: "typography.yaml"}
+		styles := p.parse([]byte(test.source))
+		if len(styles) != test.styles {
+			t.Errorf("%q 解析出 %d 个样式，应为 %d 个", test.source, len(styles), test.styles)
+		}
+		if len(p.diagnostics) == 0 {
+			t.Errorf("%q 没有诊断，应包含 %q", test.source, test.message)
+			continue
+		}
+		got := p.diagnostics[0]
+		if got.Severity != test.severity || !strings.Contains(got.Message, test.message) || got.Line != test.line {
+			t.Errorf("%q 的诊断为 %s，应为第 %d 行包含 %q 的%s", test.source, got, test.line, test.message, test.severity)
+		}
+	}
+}
+
+func TestParseWeight(t *testing.T) {
+	tests := []struct {
+		text   string
+		weight int
+		ok     bool
+	}{
+		{text: "700", weight: 700, ok: true},
+		{text: " Bold ", weight: 700, ok: true},
+		{text: "Semi Bold", weight: 600, ok: true},
+		{text: "extra-light", weight: 200, ok: true},
+		{text: "Normal", weight: 400, ok: true},
+		{text: "0", ok: false},
+		{text: "1001", ok: false},
+		{text: "bolder", ok: false},
+	}
+	for _, test := range tests {
+		weight, ok := parseWeight(test.text)
+		if ok != test.ok || weight != test.weight {
+			t.Errorf("parseWeight(%q) = %d, %v，应为 %d, %v", test.text, weight, ok, test.weight, test.ok)
+		}
+	}
+}
+
+func TestInferIOSTextStyle(t *testing.T) {
+	tests := []struct {
+		size   float64
+		weight int
+		want   string
+	}{
+		{size: 40, weight: 400, want: "largeTitle"},
+		{size: 28, weight: 400, want: "title1"},
+		{size: 21, weight: 400, want: "title2"}, // 与title2和title3距离相同时取较大的样式
+		{size: 17, weight: 400, want: "body"},
+		{size: 17, weight: 600, want: "headline"},
+		{size: 14, weight: 400, want: "subheadline"},
+		{size: 8, weight: 400, want: "caption2"},
+	}
+	for _, test := range tests {
+		if got := inferIOSTextStyle(test.size, test.weight); got != test.want {
+			t.Errorf("inferIOSTextStyle(%g, %d) = %s，应为 %s", test.size, test.weight, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package typography
+
+import (
+	"app-assets-generator/internal/naming"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DefaultSwiftType 默认的Swift文字样式类型名称
+const DefaultSwiftType = "AppTextStyle"
+
+// SwiftGenerator Swift文字样式生成器
+type SwiftGenerator struct {
+	outputPath string // 输出的.swift文件路径
+	typeName   string // 文字样式类型名称
+}
+
+// NewSwiftGenerator 创建Swift生成器，typeName为空时使用DefaultSwiftType
+func NewSwiftGenerator(outputPath, typeName string) *SwiftGenerator {
+	if typeName == "" {
+		typeName = DefaultSwiftType
+	}
+	return &SwiftGenerator{
+		outputPath: outputPath,
+		typeName:   typeName,
+	}
+}
+
+// swiftRuntime 文字样式类型的实现，%[1]s 为类型名称
+// 字号和行高通过UIFontMetrics按Dynamic Type缩放，字间距以em保存，按缩放后的字号换算为pt
+const swiftRuntime = `/// 文字样式，字号和行高随Dynamic Type缩放
+public struct %[1]s {
+    /// 字体家族名称，nil为系统字体
+    public let fontFamily: String?
+    /// 字重 100-900
+    public let weight: Int
+    /// 字号（pt）
+    public let size: CGFloat
+    /// 行高（pt），nil为字体默认行高
+    public let lineHeight: CGFloat?
+    /// 字间距（em，字号的倍数）
+    public let letterSpacing: CGFloat
+    /// Dynamic Type缩放参照的文字样式
+    public let textStyle: UIFont.TextStyle
+    /// 大小写转换
+    public let textCase: Text.Case?
+
+    /// 按当前Dynamic Type缩放后的UIFont
+    public var uiFont: UIFont {
+        let base: UIFont
+        if let fontFamily = fontFamily {
+            let descriptor = UIFontDescriptor(fontAttributes: [
+                .family: fontFamily,
+                .traits: [UIFontDescriptor.TraitKey.weight: uiFontWeight],
+            ])
+            base = UIFont(descriptor: descriptor, size: size)
+        } else {
+            base = UIFont.systemFont(ofSize: size, weight: uiFontWeight)
+        }
+        return UIFontMetrics(forTextStyle: textStyle).scaledFont(for: base)
+    }
+
+    /// SwiftUI字体，随Dynamic Type缩放
+    public var font: Font {
+        if let fontFamily = fontFamily {
+            return Font.custom(fontFamily, size: size, relativeTo: fontTextStyle).weight(fontWeight)
+        }
+        return Font.system(size: UIFontMetrics(forTextStyle: textStyle).scaledValue(for: size), weight: fontWeight)
+    }
+
+    /// 按当前Dynamic Type缩放后的行高
+    public var scaledLineHeight: CGFloat? {
+        lineHeight.map { UIFontMetrics(forTextStyle: textStyle).scaledValue(for: $0) }
+    }
+
+    /// 字间距（pt），用于NSAttributedString的kern和SwiftUI的tracking
+    public var kern: CGFloat {
+        letterSpacing * uiFont.pointSize
+    }
+
+    /// SwiftUI的行间距，即行高与字体默认行高的差值
+    public var lineSpacing: CGFloat {
+        guard let lineHeight = scaledLineHeight else { return 0 }
+        return max(0, lineHeight - uiFont.lineHeight)
+    }
+
+    /// NSAttributedString属性，包含字体、字间距和固定行高
+    public var attributes: [NSAttributedString.Key: Any] {
+        let font = uiFont
+        var attributes: [NSAttributedString.Key: Any] = [
+            .font: font,
+            .kern: letterSpacing * font.pointSize,
+        ]
+        if let lineHeight = scaledLineHeight {
+            let paragraphStyle = NSMutableParagraphStyle()
+            paragraphStyle.minimumLineHeight = lineHeight
+            paragraphStyle.maximumLineHeight = lineHeight
+            attributes[.paragraphStyle] = paragraphStyle
+            attributes[.baselineOffset] = (lineHeight - font.lineHeight) / 4
+        }
+        return attributes
+    }
+
+    private var uiFontWeight: UIFont.Weight {
+        switch weight {
+        case ..<150: return .ultraLight
+        case ..<250: return .thin
+        case ..<350: return .light
+        case ..<450: return .regular
+        case ..<550: return .medium
+        case ..<650: return .semibold
+        case ..<750: return .bold
+        case ..<850: return .heavy
+        default: return .black
+        }
+    }
+
+    private var fontWeight: Font.Weight {
+        switch weight {
+        case ..<150: return .ultraLight
+        case ..<250: return .thin
+        case ..<350: return .light
+        case ..<450: return .regular
+        case ..<550: return .medium
+        case ..<650: return .semibold
+        case ..<750: return .bold
+        case ..<850: return .heavy
+        default: return .black
+        }
+    }
+
+    private var fontTextStyle: Font.TextStyle {
+        switch textStyle {
+        case .largeTitle: return .largeTitle
+        case .title1: return .title
+        case .title2: return .title2
+        case .title3: return .title3
+        case .headline: return .headline
+        case .subheadline: return .subheadline
+        case .callout: return .callout
+        case .footnote: return .footnote
+        case .caption1: return .caption
+        case .caption2: return .caption2
+        default: return .body
+        }
+    }
+}
+
+public extension View {
+    /// 应用文字样式的字体、字间距、行距和大小写
+    @available(iOS 16.0, *)
+    func textStyle(_ style: %[1]s) -> some View {
+        font(style.font)
+            .tracking(style.kern)
+            .lineSpacing(style.lineSpacing)
+            .textCase(style.textCase)
+    }
+}
+`
+
+// Generate 生成文字样式类型和每个样式的静态常量
+func (g *SwiftGenerator) Generate(styles []*TextStyle) error {
+	if err := os.MkdirAll(filepath.Dir(g.outputPath), 0755); err != nil {
+		return fmt.Errorf("创建输出目录失败: %w", err)
+	}
+
+	var builder strings.Builder
+	builder.WriteString("// 由 app-assets-generator 自动生成，请勿手动修改\n\n")
+	builder.WriteString("#if canImport(UIKit)\n")
+	builder.WriteString("import SwiftUI\n")
+	builder.WriteString("import UIKit\n\n")
+	fmt.Fprintf(&builder, swiftRuntime, g.typeName)
+	fmt.Fprintf(&builder, "\npublic extension %s {\n", g.typeName)
+
+	section := ""
+	for i, style := range styles {
+		if style.Section != section {
+			section = style.Section
+			if i > 0 {
+				builder.WriteString("\n")
+			}
+			fmt.Fprintf(&builder, "    // MARK: - %s\n\n", naming.SingleLine(section))
+		}
+
+		for _, line := range naming.DocLines(style.Doc()) {
+			fmt.Fprintf(&builder, "    /// %s\n", line)
+		}
+		fmt.Fprintf(&builder, "    static let %s = %s(\n", naming.Identifier(style.Name), g.typeName)
+		if style.IsSystemFont() {
+			builder.WriteString("        fontFamily: nil,\n")
+		} else {
+			fmt.Fprintf(&builder, "        fontFamily: %s,\n", strconv.Quote(style.FontFamily))
+		}
+		fmt.Fprintf(&builder, "        weight: %d,\n", style.Weight)
+		fmt.Fprintf(&builder, "        size: %s,\n", formatNumber(style.Size, 2))
+		if style.LineHeight > 0 {
+			fmt.Fprintf(&builder, "        lineHeight: %s,\n", formatNumber(style.LineHeight, 2))
+		} else {
+			builder.WriteString("        lineHeight: nil,\n")
+		}
+		fmt.Fprintf(&builder, "        letterSpacing: %s,\n", formatNumber(style.LetterSpacing, 4))
+		fmt.Fprintf(&builder, "        textStyle: .%s,\n", style.IOSTextStyle)
+		switch style.TextCase {
+		case TextCaseUpper:
+			builder.WriteString("        textCase: .uppercase\n")
+		case TextCaseLower:
+			builder.WriteString("        textCase: .lowercase\n")
+		default:
+			builder.WriteString("        textCase: nil\n")
+		}
+		builder.WriteString("    )\n")
+	}
+	builder.WriteString("}\n")
+	builder.WriteString("#endif\n")
+
+	if err := os.WriteFile(g.outputPath, []byte(builder.String()), 0644); err != nil {
+		return fmt.Errorf("写入 %s 失败: %w", g.outputPath, err)
+	}
+
+	return nil
+}
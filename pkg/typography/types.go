@@ -0,0 +1,117 @@
+package typography
+
+import (
+	"math"
+	"strconv"
+)
+
+// TextCase 大小写转换
+type TextCase string
+
+const (
+	TextCaseNone  TextCase = "none"  // 不转换
+	TextCaseUpper TextCase = "upper" // 全部大写
+	TextCaseLower TextCase = "lower" // 全部小写
+)
+
+// SystemFontFamily 表示使用系统字体的字体家族名称
+const SystemFontFamily = "system"
+
+// TextStyle 文字样式令牌
+// 字号和行高的单位为pt（Android中对应sp），字间距的单位为em（字号的倍数）
+type TextStyle struct {
+	Name          string   // 名称，如 body、title_large
+	FontFamily    string   // 字体家族名称，为空时使用系统字体
+	Weight        int      // 字重 100-900
+	Size          float64  // 字号
+	LineHeight    float64  // 行高，为0时使用字体默认行高
+	LetterSpacing float64  // 字间距（em，字号的倍数）
+	TextCase      TextCase // 大小写转换
+
+	IOSTextStyle string // Dynamic Type缩放参照的文字样式，如 body、title1
+
+	Description string // 说明文字
+	Comment     string // 样式前的注释
+	Section     string // 所属分组（来自YAML中的分组注释块）
+}
+
+// Doc 获取说明文字，优先使用description字段，其次使用YAML注释
+func (s *TextStyle) Doc() string {
+	if s.Description != "" {
+		return s.Description
+	}
+	return s.Comment
+}
+
+// IsSystemFont 判断是否使用系统字体
+func (s *TextStyle) IsSystemFont() bool {
+	return s.FontFamily == ""
+}
+
+// LetterSpacingPoints 获取以pt（sp）为单位的字间距
+func (s *TextStyle) LetterSpacingPoints() float64 {
+	return s.LetterSpacing * s.Size
+}
+
+// iosTextStyle Dynamic Type文字样式及其在默认内容尺寸下的字号
+type iosTextStyle struct {
+	Name string
+	Size float64
+}
+
+// iosTextStyles iOS Dynamic Type文字样式（Large内容尺寸下的默认字号），按字号从大到小排列
+var iosTextStyles = []iosTextStyle{
+	{"largeTitle", 34},
+	{"title1", 28},
+	{"title2", 22},
+	{"title3", 20},
+	{"headline", 17},
+	{"body", 17},
+	{"callout", 16},
+	{"subheadline", 15},
+	{"footnote", 13},
+	{"caption1", 12},
+	{"caption2", 11},
+}
+
+// inferIOSTextStyle 按字号推断最接近的Dynamic Type文字样式，17pt的粗体推断为headline
+func inferIOSTextStyle(size float64, weight int) string {
+	best := iosTextStyles[0]
+	for _, style := range iosTextStyles[1:] {
+		if math.Abs(style.Size-size) < math.Abs(best.Size-size) {
+			best = style
+		}
+	}
+	if best.Name == "headline" && weight < 600 {
+		return "body"
+	}
+	return best.Name
+}
+
+// weightNames 字重名称到数值的映射
+var weightNames = map[string]int{
+	"thin":       100,
+	"extralight": 200,
+	"ultralight": 200,
+	"light":      300,
+	"regular":    400,
+	"normal":     400,
+	"medium":     500,
+	"semibold":   600,
+	"demibold":   600,
+	"bold":       700,
+	"extrabold":  800,
+	"ultrabold":  800,
+	"heavy":      800,
+	"black":      900,
+}
+
+// formatNumber 格式化数值，最多保留decimals位小数并去除多余的0
+func formatNumber(value float64, decimals int) string {
+	scale := math.Pow(10, float64(decimals))
+	value = math.Round(value*scale) / scale
+	if value == 0 {
+		value = 0 // 避免输出 -0
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,229 @@
+package xcassets
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// identityKeys 用于识别同一个槽位的字段（如同一倍数、同一设备类型、同一外观的条目）
+var identityKeys = []string{
+	"idiom", "scale", "subtype", "appearances", "width-class", "height-class",
+	"display-gamut", "locale", "language-direction", "size", "role", "platform",
+}
+
+// ownedKeys 由生成器决定的字段：合并时以生成值为准，生成值中没有时也从已有条目中删除
+// 如槽位本次没有图片时，旧的filename不能保留；深色值改为显式设置后，旧的derived-dark标注不能保留
+var ownedKeys = append([]string{"filename", "color", "derived-dark"}, identityKeys...)
+
+// assetExtensions 图片集中的资源文件扩展名
+var assetExtensions = []string{".png", ".jpg", ".jpeg", ".pdf", ".svg", ".heic"}
+
+// defaultInfo Xcode生成的info字段
+var defaultInfo = map[string]interface{}{
+	"author":  "xcode",
+	"version": 1,
+}
+
+// EnsureCatalog 确保资源目录存在，缺少根Contents.json时创建
+func EnsureCatalog(catalogPath string) error {
+	if err := os.MkdirAll(catalogPath, 0755); err != nil {
+		return fmt.Errorf("创建资源目录失败: %w", err)
+	}
+
+	return ensureFolderContents(catalogPath)
+}
+
+// EnsureFolder 确保资源目录内的子文件夹存在，每一级都带有Contents.json，返回文件夹完整路径
+func EnsureFolder(catalogPath, folder string) (string, error) {
+	current := catalogPath
+	for _, part := range strings.Split(filepath.ToSlash(folder), "/") {
+		if part == "" || part == "." {
+			continue
+		}
+		current = filepath.Join(current, part)
+		if err := os.MkdirAll(current, 0755); err != nil {
+			return "", fmt.Errorf("创建文件夹 %s 失败: %w", current, err)
+		}
+		if err := ensureFolderContents(current); err != nil {
+			return "", err
+		}
+	}
+
+	return current, nil
+}
+
+// ensureFolderContents 缺少Contents.json时写入只包含info的默认内容
+func ensureFolderContents(dirPath string) error {
+	contentsPath := filepath.Join(dirPath, "Contents.json")
+	if _, err := os.Stat(contentsPath); err == nil {
+		return nil
+	}
+
+	return WriteContents(contentsPath, map[string]interface{}{"info": defaultInfo}, false)
+}
+
+// WriteContents 写入Contents.json
+// merge为true且文件已存在时，保留已有文件中未知的字段（如properties、压缩设置），生成的字段覆盖同名字段，
+// 文件名、颜色和槽位标识等生成器管理的字段完全以生成值为准
+func WriteContents(contentsPath string, contents interface{}, merge bool) error {
+	data, err := json.Marshal(contents)
+	if err != nil {
+		return fmt.Errorf("序列化JSON失败: %w", err)
+	}
+
+	if merge {
+		existingData, err := os.ReadFile(contentsPath)
+		if err == nil {
+			var existing, generated interface{}
+			if err := json.Unmarshal(existingData, &existing); err != nil {
+				return fmt.Errorf("解析已有的 %s 失败: %w", contentsPath, err)
+			}
+			if err := json.Unmarshal(data, &generated); err != nil {
+				return fmt.Errorf("解析JSON失败: %w", err)
+			}
+			if data, err = json.Marshal(mergeValue(existing, generated)); err != nil {
+				return fmt.Errorf("序列化JSON失败: %w", err)
+			}
+		} else if !os.IsNotExist(err) {
+			return fmt.Errorf("读取已有的 %s 失败: %w", contentsPath, err)
+		}
+	}
+
+	// 格式化输出
+	var formatted bytes.Buffer
+	if err := json.Indent(&formatted, data, "", "  "); err != nil {
+		return fmt.Errorf("格式化JSON失败: %w", err)
+	}
+	formatted.WriteString("\n")
+
+	if err := os.WriteFile(contentsPath, formatted.Bytes(), 0644); err != nil {
+		return fmt.Errorf("写入 %s 失败: %w", contentsPath, err)
+	}
+
+	return nil
+}
+
+// mergeValue 合并已有值和生成值
+// 对象逐字段合并，生成器管理的字段直接使用生成值，不保留其中的旧字段；对象数组按槽位匹配后合并，未匹配的旧条目被丢弃；
+// 其它类型以生成值为准
+func mergeValue(existing, generated interface{}) interface{} {
+	switch gen := generated.(type) {
+	case map[string]interface{}:
+		old, ok := existing.(map[string]interface{})
+		if !ok {
+			return gen
+		}
+		result := make(map[string]interface{}, len(old)+len(gen))
+		for key, value := range old {
+			if !slices.Contains(ownedKeys, key) {
+				result[key] = value
+			}
+		}
+		for key, value := range gen {
+			if slices.Contains(ownedKeys, key) {
+				result[key] = value
+			} else {
+				result[key] = mergeValue(old[key], value)
+			}
+		}
+		return result
+	case []interface{}:
+		old, ok := existing.([]interface{})
+		if !ok {
+			return gen
+		}
+		used := make([]bool, len(old))
+		result := make([]interface{}, 0, len(gen))
+		for _, item := range gen {
+			merged := item
+			for i, candidate := range old {
+				if !used[i] && sameSlot(candidate, item) {
+					used[i] = true
+					merged = mergeValue(candidate, item)
+					break
+				}
+			}
+			result = append(result, merged)
+		}
+		return result
+	default:
+		return gen
+	}
+}
+
+// sameSlot 判断两个数组条目是否属于同一个槽位
+func sameSlot(a, b interface{}) bool {
+	mapA, okA := a.(map[string]interface{})
+	mapB, okB := b.(map[string]interface{})
+	if !okA || !okB {
+		return false
+	}
+
+	keyA, _ := json.Marshal(slotIdentity(mapA))
+	keyB, _ := json.Marshal(slotIdentity(mapB))
+	return bytes.Equal(keyA, keyB)
+}
+
+// slotIdentity 提取条目的槽位标识字段
+func slotIdentity(entry map[string]interface{}) map[string]interface{} {
+	identity := make(map[string]interface{})
+	for _, key := range identityKeys {
+		if value, ok := entry[key]; ok {
+			identity[key] = value
+		}
+	}
+	return identity
+}
+
+// RemoveUnreferenced 删除资源集目录中没有被Contents.json引用的资源文件
+// 用于删除之前生成、但槽位本次已清空或不再存在的图片
+func RemoveUnreferenced(setPath string) error {
+	data, err := os.ReadFile(filepath.Join(setPath, "Contents.json"))
+	if err != nil {
+		return fmt.Errorf("读取 %s 失败: %w", filepath.Join(setPath, "Contents.json"), err)
+	}
+	var contents interface{}
+	if err := json.Unmarshal(data, &contents); err != nil {
+		return fmt.Errorf("解析 %s 失败: %w", filepath.Join(setPath, "Contents.json"), err)
+	}
+	referenced := make(map[string]bool)
+	collectFilenames(contents, referenced)
+
+	entries, err := os.ReadDir(setPath)
+	if err != nil {
+		return fmt.Errorf("读取目录 %s 失败: %w", setPath, err)
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || referenced[name] || !slices.Contains(assetExtensions, strings.ToLower(filepath.Ext(name))) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(setPath, name)); err != nil {
+			return fmt.Errorf("删除不再引用的文件 %s 失败: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// collectFilenames 收集所有filename字段的值
+func collectFilenames(value interface{}, filenames map[string]bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if name, ok := child.(string); ok && key == "filename" {
+				filenames[name] = true
+				continue
+			}
+			collectFilenames(child, filenames)
+		}
+	case []interface{}:
+		for _, child := range v {
+			collectFilenames(child, filenames)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package xcassets
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// decodeJSON 解析测试用的JSON文本
+func decodeJSON(t *testing.T, text string) interface{} {
+	t.Helper()
+	var value interface{}
+	if err := json.Unmarshal([]byte(text), &value); err != nil {
+		t.Fatalf("解析JSON失败: %v\n%s", err, text)
+	}
+	return value
+}
+
+func TestMergeValue(t *testing.T) {
+	tests := []struct {
+		name      string
+		existing  string
+		generated string
+		want      string
+	}{
+		{
+			name:      "保留未知字段",
+			existing:  `{"info": {"author": "xcode", "version": 1}, "properties": {"localizable": true}}`,
+			generated: `{"info": {"author": "generator", "version": 1}}`,
+			want:      `{"info": {"author": "generator", "version": 1}, "properties": {"localizable": true}}`,
+		},
+		{
+			name: "颜色中的旧字段不保留",
+			existing: `{"colors": [{"idiom": "universal", "color": {
+				"color-space": "display-p3", "reference": "systemBlue",
+				"components": {"red": "0.1", "green": "0.2", "blue": "0.3", "alpha": "1.000", "white": "0.5"}}}]}`,
+			generated: `{"colors": [{"idiom": "universal", "color": {
+				"color-space": "srgb",
+				"components": {"red": "0x33", "green": "0x66", "blue": "0x99", "alpha": "1.000"}}}]}`,
+			want: `{"colors": [{"idiom": "universal", "color": {
+				"color-space": "srgb",
+				"components": {"red": "0x33", "green": "0x66", "blue": "0x99", "alpha": "1.000"}}}]}`,
+		},
+		{
+			name: "按槽位合并数组条目",
+			existing: `{"images": [
+				{"idiom": "universal", "scale": "2x", "filename": "old@2x.png", "compression-type": "lossless"},
+				{"idiom": "universal", "scale": "1x", "filename": "old.png"}]}`,
+			generated: `{"images": [
+				{"idiom": "universal", "scale": "1x", "filename": "icon.png"},
+				{"idiom": "universal", "scale": "2x", "filename": "icon@2x.png"}]}`,
+			want: `{"images": [
+				{"idiom": "universal", "scale": "1x", "filename": "icon.png"},
+				{"idiom": "universal", "scale": "2x", "filename": "icon@2x.png", "compression-type": "lossless"}]}`,
+		},
+		{
+			name:      "槽位清空时删除旧文件名",
+			existing:  `{"images": [{"idiom": "universal", "scale": "3x", "filename": "icon@3x.png"}]}`,
+			generated: `{"images": [{"idiom": "universal", "scale": "3x"}]}`,
+			want:      `{"images": [{"idiom": "universal", "scale": "3x"}]}`,
+		},
+		{
+			name: "丢弃不再生成的槽位",
+			existing: `{"images": [
+				{"idiom": "universal", "scale": "1x", "filename": "icon.png"},
+				{"idiom": "universal", "appearances": [{"appearance": "luminosity", "value": "dark"}], "scale": "1x", "filename": "icon_dark.png"}]}`,
+			generated: `{"images": [{"idiom": "universal", "scale": "1x", "filename": "icon.png"}]}`,
+			want:      `{"images": [{"idiom": "universal", "scale": "1x", "filename": "icon.png"}]}`,
+		},
+		{
+			name:      "类型不同时使用生成值",
+			existing:  `{"properties": "invalid"}`,
+			generated: `{"properties": {"preserves-vector-representation": true}}`,
+			want:      `{"properties": {"preserves-vector-representation": true}}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := mergeValue(decodeJSON(t, test.existing), decodeJSON(t, test.generated))
+			if want := decodeJSON(t, test.want); !reflect.DeepEqual(got, want) {
+				gotJSON, _ := json.Marshal(got)
+				wantJSON, _ := json.Marshal(want)
+				t.Errorf("合并结果为\n%s\n应为\n%s", gotJSON, wantJSON)
+			}
+		})
+	}
+}
+
+func TestWriteContentsMerge(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "Contents.json")
+	existing := `{"colors": [{"idiom": "universal", "color": {"reference": "systemRed"}}], "properties": {"localizable": true}}`
+	if err := os.WriteFile(path, []byte(existing), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	generated := map[string]interface{}{
+		"colors": []map[string]interface{}{
+			{"idiom": "universal", "color": map[string]interface{}{"color-space": "srgb"}},
+		},
+	}
+	if err := WriteContents(path, generated, true); err != nil {
+		t.Fatalf("WriteContents() 失败: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"colors": [{"idiom": "universal", "color": {"color-space": "srgb"}}], "properties": {"localizable": true}}`
+	if got := decodeJSON(t, string(data)); !reflect.DeepEqual(got, decodeJSON(t, want)) {
+		t.Errorf("写入的内容为\n%s", data)
+	}
+
+	// 已有文件无法解析时报错，不覆盖
+	if err := os.WriteFile(path, []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteContents(path, generated, true); err == nil {
+		t.Errorf("已有的Contents.json无效时应报错")
+	}
+}
+
+func TestEnsureFolder(t *testing.T) {
+	catalog := filepath.Join(t.TempDir(), "Assets.xcassets")
+	if err := EnsureCatalog(catalog); err != nil {
+		t.Fatalf("EnsureCatalog() 失败: %v", err)
+	}
+	folder, err := EnsureFolder(catalog, "Brand/./Colors/")
+	if err != nil {
+		t.Fatalf("EnsureFolder() 失败: %v", err)
+	}
+	if want := filepath.Join(catalog, "Brand", "Colors"); folder != want {
+		t.Errorf("文件夹为 %s，应为 %s", folder, want)
+	}
+	for _, dir := range []string{catalog, filepath.Join(catalog, "Brand"), folder} {
+		if _, err := os.Stat(filepath.Join(dir, "Contents.json")); err != nil {
+			t.Errorf("%s 缺少Contents.json", dir)
+		}
+	}
+}
+
+func TestRemoveUnreferenced(t *testing.T) {
+	setPath := t.TempDir()
+	files := map[string]string{
+		"Contents.json": `{"images": [{"idiom": "universal", "scale": "1x", "filename": "icon.png"},
+			{"idiom": "universal", "scale": "2x", "filename": "icon@2x.PNG"}]}`,
+		"icon.png":    "",
+		"icon@2x.PNG": "",
+		"icon@3x.png": "", // 槽位已清空
+		"old.pdf":     "", // 图片已改名
+		"notes.txt":   "", // 不是资源文件
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(setPath, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := RemoveUnreferenced(setPath); err != nil {
+		t.Fatalf("RemoveUnreferenced() 失败: %v", err)
+	}
+
+	entries, err := os.ReadDir(setPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var remaining []string
+	for _, entry := range entries {
+		remaining = append(remaining, entry.Name())
+	}
+	want := []string{"Contents.json", "icon.png", "icon@2x.PNG", "notes.txt"}
+	slices.Sort(remaining)
+	slices.Sort(want)
+	if !slices.Equal(remaining, want) {
+		t.Errorf("剩余文件为 %v，应为 %v", remaining, want)
+	}
+}